@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+	"github.com/keptn-contrib/dynatrace-service/internal/monitoring"
+)
+
+const cleanupCommand = "cleanup"
+
+// runCleanup deletes the Dynatrace entities generated for a Keptn project or stage and returns the exit code.
+// Usage: dynatrace-service cleanup -project <project> [-stage <stage>] [-dt-creds <secret>] [-config-api <api>] [-dry-run]
+func runCleanup(args []string) int {
+	flags := flag.NewFlagSet(cleanupCommand, flag.ContinueOnError)
+	project := flags.String("project", "", "Keptn project whose Dynatrace entities should be deleted (required)")
+	stage := flags.String("stage", "", "only delete the Dynatrace entities of this stage")
+	credentialsSecretName := flags.String("dt-creds", "dynatrace", "name of the secret containing the Dynatrace credentials")
+	configAPI := flags.String("config-api", dynatrace.ConfigAPIVersion1, "API used to manage configuration entities: v1, settings or auto")
+	dryRun := flags.Bool("dry-run", false, "only report the Dynatrace entities that would be deleted")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if *project == "" {
+		fmt.Fprintln(flags.Output(), "flag -project is required")
+		flags.Usage()
+		return 2
+	}
+
+	report, err := monitoring.CleanupWithCredentials(context.Background(), *credentialsSecretName, *configAPI, *project, *stage, *dryRun)
+	if err != nil {
+		log.WithError(err).Error("Could not clean up Dynatrace entities")
+		return 1
+	}
+
+	fmt.Fprint(os.Stdout, report.String())
+	if report.HasErrors() {
+		return 1
+	}
+	return 0
+}
@@ -6,14 +6,22 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"sync"
 	"syscall"
+	"time"
 
 	context2 "github.com/keptn-contrib/dynatrace-service/internal/context"
+	"github.com/keptn-contrib/dynatrace-service/internal/deduplication"
 	"github.com/keptn-contrib/dynatrace-service/internal/env"
 	"github.com/keptn-contrib/dynatrace-service/internal/event_handler"
 	"github.com/keptn-contrib/dynatrace-service/internal/keptn"
+	"github.com/keptn-contrib/dynatrace-service/internal/metrics"
+	"github.com/keptn-contrib/dynatrace-service/internal/monitoring"
 	"github.com/keptn-contrib/dynatrace-service/internal/onboard"
+	"github.com/keptn-contrib/dynatrace-service/internal/outbox"
+	"github.com/keptn-contrib/dynatrace-service/internal/tracing"
+	"github.com/keptn-contrib/dynatrace-service/internal/worker"
 
 	api "github.com/keptn/go-utils/pkg/api/utils"
 	eventsource "github.com/keptn/go-utils/pkg/sdk/connector/eventsource/nats"
@@ -38,6 +46,9 @@ type dynatraceService struct {
 
 func main() {
 	log.SetLevel(env.GetLogLevel())
+	if len(os.Args) > 1 && os.Args[1] == cleanupCommand {
+		os.Exit(runCleanup(os.Args[2:]))
+	}
 	os.Exit(_main())
 }
 
@@ -48,9 +59,37 @@ func _main() int {
 		keptnapi.RunHealthEndpoint("8070")
 	}()
 
+	outboxStore, err := outbox.NewDefaultStore()
+	if err != nil {
+		log.WithError(err).Fatal("Could not create outbox store")
+	}
+
+	// start metrics endpoint
+	go func() {
+		metrics.RunEndpoint(strconv.Itoa(env.GetMetricsPort()))
+	}()
+
+	// start admin endpoint listing the pending outbox entries
+	go func() {
+		outbox.RunAdminEndpoint(strconv.Itoa(env.GetOutboxAdminPort()), outboxStore)
+	}()
+
 	// root context
 	ctx := context.Background()
 
+	shutdownTracing, err := tracing.Init(ctx)
+	if err != nil {
+		log.WithError(err).Error("Could not initialize tracing")
+	} else {
+		defer func() {
+			shutdownCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+			defer cancel()
+			if err := shutdownTracing(shutdownCtx); err != nil {
+				log.WithError(err).Error("Could not shut down tracing")
+			}
+		}()
+	}
+
 	// notifyCtx is done when a termination signal is received
 	notifyCtx, stopNotify := signal.NotifyContext(ctx,
 		os.Interrupt,
@@ -68,9 +107,7 @@ func _main() int {
 
 	workerWaitGroup := &sync.WaitGroup{}
 	if env.IsServiceSyncEnabled() {
-		workerWaitGroup.Add(1)
-		go func() {
-			defer workerWaitGroup.Done()
+		startWorker(workerWaitGroup, func() {
 			serviceSynchronizer, err := onboard.NewDefaultServiceSynchronizer()
 			if err != nil {
 				log.WithError(err).Error("Could not create service synchronizer")
@@ -78,7 +115,25 @@ func _main() int {
 			}
 
 			serviceSynchronizer.Run(notifyCtx, workCtx)
-		}()
+		})
+	}
+
+	if env.IsMonitoringReconciliationEnabled() {
+		startWorker(workerWaitGroup, func() {
+			reconciler, err := monitoring.NewDefaultReconciler()
+			if err != nil {
+				log.WithError(err).Error("Could not create reconciler")
+				return
+			}
+
+			reconciler.Run(notifyCtx, workCtx)
+		})
+	}
+
+	if outboxStore != nil {
+		startWorker(workerWaitGroup, func() {
+			outbox.NewDefaultRelay(outboxStore).Run(notifyCtx)
+		})
 	}
 
 	natsConnector := nats.NewFromEnv()
@@ -95,23 +150,42 @@ func _main() int {
 		}))
 	}()
 
+	deduplicationStore, err := deduplication.NewDefaultStore()
+	if err != nil {
+		log.WithError(err).Fatal("Could not create event deduplication store")
+	}
+	problemWindowStore, err := deduplication.NewDefaultWindowStore()
+	if err != nil {
+		log.WithError(err).Fatal("Could not create problem deduplication window store")
+	}
+
+	// the actual processing is done by a pool of workers so that it doesn't block other events
+	// receiving further events is blocked while the queue of the pool is full
+	eventWorkerPool := worker.NewPool(env.GetEventWorkers(), env.GetEventQueueSize(), env.GetEventMaxWorkersPerProject())
+
 	// register for events
-	// the actual processing is done in a separate goroutine so that it doesn't block other events
 	log.Info("Registering with control plane")
 	err = controlPlane.Register(notifyCtx, dynatraceService{onEvent: func(eventSenderClient *keptn.EventSenderClient, event cloudevents.Event) {
-		workerWaitGroup.Add(1)
-		go func() {
-			defer workerWaitGroup.Done()
-			gotEvent(workCtx, replyCtx, eventSenderClient, event)
-		}()
+		err := eventWorkerPool.Submit(notifyCtx, worker.Task{
+			Priority: event_handler.GetEventPriority(event),
+			Project:  event_handler.GetEventProject(event),
+			Run: func() {
+				gotEvent(workCtx, replyCtx, eventSenderClient, event, deduplicationStore, outboxStore, problemWindowStore)
+			},
+		})
+		if err != nil {
+			log.WithError(err).WithField("eventType", event.Type()).Error("Could not queue event")
+		}
 	}})
 	if err != nil {
 		log.WithError(err).Error("Could not register control plane")
 	}
 
-	// wait for all existing events (i.e. worker go routines to finish)
+	// wait for all existing events (i.e. queued and running tasks) to finish
 	log.Info("Waiting for existing processing to finish")
 	stopNotify()
+	eventWorkerPool.Close()
+	eventWorkerPool.Wait()
 	workerWaitGroup.Wait()
 
 	// TODO: 2022-07-12: Once available, this should be updated to use a context when flushing the connection.
@@ -127,6 +201,17 @@ func _main() int {
 	return 0
 }
 
+// startWorker runs work that is not driven by events in a new goroutine tracked by the wait group and the in-flight workers metric.
+func startWorker(workerWaitGroup *sync.WaitGroup, work func()) {
+	workerWaitGroup.Add(1)
+	metrics.InFlightWorkers.Inc()
+	go func() {
+		defer workerWaitGroup.Done()
+		defer metrics.InFlightWorkers.Dec()
+		work()
+	}()
+}
+
 // OnEvent is called when a new event was received.
 func (d dynatraceService) OnEvent(ctx context.Context, event models.KeptnContextExtendedCE) error {
 	eventSender, ok := ctx.Value(types.EventSenderKey).(controlplane.EventSender)
@@ -172,18 +257,24 @@ func (d dynatraceService) RegistrationData() controlplane.RegistrationData {
 			createEventSubscription("sh.keptn.event.test.finished"),
 			createEventSubscription("sh.keptn.event.evaluation.finished"),
 			createEventSubscription("sh.keptn.event.release.triggered"),
+			createEventSubscription("sh.keptn.event.approval.triggered"),
+			createEventSubscription("sh.keptn.event.approval.finished"),
+			createEventSubscription("sh.keptn.event.rollback.triggered"),
+			createEventSubscription("sh.keptn.event.rollback.finished"),
+			// sequence finished events, i.e. sh.keptn.event.[stage].[sequence].finished, also includes sh.keptn.event.project.delete.finished
+			createEventSubscription("sh.keptn.event.*.*.finished"),
 		},
 	}
 }
 
-func gotEvent(workCtx context.Context, replyCtx context.Context, eventSender *keptn.EventSenderClient, event cloudevents.Event) {
+func gotEvent(workCtx context.Context, replyCtx context.Context, eventSender *keptn.EventSenderClient, event cloudevents.Event, deduplicationStore deduplication.Store, outboxStore outbox.Store, problemWindowStore deduplication.WindowStore) {
 	clientFactory, err := keptn.NewClientFactory()
 	if err != nil {
 		log.WithError(err).Error("Could not create a Keptn client factory")
 		return
 	}
 
-	handler, err := event_handler.NewEventHandler(workCtx, clientFactory, eventSender, event)
+	handler, err := event_handler.NewEventHandler(workCtx, clientFactory, eventSender, event, deduplicationStore, outboxStore, problemWindowStore)
 	if err != nil {
 		log.WithError(err).Error("NewEventHandler() returned an error")
 		return
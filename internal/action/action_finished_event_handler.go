@@ -7,6 +7,7 @@ import (
 	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/keptn-contrib/dynatrace-service/internal/config"
 	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
 	"github.com/keptn-contrib/dynatrace-service/internal/keptn"
 )
@@ -14,19 +15,23 @@ import (
 type ActionFinishedEventHandler struct {
 	event            ActionFinishedAdapterInterface
 	dtClient         dynatrace.ClientInterface
+	eventsClient     dynatrace.EventsClientInterface
 	eClient          keptn.EventClientInterface
 	bridgeURLCreator keptn.BridgeURLCreatorInterface
 	attachRules      *dynatrace.AttachRules
+	eventTemplate    *config.EventTemplate
 }
 
 // NewActionFinishedEventHandler creates a new ActionFinishedEventHandler
-func NewActionFinishedEventHandler(event ActionFinishedAdapterInterface, dtClient dynatrace.ClientInterface, eClient keptn.EventClientInterface, bridgeURLCreator keptn.BridgeURLCreatorInterface, attachRules *dynatrace.AttachRules) *ActionFinishedEventHandler {
+func NewActionFinishedEventHandler(event ActionFinishedAdapterInterface, dtClient dynatrace.ClientInterface, eventsClient dynatrace.EventsClientInterface, eClient keptn.EventClientInterface, bridgeURLCreator keptn.BridgeURLCreatorInterface, attachRules *dynatrace.AttachRules, eventTemplate *config.EventTemplate) *ActionFinishedEventHandler {
 	return &ActionFinishedEventHandler{
 		event:            event,
 		dtClient:         dtClient,
+		eventsClient:     eventsClient,
 		eClient:          eClient,
 		bridgeURLCreator: bridgeURLCreator,
 		attachRules:      attachRules,
+		eventTemplate:    eventTemplate,
 	}
 }
 
@@ -48,34 +53,41 @@ func (eh *ActionFinishedEventHandler) HandleEvent(workCtx context.Context, _ con
 		eh.event.GetStatus())
 	dynatrace.NewProblemsClient(eh.dtClient).AddProblemComment(workCtx, pid, comment)
 
+	if !isEventTemplateEnabled(eh.eventTemplate, eh.event) {
+		return nil
+	}
+
 	if eh.attachRules == nil {
 		eh.attachRules = createDefaultAttachRules(eh.event)
 	}
 
 	// https://github.com/keptn-contrib/dynatrace-service/issues/174
 	// Additionally to the problem comment, send Info or Configuration Change Event to the entities in Dynatrace to indicate that remediation actions have been executed
-	customProperties := newCustomProperties(eh.event, eh.eClient.GetImageAndTag(workCtx, eh.event), bridgeURL)
+	imageAndTag := eh.eClient.GetImageAndTag(workCtx, eh.event)
+	template := newEventTemplate(eh.eventTemplate, eh.event, imageAndTag, bridgeURL)
+	customProperties := newCustomProperties(eh.event, imageAndTag, bridgeURL)
+	template.addProperties(customProperties)
 	if eh.event.GetStatus() == keptnv2.StatusSucceeded {
 		configurationEvent := dynatrace.ConfigurationEvent{
 			EventType:        dynatrace.ConfigurationEventType,
-			Description:      "Keptn Remediation Action Finished",
+			Description:      template.getTitle("Keptn Remediation Action Finished"),
 			Source:           eventSource,
-			Configuration:    "successful",
+			Configuration:    template.getDescription("successful"),
 			CustomProperties: customProperties,
 			AttachRules:      *eh.attachRules,
 		}
 
-		return dynatrace.NewEventsClient(eh.dtClient).AddConfigurationEvent(workCtx, configurationEvent)
+		return eh.eventsClient.AddConfigurationEvent(workCtx, configurationEvent)
 	}
 
 	infoEvent := dynatrace.InfoEvent{
 		EventType:        dynatrace.InfoEventType,
 		Source:           eventSource,
-		Title:            "Keptn Remediation Action Finished",
-		Description:      "error during execution",
+		Title:            template.getTitle("Keptn Remediation Action Finished"),
+		Description:      template.getDescription("error during execution"),
 		CustomProperties: customProperties,
 		AttachRules:      *eh.attachRules,
 	}
 
-	return dynatrace.NewEventsClient(eh.dtClient).AddInfoEvent(workCtx, infoEvent)
+	return eh.eventsClient.AddInfoEvent(workCtx, infoEvent)
 }
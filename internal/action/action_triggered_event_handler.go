@@ -7,6 +7,7 @@ import (
 
 	log "github.com/sirupsen/logrus"
 
+	"github.com/keptn-contrib/dynatrace-service/internal/config"
 	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
 	"github.com/keptn-contrib/dynatrace-service/internal/keptn"
 )
@@ -14,19 +15,23 @@ import (
 type ActionTriggeredEventHandler struct {
 	event            ActionTriggeredAdapterInterface
 	dtClient         dynatrace.ClientInterface
+	eventsClient     dynatrace.EventsClientInterface
 	eClient          keptn.EventClientInterface
 	bridgeURLCreator keptn.BridgeURLCreatorInterface
 	attachRules      *dynatrace.AttachRules
+	eventTemplate    *config.EventTemplate
 }
 
 // NewActionTriggeredEventHandler creates a new ActionTriggeredEventHandler
-func NewActionTriggeredEventHandler(event ActionTriggeredAdapterInterface, dtClient dynatrace.ClientInterface, eClient keptn.EventClientInterface, bridgeURLCreator keptn.BridgeURLCreatorInterface, attachRules *dynatrace.AttachRules) *ActionTriggeredEventHandler {
+func NewActionTriggeredEventHandler(event ActionTriggeredAdapterInterface, dtClient dynatrace.ClientInterface, eventsClient dynatrace.EventsClientInterface, eClient keptn.EventClientInterface, bridgeURLCreator keptn.BridgeURLCreatorInterface, attachRules *dynatrace.AttachRules, eventTemplate *config.EventTemplate) *ActionTriggeredEventHandler {
 	return &ActionTriggeredEventHandler{
 		event:            event,
 		dtClient:         dtClient,
+		eventsClient:     eventsClient,
 		eClient:          eClient,
 		bridgeURLCreator: bridgeURLCreator,
 		attachRules:      attachRules,
+		eventTemplate:    eventTemplate,
 	}
 }
 
@@ -52,20 +57,29 @@ func (eh *ActionTriggeredEventHandler) HandleEvent(workCtx context.Context, _ co
 
 	dynatrace.NewProblemsClient(eh.dtClient).AddProblemComment(workCtx, pid, comment)
 
+	if !isEventTemplateEnabled(eh.eventTemplate, eh.event) {
+		return nil
+	}
+
 	if eh.attachRules == nil {
 		eh.attachRules = createDefaultAttachRules(eh.event)
 	}
 
+	imageAndTag := eh.eClient.GetImageAndTag(workCtx, eh.event)
+	template := newEventTemplate(eh.eventTemplate, eh.event, imageAndTag, bridgeURL)
+	customProperties := newCustomProperties(eh.event, imageAndTag, bridgeURL)
+	template.addProperties(customProperties)
+
 	// https://github.com/keptn-contrib/dynatrace-service/issues/174
 	// In addition to the problem comment, send Info and Configuration Change Event to the entities in Dynatrace to indicate that remediation actions have been executed
 	infoEvent := dynatrace.InfoEvent{
 		EventType:        dynatrace.InfoEventType,
 		Source:           eventSource,
-		Title:            "Keptn Remediation Action Triggered",
-		Description:      eh.event.GetAction(),
-		CustomProperties: newCustomProperties(eh.event, eh.eClient.GetImageAndTag(workCtx, eh.event), bridgeURL),
+		Title:            template.getTitle("Keptn Remediation Action Triggered"),
+		Description:      template.getDescription(eh.event.GetAction()),
+		CustomProperties: customProperties,
 		AttachRules:      *eh.attachRules,
 	}
 
-	return dynatrace.NewEventsClient(eh.dtClient).AddInfoEvent(workCtx, infoEvent)
+	return eh.eventsClient.AddInfoEvent(workCtx, infoEvent)
 }
@@ -0,0 +1,102 @@
+package action
+
+import (
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/keptn-contrib/dynatrace-service/internal/adapter"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+)
+
+type ApprovalFinishedAdapterInterface interface {
+	adapter.EventContentAdapter
+
+	GetResult() keptnv2.ResultType
+	GetStatus() keptnv2.StatusType
+	GetMessage() string
+}
+
+// ApprovalFinishedAdapter is a content adaptor for events of type sh.keptn.event.approval.finished
+type ApprovalFinishedAdapter struct {
+	event      keptnv2.ApprovalFinishedEventData
+	cloudEvent adapter.CloudEventAdapter
+}
+
+// NewApprovalFinishedAdapterFromEvent creates a new ApprovalFinishedAdapter from a cloudevents Event
+func NewApprovalFinishedAdapterFromEvent(e cloudevents.Event) (*ApprovalFinishedAdapter, error) {
+	ceAdapter := adapter.NewCloudEventAdapter(e)
+
+	afData := &keptnv2.ApprovalFinishedEventData{}
+	err := ceAdapter.PayloadAs(afData)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ApprovalFinishedAdapter{
+		event:      *afData,
+		cloudEvent: ceAdapter,
+	}, nil
+}
+
+// GetShKeptnContext returns the shkeptncontext
+func (a ApprovalFinishedAdapter) GetShKeptnContext() string {
+	return a.cloudEvent.GetShKeptnContext()
+}
+
+// GetSource returns the source specified in the CloudEvent context
+func (a ApprovalFinishedAdapter) GetSource() string {
+	return a.cloudEvent.GetSource()
+}
+
+// GetEvent returns the event type
+func (a ApprovalFinishedAdapter) GetEvent() string {
+	return keptnv2.GetFinishedEventType(keptnv2.ApprovalTaskName)
+}
+
+// GetProject returns the project
+func (a ApprovalFinishedAdapter) GetProject() string {
+	return a.event.Project
+}
+
+// GetStage returns the stage
+func (a ApprovalFinishedAdapter) GetStage() string {
+	return a.event.Stage
+}
+
+// GetService returns the service
+func (a ApprovalFinishedAdapter) GetService() string {
+	return a.event.Service
+}
+
+// GetDeployment returns the name of the deployment
+func (a ApprovalFinishedAdapter) GetDeployment() string {
+	return ""
+}
+
+// GetTestStrategy returns the used test strategy
+func (a ApprovalFinishedAdapter) GetTestStrategy() string {
+	return ""
+}
+
+// GetDeploymentStrategy returns the used deployment strategy
+func (a ApprovalFinishedAdapter) GetDeploymentStrategy() string {
+	return ""
+}
+
+// GetLabels returns a map of labels
+func (a ApprovalFinishedAdapter) GetLabels() map[string]string {
+	return a.event.Labels
+}
+
+// GetResult returns the result
+func (a ApprovalFinishedAdapter) GetResult() keptnv2.ResultType {
+	return a.event.Result
+}
+
+// GetStatus returns the status
+func (a ApprovalFinishedAdapter) GetStatus() keptnv2.StatusType {
+	return a.event.Status
+}
+
+// GetMessage returns the message
+func (a ApprovalFinishedAdapter) GetMessage() string {
+	return a.event.Message
+}
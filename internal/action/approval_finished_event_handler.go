@@ -0,0 +1,76 @@
+package action
+
+import (
+	"context"
+	"fmt"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/config"
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+	"github.com/keptn-contrib/dynatrace-service/internal/keptn"
+)
+
+// ApprovalFinishedEventHandler handles an approval finished event.
+type ApprovalFinishedEventHandler struct {
+	event            ApprovalFinishedAdapterInterface
+	dtClient         dynatrace.ClientInterface
+	eventsClient     dynatrace.EventsClientInterface
+	eClient          keptn.EventClientInterface
+	bridgeURLCreator keptn.BridgeURLCreatorInterface
+	attachRules      *dynatrace.AttachRules
+	eventTemplate    *config.EventTemplate
+}
+
+// NewApprovalFinishedEventHandler creates a new ApprovalFinishedEventHandler.
+func NewApprovalFinishedEventHandler(event ApprovalFinishedAdapterInterface, dtClient dynatrace.ClientInterface, eventsClient dynatrace.EventsClientInterface, eClient keptn.EventClientInterface, bridgeURLCreator keptn.BridgeURLCreatorInterface, attachRules *dynatrace.AttachRules, eventTemplate *config.EventTemplate) *ApprovalFinishedEventHandler {
+	return &ApprovalFinishedEventHandler{
+		event:            event,
+		dtClient:         dtClient,
+		eventsClient:     eventsClient,
+		eClient:          eClient,
+		bridgeURLCreator: bridgeURLCreator,
+		attachRules:      attachRules,
+		eventTemplate:    eventTemplate,
+	}
+}
+
+// HandleEvent handles an approval finished event.
+func (eh *ApprovalFinishedEventHandler) HandleEvent(workCtx context.Context, _ context.Context) error {
+	if !isEventTemplateEnabled(eh.eventTemplate, eh.event) {
+		return nil
+	}
+
+	imageAndTag := eh.eClient.GetImageAndTag(workCtx, eh.event)
+	attachRules := createAttachRulesForDeploymentTimeFrame(workCtx, eh.dtClient, eh.eClient, eh.event, imageAndTag, eh.attachRules)
+
+	bridgeURL := eh.bridgeURLCreator.TryGetBridgeURLForKeptnContext(workCtx, eh.event)
+	template := newEventTemplate(eh.eventTemplate, eh.event, imageAndTag, bridgeURL)
+	customProperties := newCustomProperties(eh.event, imageAndTag, bridgeURL)
+	customProperties.addIfNonEmpty(messageKey, eh.event.GetMessage())
+	template.addProperties(customProperties)
+
+	outcome := getApprovalOutcome(eh.event.GetResult())
+	infoEvent := dynatrace.InfoEvent{
+		EventType:        dynatrace.InfoEventType,
+		Source:           eventSource,
+		Title:            template.getTitle(fmt.Sprintf("Approval %s in stage %s", outcome, eh.event.GetStage())),
+		Description:      template.getDescription(fmt.Sprintf("Approval of %s in stage %s %s", eh.event.GetService(), eh.event.GetStage(), outcome)),
+		CustomProperties: customProperties,
+		AttachRules:      attachRules,
+	}
+
+	return eh.eventsClient.AddInfoEvent(workCtx, infoEvent)
+}
+
+// getApprovalOutcome describes the outcome of an approval based on the result of the approval finished event.
+func getApprovalOutcome(result keptnv2.ResultType) string {
+	switch result {
+	case keptnv2.ResultPass:
+		return "granted"
+	case keptnv2.ResultFailed:
+		return "rejected"
+	default:
+		return "finished with result " + string(result)
+	}
+}
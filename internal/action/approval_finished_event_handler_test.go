@@ -0,0 +1,92 @@
+package action
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+	"github.com/keptn-contrib/dynatrace-service/internal/keptn"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+)
+
+type approvalFinishedTestSetup struct {
+	t                   *testing.T
+	handler             http.Handler
+	eClient             *eventClientFake
+	customAttachRules   *dynatrace.AttachRules
+	expectedAttachRules dynatrace.AttachRules
+	labels              map[string]string
+}
+
+func (s approvalFinishedTestSetup) createHandlerAndTeardown() (eventHandler, func()) {
+	event := approvalFinishedEventData{
+		baseEventData: baseEventData{
+			context: testKeptnShContext,
+			source:  "bridge",
+			event:   "sh.keptn.event.approval.finished",
+			project: testProject,
+			stage:   testStage,
+			service: testService,
+			labels:  s.labels,
+		},
+		result:  keptnv2.ResultFailed,
+		status:  keptnv2.StatusSucceeded,
+		message: "not during business hours",
+	}
+
+	client, _, teardown := createDynatraceClient(s.t, s.handler)
+
+	return NewApprovalFinishedEventHandler(&event, client, dynatrace.NewEventsClient(client), s.eClient, keptn.NewBridgeURLCreator(newKeptnCredentialsProviderMock()), s.customAttachRules, nil), teardown
+}
+
+func (s approvalFinishedTestSetup) createExpectedDynatraceEvent() dynatrace.InfoEvent {
+	tag := s.eClient.imageAndTag.Tag()
+	properties := customProperties{
+		"Image":         s.eClient.imageAndTag.Image(),
+		"Keptn Service": "bridge",
+		"KeptnContext":  testKeptnShContext,
+		"Keptns Bridge": testKeptnsBridge,
+		"Project":       testProject,
+		"Service":       testService,
+		"Stage":         testStage,
+		"Tag":           tag,
+		"TestStrategy":  "",
+		"Message":       "not during business hours",
+	}
+
+	addLabelsToProperties(s.t, properties, s.labels)
+
+	return dynatrace.InfoEvent{
+		EventType:        "CUSTOM_INFO",
+		Source:           "Keptn dynatrace-service",
+		Title:            "Approval rejected in stage hardening",
+		Description:      "Approval of helloservice in stage hardening rejected",
+		CustomProperties: properties,
+		AttachRules:      s.expectedAttachRules,
+	}
+}
+
+func (s approvalFinishedTestSetup) createEventPayloadContainer() dynatrace.InfoEvent {
+	return dynatrace.InfoEvent{}
+}
+
+type approvalFinishedEventData struct {
+	baseEventData
+
+	result  keptnv2.ResultType
+	status  keptnv2.StatusType
+	message string
+}
+
+func (e *approvalFinishedEventData) GetResult() keptnv2.ResultType {
+	return e.result
+}
+
+func (e *approvalFinishedEventData) GetStatus() keptnv2.StatusType {
+	return e.status
+}
+
+func (e *approvalFinishedEventData) GetMessage() string {
+	return e.message
+}
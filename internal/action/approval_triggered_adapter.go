@@ -0,0 +1,102 @@
+package action
+
+import (
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/keptn-contrib/dynatrace-service/internal/adapter"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+)
+
+type ApprovalTriggeredAdapterInterface interface {
+	adapter.EventContentAdapter
+
+	GetResult() keptnv2.ResultType
+	GetPassApproval() string
+	GetWarningApproval() string
+}
+
+// ApprovalTriggeredAdapter is a content adaptor for events of type sh.keptn.event.approval.triggered
+type ApprovalTriggeredAdapter struct {
+	event      keptnv2.ApprovalTriggeredEventData
+	cloudEvent adapter.CloudEventAdapter
+}
+
+// NewApprovalTriggeredAdapterFromEvent creates a new ApprovalTriggeredAdapter from a cloudevents Event
+func NewApprovalTriggeredAdapterFromEvent(e cloudevents.Event) (*ApprovalTriggeredAdapter, error) {
+	ceAdapter := adapter.NewCloudEventAdapter(e)
+
+	atData := &keptnv2.ApprovalTriggeredEventData{}
+	err := ceAdapter.PayloadAs(atData)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ApprovalTriggeredAdapter{
+		event:      *atData,
+		cloudEvent: ceAdapter,
+	}, nil
+}
+
+// GetShKeptnContext returns the shkeptncontext
+func (a ApprovalTriggeredAdapter) GetShKeptnContext() string {
+	return a.cloudEvent.GetShKeptnContext()
+}
+
+// GetSource returns the source specified in the CloudEvent context
+func (a ApprovalTriggeredAdapter) GetSource() string {
+	return a.cloudEvent.GetSource()
+}
+
+// GetEvent returns the event type
+func (a ApprovalTriggeredAdapter) GetEvent() string {
+	return keptnv2.GetTriggeredEventType(keptnv2.ApprovalTaskName)
+}
+
+// GetProject returns the project
+func (a ApprovalTriggeredAdapter) GetProject() string {
+	return a.event.Project
+}
+
+// GetStage returns the stage
+func (a ApprovalTriggeredAdapter) GetStage() string {
+	return a.event.Stage
+}
+
+// GetService returns the service
+func (a ApprovalTriggeredAdapter) GetService() string {
+	return a.event.Service
+}
+
+// GetDeployment returns the name of the deployment
+func (a ApprovalTriggeredAdapter) GetDeployment() string {
+	return ""
+}
+
+// GetTestStrategy returns the used test strategy
+func (a ApprovalTriggeredAdapter) GetTestStrategy() string {
+	return ""
+}
+
+// GetDeploymentStrategy returns the used deployment strategy
+func (a ApprovalTriggeredAdapter) GetDeploymentStrategy() string {
+	return ""
+}
+
+// GetLabels returns a map of labels
+func (a ApprovalTriggeredAdapter) GetLabels() map[string]string {
+	return a.event.Labels
+}
+
+// GetResult returns the result of the preceding evaluation
+func (a ApprovalTriggeredAdapter) GetResult() keptnv2.ResultType {
+	return a.event.Result
+}
+
+// GetPassApproval returns the approval strategy if the preceding evaluation passed, i.e. automatic or manual
+func (a ApprovalTriggeredAdapter) GetPassApproval() string {
+	return a.event.Approval.Pass
+}
+
+// GetWarningApproval returns the approval strategy if the preceding evaluation resulted in a warning, i.e. automatic or manual
+func (a ApprovalTriggeredAdapter) GetWarningApproval() string {
+	return a.event.Approval.Warning
+}
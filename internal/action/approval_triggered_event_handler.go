@@ -0,0 +1,60 @@
+package action
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/config"
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+	"github.com/keptn-contrib/dynatrace-service/internal/keptn"
+)
+
+// ApprovalTriggeredEventHandler handles an approval triggered event.
+type ApprovalTriggeredEventHandler struct {
+	event            ApprovalTriggeredAdapterInterface
+	dtClient         dynatrace.ClientInterface
+	eventsClient     dynatrace.EventsClientInterface
+	eClient          keptn.EventClientInterface
+	bridgeURLCreator keptn.BridgeURLCreatorInterface
+	attachRules      *dynatrace.AttachRules
+	eventTemplate    *config.EventTemplate
+}
+
+// NewApprovalTriggeredEventHandler creates a new ApprovalTriggeredEventHandler.
+func NewApprovalTriggeredEventHandler(event ApprovalTriggeredAdapterInterface, dtClient dynatrace.ClientInterface, eventsClient dynatrace.EventsClientInterface, eClient keptn.EventClientInterface, bridgeURLCreator keptn.BridgeURLCreatorInterface, attachRules *dynatrace.AttachRules, eventTemplate *config.EventTemplate) *ApprovalTriggeredEventHandler {
+	return &ApprovalTriggeredEventHandler{
+		event:            event,
+		dtClient:         dtClient,
+		eventsClient:     eventsClient,
+		eClient:          eClient,
+		bridgeURLCreator: bridgeURLCreator,
+		attachRules:      attachRules,
+		eventTemplate:    eventTemplate,
+	}
+}
+
+// HandleEvent handles an approval triggered event.
+func (eh *ApprovalTriggeredEventHandler) HandleEvent(workCtx context.Context, _ context.Context) error {
+	if !isEventTemplateEnabled(eh.eventTemplate, eh.event) {
+		return nil
+	}
+
+	imageAndTag := eh.eClient.GetImageAndTag(workCtx, eh.event)
+	attachRules := createAttachRulesForDeploymentTimeFrame(workCtx, eh.dtClient, eh.eClient, eh.event, imageAndTag, eh.attachRules)
+
+	bridgeURL := eh.bridgeURLCreator.TryGetBridgeURLForKeptnContext(workCtx, eh.event)
+	template := newEventTemplate(eh.eventTemplate, eh.event, imageAndTag, bridgeURL)
+	customProperties := newCustomProperties(eh.event, imageAndTag, bridgeURL)
+	template.addProperties(customProperties)
+
+	infoEvent := dynatrace.InfoEvent{
+		EventType:        dynatrace.InfoEventType,
+		Source:           eventSource,
+		Title:            template.getTitle(fmt.Sprintf("Approval requested in stage %s", eh.event.GetStage())),
+		Description:      template.getDescription(fmt.Sprintf("Approval of %s in stage %s requested after evaluation result %s (pass: %s, warning: %s)", eh.event.GetService(), eh.event.GetStage(), eh.event.GetResult(), eh.event.GetPassApproval(), eh.event.GetWarningApproval())),
+		CustomProperties: customProperties,
+		AttachRules:      attachRules,
+	}
+
+	return eh.eventsClient.AddInfoEvent(workCtx, infoEvent)
+}
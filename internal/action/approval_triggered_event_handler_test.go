@@ -0,0 +1,89 @@
+package action
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+	"github.com/keptn-contrib/dynatrace-service/internal/keptn"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+)
+
+type approvalTriggeredTestSetup struct {
+	t                   *testing.T
+	handler             http.Handler
+	eClient             *eventClientFake
+	customAttachRules   *dynatrace.AttachRules
+	expectedAttachRules dynatrace.AttachRules
+	labels              map[string]string
+}
+
+func (s approvalTriggeredTestSetup) createHandlerAndTeardown() (eventHandler, func()) {
+	event := approvalTriggeredEventData{
+		baseEventData: baseEventData{
+			context: testKeptnShContext,
+			source:  "shipyard-controller",
+			event:   "sh.keptn.event.approval.triggered",
+			project: testProject,
+			stage:   testStage,
+			service: testService,
+			labels:  s.labels,
+		},
+		result:   keptnv2.ResultWarning,
+		approval: keptnv2.Approval{Pass: keptnv2.ApprovalAutomatic, Warning: keptnv2.ApprovalManual},
+	}
+
+	client, _, teardown := createDynatraceClient(s.t, s.handler)
+
+	return NewApprovalTriggeredEventHandler(&event, client, dynatrace.NewEventsClient(client), s.eClient, keptn.NewBridgeURLCreator(newKeptnCredentialsProviderMock()), s.customAttachRules, nil), teardown
+}
+
+func (s approvalTriggeredTestSetup) createExpectedDynatraceEvent() dynatrace.InfoEvent {
+	tag := s.eClient.imageAndTag.Tag()
+	properties := customProperties{
+		"Image":         s.eClient.imageAndTag.Image(),
+		"Keptn Service": "shipyard-controller",
+		"KeptnContext":  testKeptnShContext,
+		"Keptns Bridge": testKeptnsBridge,
+		"Project":       testProject,
+		"Service":       testService,
+		"Stage":         testStage,
+		"Tag":           tag,
+		"TestStrategy":  "",
+	}
+
+	addLabelsToProperties(s.t, properties, s.labels)
+
+	return dynatrace.InfoEvent{
+		EventType:        "CUSTOM_INFO",
+		Source:           "Keptn dynatrace-service",
+		Title:            "Approval requested in stage hardening",
+		Description:      "Approval of helloservice in stage hardening requested after evaluation result warning (pass: automatic, warning: manual)",
+		CustomProperties: properties,
+		AttachRules:      s.expectedAttachRules,
+	}
+}
+
+func (s approvalTriggeredTestSetup) createEventPayloadContainer() dynatrace.InfoEvent {
+	return dynatrace.InfoEvent{}
+}
+
+type approvalTriggeredEventData struct {
+	baseEventData
+
+	result   keptnv2.ResultType
+	approval keptnv2.Approval
+}
+
+func (e *approvalTriggeredEventData) GetResult() keptnv2.ResultType {
+	return e.result
+}
+
+func (e *approvalTriggeredEventData) GetPassApproval() string {
+	return e.approval.Pass
+}
+
+func (e *approvalTriggeredEventData) GetWarningApproval() string {
+	return e.approval.Warning
+}
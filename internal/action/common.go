@@ -14,6 +14,7 @@ import (
 
 const eventSource = "Keptn dynatrace-service"
 const bridgeURLKey = "Keptns Bridge"
+const messageKey = "Message"
 
 const contextless = "CONTEXTLESS"
 
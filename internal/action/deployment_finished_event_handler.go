@@ -8,6 +8,7 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"github.com/keptn-contrib/dynatrace-service/internal/common"
+	"github.com/keptn-contrib/dynatrace-service/internal/config"
 	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
 	"github.com/keptn-contrib/dynatrace-service/internal/keptn"
 )
@@ -16,40 +17,53 @@ import (
 type DeploymentFinishedEventHandler struct {
 	event            DeploymentFinishedAdapterInterface
 	dtClient         dynatrace.ClientInterface
+	eventsClient     dynatrace.EventsClientInterface
 	eClient          keptn.EventClientInterface
 	bridgeURLCreator keptn.BridgeURLCreatorInterface
 	attachRules      *dynatrace.AttachRules
+	eventTemplate    *config.EventTemplate
 }
 
 // NewDeploymentFinishedEventHandler creates a new DeploymentFinishedEventHandler.
-func NewDeploymentFinishedEventHandler(event DeploymentFinishedAdapterInterface, dtClient dynatrace.ClientInterface, eClient keptn.EventClientInterface, bridgeURLCreator keptn.BridgeURLCreatorInterface, attachRules *dynatrace.AttachRules) *DeploymentFinishedEventHandler {
+func NewDeploymentFinishedEventHandler(event DeploymentFinishedAdapterInterface, dtClient dynatrace.ClientInterface, eventsClient dynatrace.EventsClientInterface, eClient keptn.EventClientInterface, bridgeURLCreator keptn.BridgeURLCreatorInterface, attachRules *dynatrace.AttachRules, eventTemplate *config.EventTemplate) *DeploymentFinishedEventHandler {
 	return &DeploymentFinishedEventHandler{
 		event:            event,
 		dtClient:         dtClient,
+		eventsClient:     eventsClient,
 		eClient:          eClient,
 		bridgeURLCreator: bridgeURLCreator,
 		attachRules:      attachRules,
+		eventTemplate:    eventTemplate,
 	}
 }
 
 // HandleEvent handles a deployment finished event.
 func (eh *DeploymentFinishedEventHandler) HandleEvent(workCtx context.Context, _ context.Context) error {
+	if !isEventTemplateEnabled(eh.eventTemplate, eh.event) {
+		return nil
+	}
+
 	imageAndTag := eh.eClient.GetImageAndTag(workCtx, eh.event)
 	attachRules := eh.createAttachRules(workCtx, imageAndTag)
 
+	bridgeURL := eh.bridgeURLCreator.TryGetBridgeURLForKeptnContext(workCtx, eh.event)
+	template := newEventTemplate(eh.eventTemplate, eh.event, imageAndTag, bridgeURL)
+	customProperties := newCustomProperties(eh.event, imageAndTag, bridgeURL)
+	template.addProperties(customProperties)
+
 	deploymentEvent := dynatrace.DeploymentEvent{
 		EventType:         dynatrace.DeploymentEventType,
 		Source:            eventSource,
-		DeploymentName:    getValueFromLabels(eh.event, "deploymentName", "Deploy "+eh.event.GetService()+" "+imageAndTag.Tag()+" with strategy "+eh.event.GetDeploymentStrategy()),
+		DeploymentName:    template.getTitle(getValueFromLabels(eh.event, "deploymentName", "Deploy "+eh.event.GetService()+" "+imageAndTag.Tag()+" with strategy "+eh.event.GetDeploymentStrategy())),
 		DeploymentProject: getValueFromLabels(eh.event, "deploymentProject", eh.event.GetProject()),
 		DeploymentVersion: getValueFromLabels(eh.event, "deploymentVersion", imageAndTag.Tag()),
 		CiBackLink:        getValueFromLabels(eh.event, "ciBackLink", ""),
 		RemediationAction: getValueFromLabels(eh.event, "remediationAction", ""),
-		CustomProperties:  newCustomProperties(eh.event, imageAndTag, eh.bridgeURLCreator.TryGetBridgeURLForKeptnContext(workCtx, eh.event)),
+		CustomProperties:  customProperties,
 		AttachRules:       attachRules,
 	}
 
-	return dynatrace.NewEventsClient(eh.dtClient).AddDeploymentEvent(workCtx, deploymentEvent)
+	return eh.eventsClient.AddDeploymentEvent(workCtx, deploymentEvent)
 }
 
 func (eh *DeploymentFinishedEventHandler) createAttachRules(ctx context.Context, imageAndTag common.ImageAndTag) dynatrace.AttachRules {
@@ -69,7 +69,7 @@ func (s deploymentFinishedTestSetup) createHandlerAndTeardown() (eventHandler, f
 
 	client, _, teardown := createDynatraceClient(s.t, s.handler)
 
-	return NewDeploymentFinishedEventHandler(&event, client, s.eClient, keptn.NewBridgeURLCreator(newKeptnCredentialsProviderMock()), s.customAttachRules), teardown
+	return NewDeploymentFinishedEventHandler(&event, client, dynatrace.NewEventsClient(client), s.eClient, keptn.NewBridgeURLCreator(newKeptnCredentialsProviderMock()), s.customAttachRules, nil), teardown
 }
 
 func (s deploymentFinishedTestSetup) createExpectedDynatraceEvent() dynatrace.DeploymentEvent {
@@ -8,6 +8,7 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"github.com/keptn-contrib/dynatrace-service/internal/common"
+	"github.com/keptn-contrib/dynatrace-service/internal/config"
 	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
 	"github.com/keptn-contrib/dynatrace-service/internal/keptn"
 )
@@ -18,19 +19,23 @@ const evaluationURLKey = "evaluationHeatmapURL"
 type EvaluationFinishedEventHandler struct {
 	event            EvaluationFinishedAdapterInterface
 	dtClient         dynatrace.ClientInterface
+	eventsClient     dynatrace.EventsClientInterface
 	eClient          keptn.EventClientInterface
 	bridgeURLCreator keptn.BridgeURLCreatorInterface
 	attachRules      *dynatrace.AttachRules
+	eventTemplate    *config.EventTemplate
 }
 
 // NewEvaluationFinishedEventHandler creates a new EvaluationFinishedEventHandler.
-func NewEvaluationFinishedEventHandler(event EvaluationFinishedAdapterInterface, client dynatrace.ClientInterface, eClient keptn.EventClientInterface, bridgeURLCreator keptn.BridgeURLCreatorInterface, attachRules *dynatrace.AttachRules) *EvaluationFinishedEventHandler {
+func NewEvaluationFinishedEventHandler(event EvaluationFinishedAdapterInterface, client dynatrace.ClientInterface, eventsClient dynatrace.EventsClientInterface, eClient keptn.EventClientInterface, bridgeURLCreator keptn.BridgeURLCreatorInterface, attachRules *dynatrace.AttachRules, eventTemplate *config.EventTemplate) *EvaluationFinishedEventHandler {
 	return &EvaluationFinishedEventHandler{
 		event:            event,
 		dtClient:         client,
+		eventsClient:     eventsClient,
 		eClient:          eClient,
 		bridgeURLCreator: bridgeURLCreator,
 		attachRules:      attachRules,
+		eventTemplate:    eventTemplate,
 	}
 }
 
@@ -52,22 +57,28 @@ func (eh *EvaluationFinishedEventHandler) HandleEvent(workCtx context.Context, _
 		}
 	}
 
+	if !isEventTemplateEnabled(eh.eventTemplate, eh.event) {
+		return nil
+	}
+
 	imageAndTag := eh.eClient.GetImageAndTag(workCtx, eh.event)
 	attachRules := eh.createAttachRules(workCtx, imageAndTag)
 
+	template := newEventTemplate(eh.eventTemplate, eh.event, imageAndTag, bridgeURL)
 	customProperties := newCustomProperties(eh.event, imageAndTag, bridgeURL)
 	customProperties.addIfNonEmpty(evaluationURLKey, eh.bridgeURLCreator.TryGetBridgeURLForEvaluation(workCtx, eh.event))
+	template.addProperties(customProperties)
 
 	infoEvent := dynatrace.InfoEvent{
 		EventType:        dynatrace.InfoEventType,
 		Source:           eventSource,
-		Title:            eh.getTitle(isPartOfRemediation),
-		Description:      fmt.Sprintf("Quality Gate Result in stage %s: %s (%.2f/100)", eh.event.GetStage(), eh.event.GetResult(), eh.event.GetEvaluationScore()),
+		Title:            template.getTitle(eh.getTitle(isPartOfRemediation)),
+		Description:      template.getDescription(fmt.Sprintf("Quality Gate Result in stage %s: %s (%.2f/100)", eh.event.GetStage(), eh.event.GetResult(), eh.event.GetEvaluationScore())),
 		CustomProperties: customProperties,
 		AttachRules:      attachRules,
 	}
 
-	return dynatrace.NewEventsClient(eh.dtClient).AddInfoEvent(workCtx, infoEvent)
+	return eh.eventsClient.AddInfoEvent(workCtx, infoEvent)
 }
 
 func (eh *EvaluationFinishedEventHandler) getTitle(isPartOfRemediation bool) string {
@@ -37,7 +37,7 @@ func (s evaluationFinishedTestSetup) createHandlerAndTeardown() (eventHandler, f
 
 	client, _, teardown := createDynatraceClient(s.t, s.handler)
 
-	return NewEvaluationFinishedEventHandler(&event, client, s.eClient, keptn.NewBridgeURLCreator(newKeptnCredentialsProviderMock()), s.customAttachRules), teardown
+	return NewEvaluationFinishedEventHandler(&event, client, dynatrace.NewEventsClient(client), s.eClient, keptn.NewBridgeURLCreator(newKeptnCredentialsProviderMock()), s.customAttachRules, nil), teardown
 }
 
 func (s evaluationFinishedTestSetup) createExpectedDynatraceEvent() dynatrace.InfoEvent {
@@ -0,0 +1,110 @@
+package action
+
+import (
+	"strings"
+	"text/template"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/adapter"
+	"github.com/keptn-contrib/dynatrace-service/internal/common"
+	"github.com/keptn-contrib/dynatrace-service/internal/config"
+)
+
+// eventTemplateData is the data user-defined event templates are rendered against.
+type eventTemplateData struct {
+	// Event is the adapter of the Keptn event, allowing access to event specific values, e.g. {{ .Event.GetResult }}.
+	Event        adapter.EventContentAdapter
+	Project      string
+	Stage        string
+	Service      string
+	KeptnContext string
+	Labels       map[string]string
+	Image        string
+	Tag          string
+	BridgeURL    string
+}
+
+// eventTemplate renders the title, description and properties of a Dynatrace event using a user-defined config.EventTemplate, falling back to defaults.
+type eventTemplate struct {
+	template *config.EventTemplate
+	data     eventTemplateData
+}
+
+// newEventTemplate creates a new eventTemplate. If the template is nil, the defaults are always used.
+func newEventTemplate(template *config.EventTemplate, event adapter.EventContentAdapter, imageAndTag common.ImageAndTag, bridgeURL string) eventTemplate {
+	return eventTemplate{
+		template: template,
+		data: eventTemplateData{
+			Event:        event,
+			Project:      event.GetProject(),
+			Stage:        event.GetStage(),
+			Service:      event.GetService(),
+			KeptnContext: event.GetShKeptnContext(),
+			Labels:       event.GetLabels(),
+			Image:        imageAndTag.Image(),
+			Tag:          imageAndTag.Tag(),
+			BridgeURL:    bridgeURL,
+		},
+	}
+}
+
+// isEventTemplateEnabled returns whether the Keptn event should be forwarded to Dynatrace and logs if not.
+func isEventTemplateEnabled(template *config.EventTemplate, event adapter.EventContentAdapter) bool {
+	if template.IsEnabled() {
+		return true
+	}
+
+	log.WithField("eventType", event.GetEvent()).Info("Forwarding of event to Dynatrace is disabled")
+	return false
+}
+
+// getTitle renders the title template or returns the default value if there is none.
+func (t eventTemplate) getTitle(defaultValue string) string {
+	if t.template == nil {
+		return defaultValue
+	}
+	return t.render("title", t.template.Title, defaultValue)
+}
+
+// getDescription renders the description template or returns the default value if there is none.
+func (t eventTemplate) getDescription(defaultValue string) string {
+	if t.template == nil {
+		return defaultValue
+	}
+	return t.render("description", t.template.Description, defaultValue)
+}
+
+// addProperties renders the property templates and adds them to the custom properties, replacing any existing values.
+func (t eventTemplate) addProperties(cp customProperties) {
+	if t.template == nil {
+		return
+	}
+
+	for key, text := range t.template.Properties {
+		value := t.render("property "+key, text, "")
+		if value != "" {
+			cp[key] = value
+		}
+	}
+}
+
+// render renders the template text or returns the default value if the text is empty or cannot be rendered.
+func (t eventTemplate) render(name string, text string, defaultValue string) string {
+	if text == "" {
+		return defaultValue
+	}
+
+	tmpl, err := template.New(name).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		log.WithError(err).WithField("template", name).Error("Could not parse event template, using default")
+		return defaultValue
+	}
+
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, t.data); err != nil {
+		log.WithError(err).WithField("template", name).Error("Could not render event template, using default")
+		return defaultValue
+	}
+	return sb.String()
+}
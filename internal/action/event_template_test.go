@@ -0,0 +1,106 @@
+package action
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/common"
+	"github.com/keptn-contrib/dynatrace-service/internal/config"
+)
+
+func TestEventTemplate(t *testing.T) {
+	event := &baseEventData{
+		context: testKeptnShContext,
+		event:   "sh.keptn.event.test.triggered",
+		project: testProject,
+		stage:   testStage,
+		service: testService,
+		labels:  map[string]string{"owner": "team-a"},
+	}
+	imageAndTag := common.NewImageAndTag("registry/my-image", "1.2.3")
+
+	tests := []struct {
+		name                string
+		eventTemplate       *config.EventTemplate
+		expectedTitle       string
+		expectedDescription string
+		expectedProperties  customProperties
+	}{
+		{
+			name:                "no template uses defaults",
+			eventTemplate:       nil,
+			expectedTitle:       "default title",
+			expectedDescription: "default description",
+			expectedProperties:  customProperties{"Project": testProject},
+		},
+		{
+			name:                "empty template uses defaults",
+			eventTemplate:       &config.EventTemplate{},
+			expectedTitle:       "default title",
+			expectedDescription: "default description",
+			expectedProperties:  customProperties{"Project": testProject},
+		},
+		{
+			name: "templates are rendered",
+			eventTemplate: &config.EventTemplate{
+				Title:       "Tests of {{ .Service }} {{ .Tag }} in {{ .Stage }}",
+				Description: "Triggered by {{ .Labels.owner }}, see {{ .BridgeURL }}",
+				Properties: map[string]string{
+					"Project": "{{ .Project | printf \"%q\" }}",
+					"Image":   "{{ .Image }}",
+					"Type":    "{{ .Event.GetEvent }}",
+				},
+			},
+			expectedTitle:       "Tests of " + testService + " 1.2.3 in " + testStage,
+			expectedDescription: "Triggered by team-a, see " + testKeptnsBridge,
+			expectedProperties: customProperties{
+				"Project": `"` + testProject + `"`,
+				"Image":   "registry/my-image",
+				"Type":    "sh.keptn.event.test.triggered",
+			},
+		},
+		{
+			name: "invalid templates use defaults",
+			eventTemplate: &config.EventTemplate{
+				Title:       "{{ .Service",
+				Description: "{{ .Event.GetUnknown }}",
+				Properties: map[string]string{
+					"Project": "{{ .Unknown }}",
+				},
+			},
+			expectedTitle:       "default title",
+			expectedDescription: "default description",
+			expectedProperties:  customProperties{"Project": testProject},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template := newEventTemplate(tt.eventTemplate, event, imageAndTag, testKeptnsBridge)
+
+			assert.Equal(t, tt.expectedTitle, template.getTitle("default title"))
+			assert.Equal(t, tt.expectedDescription, template.getDescription("default description"))
+
+			properties := customProperties{"Project": testProject}
+			template.addProperties(properties)
+			assert.Equal(t, tt.expectedProperties, properties)
+		})
+	}
+}
+
+// TestEventHandlers_HandleEvent_DisabledByEventTemplate tests that no event is sent to Dynatrace if forwarding is disabled by the event template.
+func TestEventHandlers_HandleEvent_DisabledByEventTemplate(t *testing.T) {
+	event := &baseEventData{
+		context: testKeptnShContext,
+		event:   "sh.keptn.event.test.triggered",
+		project: testProject,
+		stage:   testStage,
+		service: testService,
+	}
+
+	enabled := false
+	handler := NewTestTriggeredEventHandler(event, nil, nil, nil, nil, nil, &config.EventTemplate{Enabled: &enabled})
+	assert.NoError(t, handler.HandleEvent(context.Background(), context.Background()))
+}
@@ -314,6 +314,14 @@ func createAllTestSetups(t *testing.T, handler *test.FileBasedURLHandlerWithSink
 				expectedAttachRules: expectedAttachRules,
 				labels:              labels,
 			},
+			rollbackTriggeredTestSetup{
+				t:                   t,
+				handler:             handler,
+				eClient:             eClient,
+				customAttachRules:   customAttachRulesFunc(),
+				expectedAttachRules: expectedAttachRules,
+				labels:              labels,
+			},
 		},
 		ieSetups: []testSetup[dynatrace.InfoEvent]{
 			evaluationFinishedTestSetup{
@@ -332,6 +340,38 @@ func createAllTestSetups(t *testing.T, handler *test.FileBasedURLHandlerWithSink
 				expectedAttachRules: expectedAttachRules,
 				labels:              labels,
 			},
+			approvalTriggeredTestSetup{
+				t:                   t,
+				handler:             handler,
+				eClient:             eClient,
+				customAttachRules:   customAttachRulesFunc(),
+				expectedAttachRules: expectedAttachRules,
+				labels:              labels,
+			},
+			approvalFinishedTestSetup{
+				t:                   t,
+				handler:             handler,
+				eClient:             eClient,
+				customAttachRules:   customAttachRulesFunc(),
+				expectedAttachRules: expectedAttachRules,
+				labels:              labels,
+			},
+			rollbackFinishedTestSetup{
+				t:                   t,
+				handler:             handler,
+				eClient:             eClient,
+				customAttachRules:   customAttachRulesFunc(),
+				expectedAttachRules: expectedAttachRules,
+				labels:              labels,
+			},
+			sequenceFinishedTestSetup{
+				t:                   t,
+				handler:             handler,
+				eClient:             eClient,
+				customAttachRules:   customAttachRulesFunc(),
+				expectedAttachRules: expectedAttachRules,
+				labels:              labels,
+			},
 		},
 		deSetups: []testSetup[dynatrace.DeploymentEvent]{
 			deploymentFinishedTestSetup{
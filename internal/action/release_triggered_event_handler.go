@@ -8,6 +8,7 @@ import (
 	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/keptn-contrib/dynatrace-service/internal/config"
 	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
 	"github.com/keptn-contrib/dynatrace-service/internal/keptn"
 )
@@ -15,19 +16,23 @@ import (
 type ReleaseTriggeredEventHandler struct {
 	event            ReleaseTriggeredAdapterInterface
 	dtClient         dynatrace.ClientInterface
+	eventsClient     dynatrace.EventsClientInterface
 	eClient          keptn.EventClientInterface
 	bridgeURLCreator keptn.BridgeURLCreatorInterface
 	attachRules      *dynatrace.AttachRules
+	eventTemplate    *config.EventTemplate
 }
 
 // NewReleaseTriggeredEventHandler creates a new ReleaseTriggeredEventHandler
-func NewReleaseTriggeredEventHandler(event ReleaseTriggeredAdapterInterface, dtClient dynatrace.ClientInterface, eClient keptn.EventClientInterface, bridgeURLCreator keptn.BridgeURLCreatorInterface, attachRules *dynatrace.AttachRules) *ReleaseTriggeredEventHandler {
+func NewReleaseTriggeredEventHandler(event ReleaseTriggeredAdapterInterface, dtClient dynatrace.ClientInterface, eventsClient dynatrace.EventsClientInterface, eClient keptn.EventClientInterface, bridgeURLCreator keptn.BridgeURLCreatorInterface, attachRules *dynatrace.AttachRules, eventTemplate *config.EventTemplate) *ReleaseTriggeredEventHandler {
 	return &ReleaseTriggeredEventHandler{
 		event:            event,
 		dtClient:         dtClient,
+		eventsClient:     eventsClient,
 		eClient:          eClient,
 		bridgeURLCreator: bridgeURLCreator,
 		attachRules:      attachRules,
+		eventTemplate:    eventTemplate,
 	}
 }
 
@@ -38,19 +43,28 @@ func (eh *ReleaseTriggeredEventHandler) HandleEvent(workCtx context.Context, _ c
 		log.WithError(err).Error("Could not determine deployment strategy")
 	}
 
+	if !isEventTemplateEnabled(eh.eventTemplate, eh.event) {
+		return nil
+	}
+
 	imageAndTag := eh.eClient.GetImageAndTag(workCtx, eh.event)
 	attachRules := createAttachRulesForDeploymentTimeFrame(workCtx, eh.dtClient, eh.eClient, eh.event, imageAndTag, eh.attachRules)
 
+	bridgeURL := eh.bridgeURLCreator.TryGetBridgeURLForKeptnContext(workCtx, eh.event)
+	template := newEventTemplate(eh.eventTemplate, eh.event, imageAndTag, bridgeURL)
+	customProperties := newCustomProperties(eh.event, imageAndTag, bridgeURL)
+	template.addProperties(customProperties)
+
 	infoEvent := dynatrace.InfoEvent{
 		EventType:        dynatrace.InfoEventType,
 		Source:           eventSource,
-		Title:            eh.getTitle(strategy, eh.event.GetLabels()["title"]),
-		Description:      eh.getTitle(strategy, eh.event.GetLabels()["description"]),
-		CustomProperties: newCustomProperties(eh.event, imageAndTag, eh.bridgeURLCreator.TryGetBridgeURLForKeptnContext(workCtx, eh.event)),
+		Title:            template.getTitle(eh.getTitle(strategy, eh.event.GetLabels()["title"])),
+		Description:      template.getDescription(eh.getTitle(strategy, eh.event.GetLabels()["description"])),
+		CustomProperties: customProperties,
 		AttachRules:      attachRules,
 	}
 
-	return dynatrace.NewEventsClient(eh.dtClient).AddInfoEvent(workCtx, infoEvent)
+	return eh.eventsClient.AddInfoEvent(workCtx, infoEvent)
 }
 
 func (eh *ReleaseTriggeredEventHandler) getTitle(strategy keptnevents.DeploymentStrategy, defaultValue string) string {
@@ -33,7 +33,7 @@ func (s releaseTriggeredTestSetup) createHandlerAndTeardown() (eventHandler, fun
 
 	client, _, teardown := createDynatraceClient(s.t, s.handler)
 
-	return NewReleaseTriggeredEventHandler(&event, client, s.eClient, keptn.NewBridgeURLCreator(newKeptnCredentialsProviderMock()), s.customAttachRules), teardown
+	return NewReleaseTriggeredEventHandler(&event, client, dynatrace.NewEventsClient(client), s.eClient, keptn.NewBridgeURLCreator(newKeptnCredentialsProviderMock()), s.customAttachRules, nil), teardown
 }
 
 func (s releaseTriggeredTestSetup) createExpectedDynatraceEvent() dynatrace.InfoEvent {
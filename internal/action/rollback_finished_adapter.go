@@ -0,0 +1,102 @@
+package action
+
+import (
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/keptn-contrib/dynatrace-service/internal/adapter"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+)
+
+type RollbackFinishedAdapterInterface interface {
+	adapter.EventContentAdapter
+
+	GetResult() keptnv2.ResultType
+	GetStatus() keptnv2.StatusType
+	GetMessage() string
+}
+
+// RollbackFinishedAdapter is a content adaptor for events of type sh.keptn.event.rollback.finished
+type RollbackFinishedAdapter struct {
+	event      keptnv2.RollbackFinishedEventData
+	cloudEvent adapter.CloudEventAdapter
+}
+
+// NewRollbackFinishedAdapterFromEvent creates a new RollbackFinishedAdapter from a cloudevents Event
+func NewRollbackFinishedAdapterFromEvent(e cloudevents.Event) (*RollbackFinishedAdapter, error) {
+	ceAdapter := adapter.NewCloudEventAdapter(e)
+
+	rfData := &keptnv2.RollbackFinishedEventData{}
+	err := ceAdapter.PayloadAs(rfData)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RollbackFinishedAdapter{
+		event:      *rfData,
+		cloudEvent: ceAdapter,
+	}, nil
+}
+
+// GetShKeptnContext returns the shkeptncontext
+func (a RollbackFinishedAdapter) GetShKeptnContext() string {
+	return a.cloudEvent.GetShKeptnContext()
+}
+
+// GetSource returns the source specified in the CloudEvent context
+func (a RollbackFinishedAdapter) GetSource() string {
+	return a.cloudEvent.GetSource()
+}
+
+// GetEvent returns the event type
+func (a RollbackFinishedAdapter) GetEvent() string {
+	return keptnv2.GetFinishedEventType(keptnv2.RollbackTaskName)
+}
+
+// GetProject returns the project
+func (a RollbackFinishedAdapter) GetProject() string {
+	return a.event.Project
+}
+
+// GetStage returns the stage
+func (a RollbackFinishedAdapter) GetStage() string {
+	return a.event.Stage
+}
+
+// GetService returns the service
+func (a RollbackFinishedAdapter) GetService() string {
+	return a.event.Service
+}
+
+// GetDeployment returns the name of the deployment
+func (a RollbackFinishedAdapter) GetDeployment() string {
+	return ""
+}
+
+// GetTestStrategy returns the used test strategy
+func (a RollbackFinishedAdapter) GetTestStrategy() string {
+	return ""
+}
+
+// GetDeploymentStrategy returns the used deployment strategy
+func (a RollbackFinishedAdapter) GetDeploymentStrategy() string {
+	return ""
+}
+
+// GetLabels returns a map of labels
+func (a RollbackFinishedAdapter) GetLabels() map[string]string {
+	return a.event.Labels
+}
+
+// GetResult returns the result
+func (a RollbackFinishedAdapter) GetResult() keptnv2.ResultType {
+	return a.event.Result
+}
+
+// GetStatus returns the status
+func (a RollbackFinishedAdapter) GetStatus() keptnv2.StatusType {
+	return a.event.Status
+}
+
+// GetMessage returns the message
+func (a RollbackFinishedAdapter) GetMessage() string {
+	return a.event.Message
+}
@@ -0,0 +1,61 @@
+package action
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/config"
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+	"github.com/keptn-contrib/dynatrace-service/internal/keptn"
+)
+
+// RollbackFinishedEventHandler handles a rollback finished event.
+type RollbackFinishedEventHandler struct {
+	event            RollbackFinishedAdapterInterface
+	dtClient         dynatrace.ClientInterface
+	eventsClient     dynatrace.EventsClientInterface
+	eClient          keptn.EventClientInterface
+	bridgeURLCreator keptn.BridgeURLCreatorInterface
+	attachRules      *dynatrace.AttachRules
+	eventTemplate    *config.EventTemplate
+}
+
+// NewRollbackFinishedEventHandler creates a new RollbackFinishedEventHandler.
+func NewRollbackFinishedEventHandler(event RollbackFinishedAdapterInterface, dtClient dynatrace.ClientInterface, eventsClient dynatrace.EventsClientInterface, eClient keptn.EventClientInterface, bridgeURLCreator keptn.BridgeURLCreatorInterface, attachRules *dynatrace.AttachRules, eventTemplate *config.EventTemplate) *RollbackFinishedEventHandler {
+	return &RollbackFinishedEventHandler{
+		event:            event,
+		dtClient:         dtClient,
+		eventsClient:     eventsClient,
+		eClient:          eClient,
+		bridgeURLCreator: bridgeURLCreator,
+		attachRules:      attachRules,
+		eventTemplate:    eventTemplate,
+	}
+}
+
+// HandleEvent handles a rollback finished event.
+func (eh *RollbackFinishedEventHandler) HandleEvent(workCtx context.Context, _ context.Context) error {
+	if !isEventTemplateEnabled(eh.eventTemplate, eh.event) {
+		return nil
+	}
+
+	imageAndTag := eh.eClient.GetImageAndTag(workCtx, eh.event)
+	attachRules := createAttachRulesForDeploymentTimeFrame(workCtx, eh.dtClient, eh.eClient, eh.event, imageAndTag, eh.attachRules)
+
+	bridgeURL := eh.bridgeURLCreator.TryGetBridgeURLForKeptnContext(workCtx, eh.event)
+	template := newEventTemplate(eh.eventTemplate, eh.event, imageAndTag, bridgeURL)
+	customProperties := newCustomProperties(eh.event, imageAndTag, bridgeURL)
+	customProperties.addIfNonEmpty(messageKey, eh.event.GetMessage())
+	template.addProperties(customProperties)
+
+	infoEvent := dynatrace.InfoEvent{
+		EventType:        dynatrace.InfoEventType,
+		Source:           eventSource,
+		Title:            template.getTitle(fmt.Sprintf("Rollback result: %s", eh.event.GetResult())),
+		Description:      template.getDescription(fmt.Sprintf("Rollback of %s in stage %s finished with status %s and result %s", eh.event.GetService(), eh.event.GetStage(), eh.event.GetStatus(), eh.event.GetResult())),
+		CustomProperties: customProperties,
+		AttachRules:      attachRules,
+	}
+
+	return eh.eventsClient.AddInfoEvent(workCtx, infoEvent)
+}
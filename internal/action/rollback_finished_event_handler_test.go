@@ -0,0 +1,90 @@
+package action
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+	"github.com/keptn-contrib/dynatrace-service/internal/keptn"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+)
+
+type rollbackFinishedTestSetup struct {
+	t                   *testing.T
+	handler             http.Handler
+	eClient             *eventClientFake
+	customAttachRules   *dynatrace.AttachRules
+	expectedAttachRules dynatrace.AttachRules
+	labels              map[string]string
+}
+
+func (s rollbackFinishedTestSetup) createHandlerAndTeardown() (eventHandler, func()) {
+	event := rollbackFinishedEventData{
+		baseEventData: baseEventData{
+			context: testKeptnShContext,
+			source:  "helm-service",
+			event:   "sh.keptn.event.rollback.finished",
+			project: testProject,
+			stage:   testStage,
+			service: testService,
+			labels:  s.labels,
+		},
+		result: keptnv2.ResultPass,
+		status: keptnv2.StatusSucceeded,
+	}
+
+	client, _, teardown := createDynatraceClient(s.t, s.handler)
+
+	return NewRollbackFinishedEventHandler(&event, client, dynatrace.NewEventsClient(client), s.eClient, keptn.NewBridgeURLCreator(newKeptnCredentialsProviderMock()), s.customAttachRules, nil), teardown
+}
+
+func (s rollbackFinishedTestSetup) createExpectedDynatraceEvent() dynatrace.InfoEvent {
+	tag := s.eClient.imageAndTag.Tag()
+	properties := customProperties{
+		"Image":         s.eClient.imageAndTag.Image(),
+		"Keptn Service": "helm-service",
+		"KeptnContext":  testKeptnShContext,
+		"Keptns Bridge": testKeptnsBridge,
+		"Project":       testProject,
+		"Service":       testService,
+		"Stage":         testStage,
+		"Tag":           tag,
+		"TestStrategy":  "",
+	}
+
+	addLabelsToProperties(s.t, properties, s.labels)
+
+	return dynatrace.InfoEvent{
+		EventType:        "CUSTOM_INFO",
+		Source:           "Keptn dynatrace-service",
+		Title:            "Rollback result: pass",
+		Description:      "Rollback of helloservice in stage hardening finished with status succeeded and result pass",
+		CustomProperties: properties,
+		AttachRules:      s.expectedAttachRules,
+	}
+}
+
+func (s rollbackFinishedTestSetup) createEventPayloadContainer() dynatrace.InfoEvent {
+	return dynatrace.InfoEvent{}
+}
+
+type rollbackFinishedEventData struct {
+	baseEventData
+
+	result  keptnv2.ResultType
+	status  keptnv2.StatusType
+	message string
+}
+
+func (e *rollbackFinishedEventData) GetResult() keptnv2.ResultType {
+	return e.result
+}
+
+func (e *rollbackFinishedEventData) GetStatus() keptnv2.StatusType {
+	return e.status
+}
+
+func (e *rollbackFinishedEventData) GetMessage() string {
+	return e.message
+}
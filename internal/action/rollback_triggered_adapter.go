@@ -0,0 +1,83 @@
+package action
+
+import (
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/keptn-contrib/dynatrace-service/internal/adapter"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+)
+
+type RollbackTriggeredAdapterInterface interface {
+	adapter.EventContentAdapter
+}
+
+// RollbackTriggeredAdapter is a content adaptor for events of type sh.keptn.event.rollback.triggered
+type RollbackTriggeredAdapter struct {
+	event      keptnv2.RollbackTriggeredEventData
+	cloudEvent adapter.CloudEventAdapter
+}
+
+// NewRollbackTriggeredAdapterFromEvent creates a new RollbackTriggeredAdapter from a cloudevents Event
+func NewRollbackTriggeredAdapterFromEvent(e cloudevents.Event) (*RollbackTriggeredAdapter, error) {
+	ceAdapter := adapter.NewCloudEventAdapter(e)
+
+	rtData := &keptnv2.RollbackTriggeredEventData{}
+	err := ceAdapter.PayloadAs(rtData)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RollbackTriggeredAdapter{
+		event:      *rtData,
+		cloudEvent: ceAdapter,
+	}, nil
+}
+
+// GetShKeptnContext returns the shkeptncontext
+func (a RollbackTriggeredAdapter) GetShKeptnContext() string {
+	return a.cloudEvent.GetShKeptnContext()
+}
+
+// GetSource returns the source specified in the CloudEvent context
+func (a RollbackTriggeredAdapter) GetSource() string {
+	return a.cloudEvent.GetSource()
+}
+
+// GetEvent returns the event type
+func (a RollbackTriggeredAdapter) GetEvent() string {
+	return keptnv2.GetTriggeredEventType(keptnv2.RollbackTaskName)
+}
+
+// GetProject returns the project
+func (a RollbackTriggeredAdapter) GetProject() string {
+	return a.event.Project
+}
+
+// GetStage returns the stage
+func (a RollbackTriggeredAdapter) GetStage() string {
+	return a.event.Stage
+}
+
+// GetService returns the service
+func (a RollbackTriggeredAdapter) GetService() string {
+	return a.event.Service
+}
+
+// GetDeployment returns the name of the deployment
+func (a RollbackTriggeredAdapter) GetDeployment() string {
+	return ""
+}
+
+// GetTestStrategy returns the used test strategy
+func (a RollbackTriggeredAdapter) GetTestStrategy() string {
+	return ""
+}
+
+// GetDeploymentStrategy returns the used deployment strategy
+func (a RollbackTriggeredAdapter) GetDeploymentStrategy() string {
+	return ""
+}
+
+// GetLabels returns a map of labels
+func (a RollbackTriggeredAdapter) GetLabels() map[string]string {
+	return a.event.Labels
+}
@@ -0,0 +1,59 @@
+package action
+
+import (
+	"context"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/config"
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+	"github.com/keptn-contrib/dynatrace-service/internal/keptn"
+)
+
+// RollbackTriggeredEventHandler handles a rollback triggered event.
+type RollbackTriggeredEventHandler struct {
+	event            RollbackTriggeredAdapterInterface
+	dtClient         dynatrace.ClientInterface
+	eventsClient     dynatrace.EventsClientInterface
+	eClient          keptn.EventClientInterface
+	bridgeURLCreator keptn.BridgeURLCreatorInterface
+	attachRules      *dynatrace.AttachRules
+	eventTemplate    *config.EventTemplate
+}
+
+// NewRollbackTriggeredEventHandler creates a new RollbackTriggeredEventHandler.
+func NewRollbackTriggeredEventHandler(event RollbackTriggeredAdapterInterface, dtClient dynatrace.ClientInterface, eventsClient dynatrace.EventsClientInterface, eClient keptn.EventClientInterface, bridgeURLCreator keptn.BridgeURLCreatorInterface, attachRules *dynatrace.AttachRules, eventTemplate *config.EventTemplate) *RollbackTriggeredEventHandler {
+	return &RollbackTriggeredEventHandler{
+		event:            event,
+		dtClient:         dtClient,
+		eventsClient:     eventsClient,
+		eClient:          eClient,
+		bridgeURLCreator: bridgeURLCreator,
+		attachRules:      attachRules,
+		eventTemplate:    eventTemplate,
+	}
+}
+
+// HandleEvent handles a rollback triggered event.
+func (eh *RollbackTriggeredEventHandler) HandleEvent(workCtx context.Context, _ context.Context) error {
+	if !isEventTemplateEnabled(eh.eventTemplate, eh.event) {
+		return nil
+	}
+
+	imageAndTag := eh.eClient.GetImageAndTag(workCtx, eh.event)
+	attachRules := createAttachRulesForDeploymentTimeFrame(workCtx, eh.dtClient, eh.eClient, eh.event, imageAndTag, eh.attachRules)
+
+	bridgeURL := eh.bridgeURLCreator.TryGetBridgeURLForKeptnContext(workCtx, eh.event)
+	template := newEventTemplate(eh.eventTemplate, eh.event, imageAndTag, bridgeURL)
+	customProperties := newCustomProperties(eh.event, imageAndTag, bridgeURL)
+	template.addProperties(customProperties)
+
+	annotationEvent := dynatrace.AnnotationEvent{
+		EventType:             dynatrace.AnnotationEventType,
+		Source:                eventSource,
+		AnnotationType:        template.getTitle("Start Rollback"),
+		AnnotationDescription: template.getDescription("Start rolling back " + eh.event.GetService() + " in stage " + eh.event.GetStage()),
+		CustomProperties:      customProperties,
+		AttachRules:           attachRules,
+	}
+
+	return eh.eventsClient.AddAnnotationEvent(workCtx, annotationEvent)
+}
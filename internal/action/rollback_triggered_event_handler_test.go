@@ -0,0 +1,64 @@
+package action
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+	"github.com/keptn-contrib/dynatrace-service/internal/keptn"
+)
+
+type rollbackTriggeredTestSetup struct {
+	t                   *testing.T
+	handler             http.Handler
+	eClient             *eventClientFake
+	customAttachRules   *dynatrace.AttachRules
+	expectedAttachRules dynatrace.AttachRules
+	labels              map[string]string
+}
+
+func (s rollbackTriggeredTestSetup) createHandlerAndTeardown() (eventHandler, func()) {
+	event := baseEventData{
+		context: testKeptnShContext,
+		source:  "shipyard-controller",
+		event:   "sh.keptn.event.rollback.triggered",
+		project: testProject,
+		stage:   testStage,
+		service: testService,
+		labels:  s.labels,
+	}
+
+	client, _, teardown := createDynatraceClient(s.t, s.handler)
+
+	return NewRollbackTriggeredEventHandler(&event, client, dynatrace.NewEventsClient(client), s.eClient, keptn.NewBridgeURLCreator(newKeptnCredentialsProviderMock()), s.customAttachRules, nil), teardown
+}
+
+func (s rollbackTriggeredTestSetup) createExpectedDynatraceEvent() dynatrace.AnnotationEvent {
+	tag := s.eClient.imageAndTag.Tag()
+	properties := customProperties{
+		"Image":         s.eClient.imageAndTag.Image(),
+		"Keptn Service": "shipyard-controller",
+		"KeptnContext":  testKeptnShContext,
+		"Keptns Bridge": testKeptnsBridge,
+		"Project":       testProject,
+		"Service":       testService,
+		"Stage":         testStage,
+		"Tag":           tag,
+		"TestStrategy":  "",
+	}
+
+	addLabelsToProperties(s.t, properties, s.labels)
+
+	return dynatrace.AnnotationEvent{
+		EventType:             "CUSTOM_ANNOTATION",
+		Source:                "Keptn dynatrace-service",
+		AnnotationType:        "Start Rollback",
+		AnnotationDescription: "Start rolling back helloservice in stage hardening",
+		CustomProperties:      properties,
+		AttachRules:           s.expectedAttachRules,
+	}
+}
+
+func (s rollbackTriggeredTestSetup) createEventPayloadContainer() dynatrace.AnnotationEvent {
+	return dynatrace.AnnotationEvent{}
+}
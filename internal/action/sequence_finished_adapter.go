@@ -0,0 +1,112 @@
+package action
+
+import (
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/keptn-contrib/dynatrace-service/internal/adapter"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+)
+
+type SequenceFinishedAdapterInterface interface {
+	adapter.EventContentAdapter
+
+	GetSequence() string
+	GetResult() keptnv2.ResultType
+	GetStatus() keptnv2.StatusType
+	GetMessage() string
+}
+
+// SequenceFinishedAdapter is a content adaptor for events of type sh.keptn.event.[stage].[sequence].finished
+type SequenceFinishedAdapter struct {
+	event      keptnv2.EventData
+	cloudEvent adapter.CloudEventAdapter
+}
+
+// NewSequenceFinishedAdapterFromEvent creates a new SequenceFinishedAdapter from a cloudevents Event
+func NewSequenceFinishedAdapterFromEvent(e cloudevents.Event) (*SequenceFinishedAdapter, error) {
+	ceAdapter := adapter.NewCloudEventAdapter(e)
+
+	sfData := &keptnv2.EventData{}
+	err := ceAdapter.PayloadAs(sfData)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SequenceFinishedAdapter{
+		event:      *sfData,
+		cloudEvent: ceAdapter,
+	}, nil
+}
+
+// GetShKeptnContext returns the shkeptncontext
+func (a SequenceFinishedAdapter) GetShKeptnContext() string {
+	return a.cloudEvent.GetShKeptnContext()
+}
+
+// GetSource returns the source specified in the CloudEvent context
+func (a SequenceFinishedAdapter) GetSource() string {
+	return a.cloudEvent.GetSource()
+}
+
+// GetEvent returns the event type
+func (a SequenceFinishedAdapter) GetEvent() string {
+	return a.cloudEvent.GetType()
+}
+
+// GetProject returns the project
+func (a SequenceFinishedAdapter) GetProject() string {
+	return a.event.Project
+}
+
+// GetStage returns the stage
+func (a SequenceFinishedAdapter) GetStage() string {
+	return a.event.Stage
+}
+
+// GetService returns the service
+func (a SequenceFinishedAdapter) GetService() string {
+	return a.event.Service
+}
+
+// GetDeployment returns the name of the deployment
+func (a SequenceFinishedAdapter) GetDeployment() string {
+	return ""
+}
+
+// GetTestStrategy returns the used test strategy
+func (a SequenceFinishedAdapter) GetTestStrategy() string {
+	return ""
+}
+
+// GetDeploymentStrategy returns the used deployment strategy
+func (a SequenceFinishedAdapter) GetDeploymentStrategy() string {
+	return ""
+}
+
+// GetLabels returns a map of labels
+func (a SequenceFinishedAdapter) GetLabels() map[string]string {
+	return a.event.Labels
+}
+
+// GetSequence returns the name of the sequence
+func (a SequenceFinishedAdapter) GetSequence() string {
+	_, sequence, _, err := keptnv2.ParseSequenceEventType(a.cloudEvent.GetType())
+	if err != nil {
+		return ""
+	}
+	return sequence
+}
+
+// GetResult returns the result
+func (a SequenceFinishedAdapter) GetResult() keptnv2.ResultType {
+	return a.event.Result
+}
+
+// GetStatus returns the status
+func (a SequenceFinishedAdapter) GetStatus() keptnv2.StatusType {
+	return a.event.Status
+}
+
+// GetMessage returns the message
+func (a SequenceFinishedAdapter) GetMessage() string {
+	return a.event.Message
+}
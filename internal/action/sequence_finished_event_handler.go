@@ -0,0 +1,61 @@
+package action
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/config"
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+	"github.com/keptn-contrib/dynatrace-service/internal/keptn"
+)
+
+// SequenceFinishedEventHandler handles a sequence finished event.
+type SequenceFinishedEventHandler struct {
+	event            SequenceFinishedAdapterInterface
+	dtClient         dynatrace.ClientInterface
+	eventsClient     dynatrace.EventsClientInterface
+	eClient          keptn.EventClientInterface
+	bridgeURLCreator keptn.BridgeURLCreatorInterface
+	attachRules      *dynatrace.AttachRules
+	eventTemplate    *config.EventTemplate
+}
+
+// NewSequenceFinishedEventHandler creates a new SequenceFinishedEventHandler.
+func NewSequenceFinishedEventHandler(event SequenceFinishedAdapterInterface, dtClient dynatrace.ClientInterface, eventsClient dynatrace.EventsClientInterface, eClient keptn.EventClientInterface, bridgeURLCreator keptn.BridgeURLCreatorInterface, attachRules *dynatrace.AttachRules, eventTemplate *config.EventTemplate) *SequenceFinishedEventHandler {
+	return &SequenceFinishedEventHandler{
+		event:            event,
+		dtClient:         dtClient,
+		eventsClient:     eventsClient,
+		eClient:          eClient,
+		bridgeURLCreator: bridgeURLCreator,
+		attachRules:      attachRules,
+		eventTemplate:    eventTemplate,
+	}
+}
+
+// HandleEvent handles a sequence finished event.
+func (eh *SequenceFinishedEventHandler) HandleEvent(workCtx context.Context, _ context.Context) error {
+	if !isEventTemplateEnabled(eh.eventTemplate, eh.event) {
+		return nil
+	}
+
+	imageAndTag := eh.eClient.GetImageAndTag(workCtx, eh.event)
+	attachRules := createAttachRulesForDeploymentTimeFrame(workCtx, eh.dtClient, eh.eClient, eh.event, imageAndTag, eh.attachRules)
+
+	bridgeURL := eh.bridgeURLCreator.TryGetBridgeURLForKeptnContext(workCtx, eh.event)
+	template := newEventTemplate(eh.eventTemplate, eh.event, imageAndTag, bridgeURL)
+	customProperties := newCustomProperties(eh.event, imageAndTag, bridgeURL)
+	customProperties.addIfNonEmpty(messageKey, eh.event.GetMessage())
+	template.addProperties(customProperties)
+
+	infoEvent := dynatrace.InfoEvent{
+		EventType:        dynatrace.InfoEventType,
+		Source:           eventSource,
+		Title:            template.getTitle(fmt.Sprintf("Sequence %s result: %s", eh.event.GetSequence(), eh.event.GetResult())),
+		Description:      template.getDescription(fmt.Sprintf("Sequence %s of %s in stage %s finished with status %s and result %s", eh.event.GetSequence(), eh.event.GetService(), eh.event.GetStage(), eh.event.GetStatus(), eh.event.GetResult())),
+		CustomProperties: customProperties,
+		AttachRules:      attachRules,
+	}
+
+	return eh.eventsClient.AddInfoEvent(workCtx, infoEvent)
+}
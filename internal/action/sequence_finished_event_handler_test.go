@@ -0,0 +1,96 @@
+package action
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+	"github.com/keptn-contrib/dynatrace-service/internal/keptn"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+)
+
+type sequenceFinishedTestSetup struct {
+	t                   *testing.T
+	handler             http.Handler
+	eClient             *eventClientFake
+	customAttachRules   *dynatrace.AttachRules
+	expectedAttachRules dynatrace.AttachRules
+	labels              map[string]string
+}
+
+func (s sequenceFinishedTestSetup) createHandlerAndTeardown() (eventHandler, func()) {
+	event := sequenceFinishedEventData{
+		baseEventData: baseEventData{
+			context: testKeptnShContext,
+			source:  "shipyard-controller",
+			event:   "sh.keptn.event.hardening.delivery.finished",
+			project: testProject,
+			stage:   testStage,
+			service: testService,
+			labels:  s.labels,
+		},
+		sequence: "delivery",
+		result:   keptnv2.ResultWarning,
+		status:   keptnv2.StatusSucceeded,
+	}
+
+	client, _, teardown := createDynatraceClient(s.t, s.handler)
+
+	return NewSequenceFinishedEventHandler(&event, client, dynatrace.NewEventsClient(client), s.eClient, keptn.NewBridgeURLCreator(newKeptnCredentialsProviderMock()), s.customAttachRules, nil), teardown
+}
+
+func (s sequenceFinishedTestSetup) createExpectedDynatraceEvent() dynatrace.InfoEvent {
+	tag := s.eClient.imageAndTag.Tag()
+	properties := customProperties{
+		"Image":         s.eClient.imageAndTag.Image(),
+		"Keptn Service": "shipyard-controller",
+		"KeptnContext":  testKeptnShContext,
+		"Keptns Bridge": testKeptnsBridge,
+		"Project":       testProject,
+		"Service":       testService,
+		"Stage":         testStage,
+		"Tag":           tag,
+		"TestStrategy":  "",
+	}
+
+	addLabelsToProperties(s.t, properties, s.labels)
+
+	return dynatrace.InfoEvent{
+		EventType:        "CUSTOM_INFO",
+		Source:           "Keptn dynatrace-service",
+		Title:            "Sequence delivery result: warning",
+		Description:      "Sequence delivery of helloservice in stage hardening finished with status succeeded and result warning",
+		CustomProperties: properties,
+		AttachRules:      s.expectedAttachRules,
+	}
+}
+
+func (s sequenceFinishedTestSetup) createEventPayloadContainer() dynatrace.InfoEvent {
+	return dynatrace.InfoEvent{}
+}
+
+type sequenceFinishedEventData struct {
+	baseEventData
+
+	sequence string
+	result   keptnv2.ResultType
+	status   keptnv2.StatusType
+	message  string
+}
+
+func (e *sequenceFinishedEventData) GetResult() keptnv2.ResultType {
+	return e.result
+}
+
+func (e *sequenceFinishedEventData) GetStatus() keptnv2.StatusType {
+	return e.status
+}
+
+func (e *sequenceFinishedEventData) GetMessage() string {
+	return e.message
+}
+
+func (e *sequenceFinishedEventData) GetSequence() string {
+	return e.sequence
+}
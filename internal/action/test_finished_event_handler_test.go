@@ -30,7 +30,7 @@ func (s testFinishedTestSetup) createHandlerAndTeardown() (eventHandler, func())
 
 	client, _, teardown := createDynatraceClient(s.t, s.handler)
 
-	return NewTestFinishedEventHandler(&event, client, s.eClient, keptn.NewBridgeURLCreator(newKeptnCredentialsProviderMock()), s.customAttachRules), teardown
+	return NewTestFinishedEventHandler(&event, client, dynatrace.NewEventsClient(client), s.eClient, keptn.NewBridgeURLCreator(newKeptnCredentialsProviderMock()), s.customAttachRules, nil), teardown
 }
 
 func (s testFinishedTestSetup) createExpectedDynatraceEvent() dynatrace.AnnotationEvent {
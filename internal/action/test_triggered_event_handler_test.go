@@ -31,7 +31,7 @@ func (s testTriggeredTestSetup) createHandlerAndTeardown() (eventHandler, func()
 
 	client, _, teardown := createDynatraceClient(s.t, s.handler)
 
-	return NewTestTriggeredEventHandler(&event, client, s.eClient, keptn.NewBridgeURLCreator(newKeptnCredentialsProviderMock()), s.customAttachRules), teardown
+	return NewTestTriggeredEventHandler(&event, client, dynatrace.NewEventsClient(client), s.eClient, keptn.NewBridgeURLCreator(newKeptnCredentialsProviderMock()), s.customAttachRules, nil), teardown
 }
 
 func (s testTriggeredTestSetup) createExpectedDynatraceEvent() dynatrace.AnnotationEvent {
@@ -0,0 +1,111 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// TTLCache is a thread-safe cache whose entries expire after a time-to-live.
+// The number of entries is bounded; once full, the least recently used entry is evicted.
+type TTLCache[K comparable, V any] struct {
+	mutex      sync.Mutex
+	maxEntries int
+	entries    map[K]*list.Element
+	lru        *list.List
+	now        func() time.Time
+}
+
+type ttlCacheEntry[K comparable, V any] struct {
+	key       K
+	value     V
+	expiresAt time.Time
+}
+
+// NewTTLCache creates a new TTLCache holding at most maxEntries entries.
+func NewTTLCache[K comparable, V any](maxEntries int) *TTLCache[K, V] {
+	return newTTLCacheWithClock[K, V](maxEntries, time.Now)
+}
+
+func newTTLCacheWithClock[K comparable, V any](maxEntries int, now func() time.Time) *TTLCache[K, V] {
+	return &TTLCache[K, V]{
+		maxEntries: maxEntries,
+		entries:    make(map[K]*list.Element),
+		lru:        list.New(),
+		now:        now,
+	}
+}
+
+// Get returns the value stored for the key and whether it was found and has not yet expired.
+func (c *TTLCache[K, V]) Get(key K) (V, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+
+	entry := element.Value.(*ttlCacheEntry[K, V])
+	if !c.now().Before(entry.expiresAt) {
+		c.removeElement(element)
+		var zero V
+		return zero, false
+	}
+
+	c.lru.MoveToFront(element)
+	return entry.value, true
+}
+
+// Set stores the value for the key for the specified time-to-live. Values with a time-to-live of zero or less are not stored.
+func (c *TTLCache[K, V]) Set(key K, value V, ttl time.Duration) {
+	if ttl <= 0 || c.maxEntries <= 0 {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	expiresAt := c.now().Add(ttl)
+	if element, ok := c.entries[key]; ok {
+		entry := element.Value.(*ttlCacheEntry[K, V])
+		entry.value = value
+		entry.expiresAt = expiresAt
+		c.lru.MoveToFront(element)
+		return
+	}
+
+	c.entries[key] = c.lru.PushFront(&ttlCacheEntry[K, V]{key: key, value: value, expiresAt: expiresAt})
+	for c.lru.Len() > c.maxEntries {
+		c.removeElement(c.lru.Back())
+	}
+}
+
+// RemoveIf removes all entries for which the predicate returns true.
+func (c *TTLCache[K, V]) RemoveIf(predicate func(key K, value V) bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for element := c.lru.Front(); element != nil; {
+		next := element.Next()
+		entry := element.Value.(*ttlCacheEntry[K, V])
+		if predicate(entry.key, entry.value) {
+			c.removeElement(element)
+		}
+		element = next
+	}
+}
+
+// Len returns the number of entries, including any that have expired but not yet been removed.
+func (c *TTLCache[K, V]) Len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.lru.Len()
+}
+
+func (c *TTLCache[K, V]) removeElement(element *list.Element) {
+	c.lru.Remove(element)
+	delete(c.entries, element.Value.(*ttlCacheEntry[K, V]).key)
+}
@@ -0,0 +1,78 @@
+package cache
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	return c.now
+}
+
+func (c *testClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func TestTTLCache_Expiry(t *testing.T) {
+	clock := &testClock{now: time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)}
+	cache := newTTLCacheWithClock[string, int](10, clock.Now)
+
+	cache.Set("a", 1, time.Minute)
+	cache.Set("b", 2, 0)
+
+	value, found := cache.Get("a")
+	assert.True(t, found)
+	assert.Equal(t, 1, value)
+
+	_, found = cache.Get("b")
+	assert.False(t, found, "entries without time-to-live should not be stored")
+
+	clock.Advance(time.Minute)
+	_, found = cache.Get("a")
+	assert.False(t, found)
+	assert.Equal(t, 0, cache.Len())
+}
+
+func TestTTLCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	cache := NewTTLCache[string, int](2)
+
+	cache.Set("a", 1, time.Minute)
+	cache.Set("b", 2, time.Minute)
+
+	// use "a", so that "b" becomes the least recently used entry
+	_, _ = cache.Get("a")
+	cache.Set("c", 3, time.Minute)
+
+	_, found := cache.Get("b")
+	assert.False(t, found)
+
+	_, found = cache.Get("a")
+	assert.True(t, found)
+
+	_, found = cache.Get("c")
+	assert.True(t, found)
+	assert.Equal(t, 2, cache.Len())
+}
+
+func TestTTLCache_RemoveIf(t *testing.T) {
+	cache := NewTTLCache[string, int](10)
+
+	cache.Set("dashboards/1", 1, time.Minute)
+	cache.Set("dashboards/2", 2, time.Minute)
+	cache.Set("metrics/1", 3, time.Minute)
+
+	cache.RemoveIf(func(key string, _ int) bool {
+		return strings.HasPrefix(key, "dashboards/")
+	})
+
+	assert.Equal(t, 1, cache.Len())
+	_, found := cache.Get("metrics/1")
+	assert.True(t, found)
+}
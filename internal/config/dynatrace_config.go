@@ -4,10 +4,39 @@ import "github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
 
 // DynatraceConfig defines the Dynatrace configuration structure
 type DynatraceConfig struct {
-	SpecVersion string                 `json:"spec_version" yaml:"spec_version"`
-	DtCreds     string                 `json:"dtCreds,omitempty" yaml:"dtCreds,omitempty"`
-	Dashboard   string                 `json:"dashboard,omitempty" yaml:"dashboard,omitempty"`
-	AttachRules *dynatrace.AttachRules `json:"attachRules,omitempty" yaml:"attachRules,omitempty"`
+	SpecVersion    string                   `json:"spec_version" yaml:"spec_version"`
+	DtCreds        string                   `json:"dtCreds,omitempty" yaml:"dtCreds,omitempty"`
+	Dashboard      string                   `json:"dashboard,omitempty" yaml:"dashboard,omitempty"`
+	AttachRules    *dynatrace.AttachRules   `json:"attachRules,omitempty" yaml:"attachRules,omitempty"`
+	EventsAPI      string                   `json:"eventsApi,omitempty" yaml:"eventsApi,omitempty"`
+	ConfigAPI      string                   `json:"configApi,omitempty" yaml:"configApi,omitempty"`
+	Events         map[string]EventTemplate `json:"events,omitempty" yaml:"events,omitempty"`
+	Monitoring     *MonitoringConfig        `json:"monitoring,omitempty" yaml:"monitoring,omitempty"`
+	ProblemRouting *ProblemRoutingConfig    `json:"problemRouting,omitempty" yaml:"problemRouting,omitempty"`
+	ProblemFilter  *ProblemFilterConfig     `json:"problemFilter,omitempty" yaml:"problemFilter,omitempty"`
+}
+
+// EventTemplate defines how a Keptn event is forwarded to Dynatrace.
+// Title, description and property values are Go templates overriding the defaults if set.
+type EventTemplate struct {
+	Enabled     *bool             `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	Title       string            `json:"title,omitempty" yaml:"title,omitempty"`
+	Description string            `json:"description,omitempty" yaml:"description,omitempty"`
+	Properties  map[string]string `json:"properties,omitempty" yaml:"properties,omitempty"`
+}
+
+// IsEnabled returns whether the Keptn event should be forwarded to Dynatrace. If not set, events are forwarded.
+func (t *EventTemplate) IsEnabled() bool {
+	return t == nil || t.Enabled == nil || *t.Enabled
+}
+
+// GetEventTemplate returns the template for the specified Keptn event type or nil if there is none.
+func (c *DynatraceConfig) GetEventTemplate(eventType string) *EventTemplate {
+	eventTemplate, ok := c.Events[eventType]
+	if !ok {
+		return nil
+	}
+	return &eventTemplate
 }
 
 // NewDynatraceConfigWithDefaults returns a new DynatraceConfig with values set to defaults
@@ -17,5 +46,7 @@ func NewDynatraceConfigWithDefaults() *DynatraceConfig {
 		DtCreds:     "dynatrace",
 		Dashboard:   "",
 		AttachRules: nil,
+		EventsAPI:   dynatrace.EventsAPIVersion1,
+		ConfigAPI:   dynatrace.ConfigAPIVersion1,
 	}
 }
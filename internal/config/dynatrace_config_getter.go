@@ -43,10 +43,16 @@ func (d *DynatraceConfigGetter) GetDynatraceConfig(ctx context.Context, event ad
 
 func replacePlaceholdersInDynatraceConfig(dynatraceConfig *DynatraceConfig, event adapter.EventContentAdapter) *DynatraceConfig {
 	return &DynatraceConfig{
-		SpecVersion: dynatraceConfig.SpecVersion,
-		DtCreds:     common.ReplaceKeptnPlaceholders(dynatraceConfig.DtCreds, event),
-		Dashboard:   common.ReplaceKeptnPlaceholders(dynatraceConfig.Dashboard, event),
-		AttachRules: replacePlaceholdersInAttachRules(dynatraceConfig.AttachRules, event),
+		SpecVersion:    dynatraceConfig.SpecVersion,
+		DtCreds:        common.ReplaceKeptnPlaceholders(dynatraceConfig.DtCreds, event),
+		Dashboard:      common.ReplaceKeptnPlaceholders(dynatraceConfig.Dashboard, event),
+		AttachRules:    replacePlaceholdersInAttachRules(dynatraceConfig.AttachRules, event),
+		EventsAPI:      dynatraceConfig.EventsAPI,
+		ConfigAPI:      dynatraceConfig.ConfigAPI,
+		Events:         dynatraceConfig.Events,
+		Monitoring:     dynatraceConfig.Monitoring,
+		ProblemRouting: dynatraceConfig.ProblemRouting,
+		ProblemFilter:  dynatraceConfig.ProblemFilter,
 	}
 }
 
@@ -31,6 +31,8 @@ dtCreds: dyna`,
 			want: &DynatraceConfig{
 				SpecVersion: "0.1.0",
 				DtCreds:     "dyna",
+				EventsAPI:   "v1",
+				ConfigAPI:   "v1",
 			},
 			wantErr: false,
 		},
@@ -44,6 +46,179 @@ dashboard: dash`,
 				SpecVersion: "0.1.0",
 				DtCreds:     "dyna",
 				Dashboard:   "dash",
+				EventsAPI:   "v1",
+				ConfigAPI:   "v1",
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid yaml with events API",
+			yamlString: `
+spec_version: '0.1.0'
+dtCreds: dyna
+eventsApi: v2`,
+			want: &DynatraceConfig{
+				SpecVersion: "0.1.0",
+				DtCreds:     "dyna",
+				EventsAPI:   "v2",
+				ConfigAPI:   "v1",
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid yaml with events",
+			yamlString: `
+spec_version: '0.1.0'
+dtCreds: dyna
+events:
+  sh.keptn.event.test.triggered:
+    enabled: false
+  sh.keptn.event.deployment.finished:
+    title: 'Deploy {{ .Service }} {{ .Tag }}'
+    properties:
+      Owner: '{{ .Labels.owner }}'`,
+			want: &DynatraceConfig{
+				SpecVersion: "0.1.0",
+				DtCreds:     "dyna",
+				EventsAPI:   "v1",
+				ConfigAPI:   "v1",
+				Events: map[string]EventTemplate{
+					"sh.keptn.event.test.triggered": {
+						Enabled: boolPtr(false),
+					},
+					"sh.keptn.event.deployment.finished": {
+						Title:      "Deploy {{ .Service }} {{ .Tag }}",
+						Properties: map[string]string{"Owner": "{{ .Labels.owner }}"},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid yaml with monitoring",
+			yamlString: `
+spec_version: '0.1.0'
+dtCreds: dyna
+monitoring:
+  dashboards:
+    enabled: false
+  managementZones:
+    enabled: true
+    stages:
+      - production`,
+			want: &DynatraceConfig{
+				SpecVersion: "0.1.0",
+				DtCreds:     "dyna",
+				EventsAPI:   "v1",
+				ConfigAPI:   "v1",
+				Monitoring: &MonitoringConfig{
+					Dashboards: &MonitoringEntityConfig{
+						Enabled: boolPtr(false),
+					},
+					ManagementZones: &MonitoringEntityConfig{
+						Enabled: boolPtr(true),
+						Stages:  []string{"production"},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid yaml with problem notifications",
+			yamlString: `
+spec_version: '0.1.0'
+monitoring:
+  problemNotifications:
+    notifications:
+      - name: production
+        alertingProfile:
+          name: Keptn sockshop production
+          rules:
+            - severityLevel: AVAILABILITY
+              delayInMinutes: 5
+              tags:
+                - keptn_stage:production`,
+			want: &DynatraceConfig{
+				SpecVersion: "0.1.0",
+				DtCreds:     "dynatrace",
+				EventsAPI:   "v1",
+				ConfigAPI:   "v1",
+				Monitoring: &MonitoringConfig{
+					ProblemNotifications: &ProblemNotificationsConfig{
+						Notifications: []ProblemNotificationConfig{
+							{
+								Name: "production",
+								AlertingProfile: &AlertingProfileConfig{
+									Name: "Keptn sockshop production",
+									Rules: []AlertingProfileRuleConfig{
+										{SeverityLevel: "AVAILABILITY", DelayInMinutes: 5, Tags: []string{"keptn_stage:production"}},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid yaml with problem routing",
+			yamlString: `
+spec_version: '0.1.0'
+problemRouting:
+  lookupEntityTags: true
+  rules:
+    - managementZone: 'Production*'
+      tags:
+        - 'app:carts*'
+      severityLevel: PERFORMANCE
+      rootCauseEntityType: SERVICE
+      stage: production
+      service: carts`,
+			want: &DynatraceConfig{
+				SpecVersion: "0.1.0",
+				DtCreds:     "dynatrace",
+				EventsAPI:   "v1",
+				ConfigAPI:   "v1",
+				ProblemRouting: &ProblemRoutingConfig{
+					LookupEntityTags: true,
+					Rules: []ProblemRoutingRule{
+						{
+							ManagementZone:      "Production*",
+							Tags:                []string{"app:carts*"},
+							SeverityLevel:       "PERFORMANCE",
+							RootCauseEntityType: "SERVICE",
+							Stage:               "production",
+							Service:             "carts",
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid yaml with problem filter",
+			yamlString: `
+spec_version: '0.1.0'
+problemFilter:
+  minSeverityLevel: ERROR
+  deniedTitles:
+    - '*CPU saturation*'
+  deduplicationWindowMinutes: 30
+  cooldownMinutes: 15
+  addProblemComment: true`,
+			want: &DynatraceConfig{
+				SpecVersion: "0.1.0",
+				DtCreds:     "dynatrace",
+				EventsAPI:   "v1",
+				ConfigAPI:   "v1",
+				ProblemFilter: &ProblemFilterConfig{
+					MinSeverityLevel:           "ERROR",
+					DeniedTitles:               []string{"*CPU saturation*"},
+					DeduplicationWindowMinutes: 30,
+					CooldownMinutes:            15,
+					AddProblemComment:          true,
+				},
 			},
 			wantErr: false,
 		},
@@ -66,6 +241,8 @@ dashboard: '****'`,
 				SpecVersion: "0.1.0",
 				DtCreds:     "dyna",
 				Dashboard:   "****",
+				EventsAPI:   "v1",
+				ConfigAPI:   "v1",
 			},
 			wantErr: false,
 		},
@@ -137,6 +314,8 @@ attachRules:
 				SpecVersion: "0.1.0",
 				DtCreds:     "dynatrace-myproject",
 				Dashboard:   "12345678-1111-4444-8888-123456789012",
+				EventsAPI:   "v1",
+				ConfigAPI:   "v1",
 				AttachRules: &dynatrace.AttachRules{
 					TagRule: []dynatrace.TagRule{
 						{
@@ -183,6 +362,8 @@ attachRules:
 				SpecVersion: "0.1.0",
 				DtCreds:     "dynatrace-myproject",
 				Dashboard:   "12345678-1111-4444-8888-123456789012",
+				EventsAPI:   "v1",
+				ConfigAPI:   "v1",
 				AttachRules: &dynatrace.AttachRules{
 					TagRule: []dynatrace.TagRule{
 						{
@@ -204,6 +385,8 @@ dashboard: $LABEL.dashboard`,
 				SpecVersion: "0.1.0",
 				DtCreds:     "dynatrace-myproject",
 				Dashboard:   "12345678-1111-4444-8888-123456789012",
+				EventsAPI:   "v1",
+				ConfigAPI:   "v1",
 				AttachRules: nil,
 			},
 		},
@@ -216,6 +399,8 @@ dashboard: $LABEL.my_dashboard`,
 				SpecVersion: "0.1.0",
 				DtCreds:     "dynatrace-myproject",
 				Dashboard:   "$LABEL.my_dashboard",
+				EventsAPI:   "v1",
+				ConfigAPI:   "v1",
 				AttachRules: nil,
 			},
 		},
@@ -228,6 +413,8 @@ dashboard: $LABEL.dashboard_name`,
 				SpecVersion: "0.1.0",
 				DtCreds:     "dynatrace-myproject",
 				Dashboard:   "12345678-1111-4444-8888-123456789012_name",
+				EventsAPI:   "v1",
+				ConfigAPI:   "v1",
 				AttachRules: nil,
 			},
 		},
@@ -256,3 +443,7 @@ type dynatraceConfigResourceClientMock struct {
 func (c *dynatraceConfigResourceClientMock) GetDynatraceConfig(_ context.Context, _ string, _ string, _ string) (string, error) {
 	return c.configString, nil
 }
+
+func boolPtr(b bool) *bool {
+	return &b
+}
@@ -0,0 +1,151 @@
+package config
+
+// MonitoringConfig defines which Dynatrace entities are generated when configuring monitoring for a Keptn project.
+// Entities without explicit settings fall back to the corresponding GENERATE_* environment variables.
+// If DryRun is set, the changes are only reported rather than performed.
+// If Reconcile is set, the generated entities are periodically checked for drift.
+type MonitoringConfig struct {
+	DryRun               bool                        `json:"dryRun,omitempty" yaml:"dryRun,omitempty"`
+	Reconcile            bool                        `json:"reconcile,omitempty" yaml:"reconcile,omitempty"`
+	TaggingRules         *MonitoringEntityConfig     `json:"taggingRules,omitempty" yaml:"taggingRules,omitempty"`
+	ProblemNotifications *ProblemNotificationsConfig `json:"problemNotifications,omitempty" yaml:"problemNotifications,omitempty"`
+	ManagementZones      *MonitoringEntityConfig     `json:"managementZones,omitempty" yaml:"managementZones,omitempty"`
+	Dashboards           *MonitoringEntityConfig     `json:"dashboards,omitempty" yaml:"dashboards,omitempty"`
+	MetricEvents         *MonitoringEntityConfig     `json:"metricEvents,omitempty" yaml:"metricEvents,omitempty"`
+	SLOs                 *MonitoringEntityConfig     `json:"slos,omitempty" yaml:"slos,omitempty"`
+}
+
+// MonitoringEntityConfig defines whether and for which stages a type of Dynatrace entity is generated.
+// Stages is only considered by stage-specific entities, i.e. management zones, dashboards, metric events and SLOs.
+type MonitoringEntityConfig struct {
+	Enabled *bool    `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	Stages  []string `json:"stages,omitempty" yaml:"stages,omitempty"`
+}
+
+// ProblemNotificationsConfig defines whether and which problem notifications forwarding problems to Keptn are generated.
+// If no notifications are set, a single notification forwarding all problems to the configured project is generated.
+type ProblemNotificationsConfig struct {
+	Enabled       *bool                       `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	Notifications []ProblemNotificationConfig `json:"notifications,omitempty" yaml:"notifications,omitempty"`
+}
+
+// ProblemNotificationConfig defines a problem notification forwarding the problems selected by its alerting profile to a Keptn project.
+// Name is appended to the name of the notification, Project defaults to the configured project and Payload to the built-in payload.
+type ProblemNotificationConfig struct {
+	Name            string                 `json:"name,omitempty" yaml:"name,omitempty"`
+	Project         string                 `json:"project,omitempty" yaml:"project,omitempty"`
+	Payload         string                 `json:"payload,omitempty" yaml:"payload,omitempty"`
+	AlertingProfile *AlertingProfileConfig `json:"alertingProfile,omitempty" yaml:"alertingProfile,omitempty"`
+}
+
+// AlertingProfileConfig defines the alerting profile selecting the problems forwarded by a problem notification.
+// Name defaults to the name of the Keptn alerting profile and Rules to rules including all problems of all severity levels.
+type AlertingProfileConfig struct {
+	Name  string                      `json:"name,omitempty" yaml:"name,omitempty"`
+	Rules []AlertingProfileRuleConfig `json:"rules,omitempty" yaml:"rules,omitempty"`
+}
+
+// AlertingProfileRuleConfig defines which problems of a severity level are included by an alerting profile and after which delay.
+// If Tags are set, IncludeMode defaults to INCLUDE_ANY.
+type AlertingProfileRuleConfig struct {
+	SeverityLevel  string   `json:"severityLevel" yaml:"severityLevel"`
+	DelayInMinutes int      `json:"delayInMinutes,omitempty" yaml:"delayInMinutes,omitempty"`
+	IncludeMode    string   `json:"includeMode,omitempty" yaml:"includeMode,omitempty"`
+	Tags           []string `json:"tags,omitempty" yaml:"tags,omitempty"`
+}
+
+// IsDryRun returns whether changes should only be reported rather than performed.
+func (c *MonitoringConfig) IsDryRun() bool {
+	return c != nil && c.DryRun
+}
+
+// IsReconciliationEnabled returns whether the generated entities should be periodically checked for drift.
+func (c *MonitoringConfig) IsReconciliationEnabled() bool {
+	return c != nil && c.Reconcile
+}
+
+// GetTaggingRules returns the configuration for tagging rules or nil if there is none.
+func (c *MonitoringConfig) GetTaggingRules() *MonitoringEntityConfig {
+	if c == nil {
+		return nil
+	}
+	return c.TaggingRules
+}
+
+// GetProblemNotifications returns the configuration for problem notifications or nil if there is none.
+func (c *MonitoringConfig) GetProblemNotifications() *ProblemNotificationsConfig {
+	if c == nil {
+		return nil
+	}
+	return c.ProblemNotifications
+}
+
+// GetManagementZones returns the configuration for management zones or nil if there is none.
+func (c *MonitoringConfig) GetManagementZones() *MonitoringEntityConfig {
+	if c == nil {
+		return nil
+	}
+	return c.ManagementZones
+}
+
+// GetDashboards returns the configuration for dashboards or nil if there is none.
+func (c *MonitoringConfig) GetDashboards() *MonitoringEntityConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Dashboards
+}
+
+// GetMetricEvents returns the configuration for metric events or nil if there is none.
+func (c *MonitoringConfig) GetMetricEvents() *MonitoringEntityConfig {
+	if c == nil {
+		return nil
+	}
+	return c.MetricEvents
+}
+
+// GetSLOs returns the configuration for SLOs or nil if there is none.
+func (c *MonitoringConfig) GetSLOs() *MonitoringEntityConfig {
+	if c == nil {
+		return nil
+	}
+	return c.SLOs
+}
+
+// IsEnabled returns whether the entities should be generated. If not set, defaultValue is returned.
+func (c *MonitoringEntityConfig) IsEnabled(defaultValue bool) bool {
+	if c == nil || c.Enabled == nil {
+		return defaultValue
+	}
+	return *c.Enabled
+}
+
+// IsEnabled returns whether problem notifications should be generated. If not set, defaultValue is returned.
+func (c *ProblemNotificationsConfig) IsEnabled(defaultValue bool) bool {
+	if c == nil || c.Enabled == nil {
+		return defaultValue
+	}
+	return *c.Enabled
+}
+
+// GetNotifications returns the configured problem notifications or nil if there are none.
+func (c *ProblemNotificationsConfig) GetNotifications() []ProblemNotificationConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Notifications
+}
+
+// IncludesStage returns whether entities should be generated for the specified stage. If no stages are set, all stages are included.
+func (c *MonitoringEntityConfig) IncludesStage(stage string) bool {
+	if c == nil || len(c.Stages) == 0 {
+		return true
+	}
+
+	for _, s := range c.Stages {
+		if s == stage {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,57 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMonitoringEntityConfig_IsEnabled(t *testing.T) {
+	tests := []struct {
+		name         string
+		config       *MonitoringConfig
+		defaultValue bool
+		want         bool
+	}{
+		{
+			name:         "no monitoring config uses default",
+			config:       nil,
+			defaultValue: true,
+			want:         true,
+		},
+		{
+			name:         "no entity config uses default",
+			config:       &MonitoringConfig{},
+			defaultValue: true,
+			want:         true,
+		},
+		{
+			name:         "entity config without enabled uses default",
+			config:       &MonitoringConfig{Dashboards: &MonitoringEntityConfig{Stages: []string{"dev"}}},
+			defaultValue: false,
+			want:         false,
+		},
+		{
+			name:         "entity config overrides default",
+			config:       &MonitoringConfig{Dashboards: &MonitoringEntityConfig{Enabled: boolPtr(true)}},
+			defaultValue: false,
+			want:         true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.config.GetDashboards().IsEnabled(tt.defaultValue))
+		})
+	}
+}
+
+func TestMonitoringEntityConfig_IncludesStage(t *testing.T) {
+	var noConfig *MonitoringEntityConfig
+	assert.True(t, noConfig.IncludesStage("dev"))
+	assert.True(t, (&MonitoringEntityConfig{}).IncludesStage("dev"))
+
+	config := &MonitoringEntityConfig{Stages: []string{"production"}}
+	assert.True(t, config.IncludesStage("production"))
+	assert.False(t, config.IncludesStage("dev"))
+}
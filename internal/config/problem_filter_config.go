@@ -0,0 +1,16 @@
+package config
+
+// ProblemFilterConfig defines which open problems received from Dynatrace trigger a remediation.
+// MinSeverityLevel and ImpactLevels only filter problems whose severity or impact level is known.
+// AllowedTitles and DeniedTitles are patterns that may contain * wildcards: if AllowedTitles are set, the title must match one of them, and it must not match any of the DeniedTitles.
+// DeduplicationWindowMinutes suppresses further notifications for a problem, CooldownMinutes further remediations for a service, within the specified number of minutes.
+// If AddProblemComment is set, a comment stating why a problem was dropped is added to it in Dynatrace.
+type ProblemFilterConfig struct {
+	MinSeverityLevel           string   `json:"minSeverityLevel,omitempty" yaml:"minSeverityLevel,omitempty"`
+	ImpactLevels               []string `json:"impactLevels,omitempty" yaml:"impactLevels,omitempty"`
+	AllowedTitles              []string `json:"allowedTitles,omitempty" yaml:"allowedTitles,omitempty"`
+	DeniedTitles               []string `json:"deniedTitles,omitempty" yaml:"deniedTitles,omitempty"`
+	DeduplicationWindowMinutes int      `json:"deduplicationWindowMinutes,omitempty" yaml:"deduplicationWindowMinutes,omitempty"`
+	CooldownMinutes            int      `json:"cooldownMinutes,omitempty" yaml:"cooldownMinutes,omitempty"`
+	AddProblemComment          bool     `json:"addProblemComment,omitempty" yaml:"addProblemComment,omitempty"`
+}
@@ -0,0 +1,35 @@
+package config
+
+// ProblemRoutingConfig defines how problems received from Dynatrace are routed to a Keptn project, stage and service.
+// The first rule matching a problem overrides the project, stage and service determined from its payload.
+// If LookupEntityTags is set, the tags of the impacted entities are retrieved from Dynatrace for problems sent without tags.
+type ProblemRoutingConfig struct {
+	LookupEntityTags bool                 `json:"lookupEntityTags,omitempty" yaml:"lookupEntityTags,omitempty"`
+	Rules            []ProblemRoutingRule `json:"rules,omitempty" yaml:"rules,omitempty"`
+}
+
+// ProblemRoutingRule routes the problems matching all of its conditions to a Keptn project, stage and service.
+// ManagementZone and Tags are patterns that may contain * wildcards, each of the tag patterns must match a tag of the problem.
+// Conditions that are not set match any problem. Targets that are not set leave the corresponding value unchanged.
+type ProblemRoutingRule struct {
+	ManagementZone      string   `json:"managementZone,omitempty" yaml:"managementZone,omitempty"`
+	Tags                []string `json:"tags,omitempty" yaml:"tags,omitempty"`
+	SeverityLevel       string   `json:"severityLevel,omitempty" yaml:"severityLevel,omitempty"`
+	RootCauseEntityType string   `json:"rootCauseEntityType,omitempty" yaml:"rootCauseEntityType,omitempty"`
+	Project             string   `json:"project,omitempty" yaml:"project,omitempty"`
+	Stage               string   `json:"stage,omitempty" yaml:"stage,omitempty"`
+	Service             string   `json:"service,omitempty" yaml:"service,omitempty"`
+}
+
+// IsEntityTagLookupEnabled returns whether the tags of the impacted entities should be retrieved for problems sent without tags.
+func (c *ProblemRoutingConfig) IsEntityTagLookupEnabled() bool {
+	return c != nil && c.LookupEntityTags
+}
+
+// GetRules returns the configured routing rules or nil if there are none.
+func (c *ProblemRoutingConfig) GetRules() []ProblemRoutingRule {
+	if c == nil {
+		return nil
+	}
+	return c.Rules
+}
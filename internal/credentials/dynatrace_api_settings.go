@@ -0,0 +1,104 @@
+package credentials
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DynatraceAPISettings holds optional, tenant-specific settings for accessing the Dynatrace API.
+// Unset values should be replaced by the defaults of the dynatrace-service.
+type DynatraceAPISettings struct {
+	maxRetries        *int
+	maxRetryBackoff   *time.Duration
+	requestsPerMinute *int
+	rootCAs           *x509.CertPool
+	clientCertificate *tls.Certificate
+}
+
+// GetMaxRetries gets the maximum number of retries of a failed request and whether it is set.
+func (s DynatraceAPISettings) GetMaxRetries() (int, bool) {
+	if s.maxRetries == nil {
+		return 0, false
+	}
+	return *s.maxRetries, true
+}
+
+// GetMaxRetryBackoff gets the maximum delay between two attempts of a request and whether it is set.
+func (s DynatraceAPISettings) GetMaxRetryBackoff() (time.Duration, bool) {
+	if s.maxRetryBackoff == nil {
+		return 0, false
+	}
+	return *s.maxRetryBackoff, true
+}
+
+// GetRequestsPerMinute gets the maximum number of requests per minute sent to the tenant and whether it is set.
+func (s DynatraceAPISettings) GetRequestsPerMinute() (int, bool) {
+	if s.requestsPerMinute == nil {
+		return 0, false
+	}
+	return *s.requestsPerMinute, true
+}
+
+// GetRootCAs gets the certificate authorities used to verify the tenant's certificate and whether these are set.
+// If set, the pool contains the system certificate authorities as well as the custom ones.
+func (s DynatraceAPISettings) GetRootCAs() (*x509.CertPool, bool) {
+	if s.rootCAs == nil {
+		return nil, false
+	}
+	return s.rootCAs, true
+}
+
+// GetClientCertificate gets the client certificate presented to the tenant for mutual TLS and whether it is set.
+func (s DynatraceAPISettings) GetClientCertificate() (*tls.Certificate, bool) {
+	if s.clientCertificate == nil {
+		return nil, false
+	}
+	return s.clientCertificate, true
+}
+
+// parseCACertificates creates a certificate pool containing the system certificate authorities and the PEM encoded certificates read from the specified key.
+func parseCACertificates(key string, value string) (*x509.CertPool, error) {
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+
+	if !pool.AppendCertsFromPEM([]byte(value)) {
+		return nil, fmt.Errorf("invalid value for %s: no valid PEM encoded certificate found", key)
+	}
+
+	return pool, nil
+}
+
+// parseClientCertificate parses a PEM encoded client certificate and private key read from the specified keys.
+func parseClientCertificate(certificateKey string, certificate string, privateKeyKey string, privateKey string) (*tls.Certificate, error) {
+	if certificate == "" || privateKey == "" {
+		return nil, errors.New(certificateKey + " and " + privateKeyKey + " must be specified together")
+	}
+
+	clientCertificate, err := tls.X509KeyPair([]byte(certificate), []byte(privateKey))
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for %s or %s: %w", certificateKey, privateKeyKey, err)
+	}
+
+	return &clientCertificate, nil
+}
+
+// parseNonNegativeInt parses a non-negative integer value read from the specified key.
+func parseNonNegativeInt(key string, value string) (int, error) {
+	i, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return 0, fmt.Errorf("invalid value for %s: %w", key, err)
+	}
+
+	if i < 0 {
+		return 0, fmt.Errorf("invalid value for %s: must not be negative", key)
+	}
+
+	return i, nil
+}
@@ -11,11 +11,18 @@ import (
 var dynatraceAPITokenRegex = regexp.MustCompile(`^([^\.]+)\.([A-Z0-9]{24})\.([A-Z0-9]{64})$`)
 
 type DynatraceCredentials struct {
-	tenant   string
-	apiToken string
+	tenant           string
+	apiToken         string
+	oauthCredentials *DynatraceOAuthCredentials
+	apiSettings      DynatraceAPISettings
 }
 
 func NewDynatraceCredentials(tenant string, apiToken string) (*DynatraceCredentials, error) {
+	return NewDynatraceCredentialsWithAPISettings(tenant, apiToken, DynatraceAPISettings{})
+}
+
+// NewDynatraceCredentialsWithAPISettings creates new Dynatrace credentials with additional tenant-specific API settings or returns an error.
+func NewDynatraceCredentialsWithAPISettings(tenant string, apiToken string, apiSettings DynatraceAPISettings) (*DynatraceCredentials, error) {
 	tenant, err := url.CleanURL(tenant)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create Dynatrace credentials: %v", err)
@@ -26,7 +33,17 @@ func NewDynatraceCredentials(tenant string, apiToken string) (*DynatraceCredenti
 		return nil, fmt.Errorf("cannot create Dynatrace credentials: %v", err)
 	}
 
-	return &DynatraceCredentials{tenant: tenant, apiToken: apiToken}, nil
+	return &DynatraceCredentials{tenant: tenant, apiToken: apiToken, apiSettings: apiSettings}, nil
+}
+
+// NewDynatraceOAuthCredentialsWithAPISettings creates new Dynatrace credentials that authenticate using an OAuth client instead of an API token or returns an error.
+func NewDynatraceOAuthCredentialsWithAPISettings(tenant string, oauthCredentials DynatraceOAuthCredentials, apiSettings DynatraceAPISettings) (*DynatraceCredentials, error) {
+	tenant, err := url.CleanURL(tenant)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create Dynatrace credentials: %v", err)
+	}
+
+	return &DynatraceCredentials{tenant: tenant, oauthCredentials: &oauthCredentials, apiSettings: apiSettings}, nil
 }
 
 // GetTenant gets the base URL of Dynatrace tenant. This is always prefixed with "https://" or "http://".
@@ -34,10 +51,24 @@ func (c *DynatraceCredentials) GetTenant() string {
 	return c.tenant
 }
 
+// GetAPIToken gets the API token. This is empty if the credentials use OAuth.
 func (c *DynatraceCredentials) GetAPIToken() string {
 	return c.apiToken
 }
 
+// GetOAuthCredentials gets the OAuth client credentials and whether these should be used instead of the API token.
+func (c *DynatraceCredentials) GetOAuthCredentials() (*DynatraceOAuthCredentials, bool) {
+	if c.oauthCredentials == nil {
+		return nil, false
+	}
+	return c.oauthCredentials, true
+}
+
+// GetAPISettings gets the tenant-specific API settings.
+func (c *DynatraceCredentials) GetAPISettings() DynatraceAPISettings {
+	return c.apiSettings
+}
+
 func cleanDynatraceAPIToken(t string) (string, error) {
 	t = strings.TrimSpace(t)
 
@@ -3,10 +3,20 @@ package credentials
 import (
 	"context"
 	"fmt"
+	"time"
 )
 
 const dynatraceTenantKey = "DT_TENANT"
 const dynatraceAPITokenKey = "DT_API_TOKEN"
+const dynatraceClientIDKey = "DT_CLIENT_ID"
+const dynatraceClientSecretKey = "DT_CLIENT_SECRET"
+const dynatraceSSOURLKey = "DT_SSO_URL"
+const dynatraceAPIMaxRetriesKey = "DT_API_MAX_RETRIES"
+const dynatraceAPIRetryMaxBackoffSecondsKey = "DT_API_RETRY_MAX_BACKOFF_SECONDS"
+const dynatraceAPIRequestsPerMinuteKey = "DT_API_REQUESTS_PER_MINUTE"
+const dynatraceCACertKey = "DT_CA_CERT"
+const dynatraceClientCertKey = "DT_CLIENT_CERT"
+const dynatraceClientKeyKey = "DT_CLIENT_KEY"
 
 // DynatraceCredentialsProvider allows Dynatrace credentials to be read.
 type DynatraceCredentialsProvider interface {
@@ -35,15 +45,102 @@ func NewDefaultDynatraceK8sSecretReader() (*DynatraceK8sSecretReader, error) {
 
 // GetDynatraceCredentials gets Dynatrace credentials from the secret with the specified name or returns an error.
 func (cr *DynatraceK8sSecretReader) GetDynatraceCredentials(ctx context.Context, secretName string) (*DynatraceCredentials, error) {
-	tenant, err := cr.secretReader.ReadSecret(ctx, secretName, dynatraceTenantKey)
+	secretValues, err := cr.secretReader.ReadSecretValues(ctx, secretName)
 	if err != nil {
 		return nil, err
 	}
 
-	apiToken, err := cr.secretReader.ReadSecret(ctx, secretName, dynatraceAPITokenKey)
+	tenant, err := secretValues.Get(dynatraceTenantKey)
 	if err != nil {
 		return nil, err
 	}
 
-	return NewDynatraceCredentials(tenant, apiToken)
+	apiSettings, err := readDynatraceAPISettings(secretValues)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read Dynatrace API settings from secret \"%s\": %w", secretName, err)
+	}
+
+	// OAuth client credentials take precedence, otherwise fall back to the API token
+	if _, found := secretValues.GetOptional(dynatraceClientIDKey); found {
+		oauthCredentials, err := readDynatraceOAuthCredentials(secretValues)
+		if err != nil {
+			return nil, err
+		}
+		return NewDynatraceOAuthCredentialsWithAPISettings(tenant, *oauthCredentials, *apiSettings)
+	}
+
+	apiToken, err := secretValues.Get(dynatraceAPITokenKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewDynatraceCredentialsWithAPISettings(tenant, apiToken, *apiSettings)
+}
+
+func readDynatraceOAuthCredentials(secretValues *SecretValues) (*DynatraceOAuthCredentials, error) {
+	clientID, err := secretValues.Get(dynatraceClientIDKey)
+	if err != nil {
+		return nil, err
+	}
+
+	clientSecret, err := secretValues.Get(dynatraceClientSecretKey)
+	if err != nil {
+		return nil, err
+	}
+
+	ssoURL, err := secretValues.Get(dynatraceSSOURLKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewDynatraceOAuthCredentials(clientID, clientSecret, ssoURL)
+}
+
+func readDynatraceAPISettings(secretValues *SecretValues) (*DynatraceAPISettings, error) {
+	apiSettings := &DynatraceAPISettings{}
+
+	if value, found := secretValues.GetOptional(dynatraceAPIMaxRetriesKey); found {
+		maxRetries, err := parseNonNegativeInt(dynatraceAPIMaxRetriesKey, value)
+		if err != nil {
+			return nil, err
+		}
+		apiSettings.maxRetries = &maxRetries
+	}
+
+	if value, found := secretValues.GetOptional(dynatraceAPIRetryMaxBackoffSecondsKey); found {
+		seconds, err := parseNonNegativeInt(dynatraceAPIRetryMaxBackoffSecondsKey, value)
+		if err != nil {
+			return nil, err
+		}
+		maxRetryBackoff := time.Duration(seconds) * time.Second
+		apiSettings.maxRetryBackoff = &maxRetryBackoff
+	}
+
+	if value, found := secretValues.GetOptional(dynatraceAPIRequestsPerMinuteKey); found {
+		requestsPerMinute, err := parseNonNegativeInt(dynatraceAPIRequestsPerMinuteKey, value)
+		if err != nil {
+			return nil, err
+		}
+		apiSettings.requestsPerMinute = &requestsPerMinute
+	}
+
+	if value, found := secretValues.GetOptional(dynatraceCACertKey); found {
+		rootCAs, err := parseCACertificates(dynatraceCACertKey, value)
+		if err != nil {
+			return nil, err
+		}
+		apiSettings.rootCAs = rootCAs
+	}
+
+	clientCert, clientCertFound := secretValues.GetOptional(dynatraceClientCertKey)
+	clientKey, clientKeyFound := secretValues.GetOptional(dynatraceClientKeyKey)
+	if clientCertFound || clientKeyFound {
+		clientCertificate, err := parseClientCertificate(dynatraceClientCertKey, clientCert, dynatraceClientKeyKey, clientKey)
+		if err != nil {
+			return nil, err
+		}
+		apiSettings.clientCertificate = clientCertificate
+	}
+
+	return apiSettings, nil
 }
@@ -3,6 +3,7 @@ package credentials
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/core/v1"
@@ -17,6 +18,18 @@ func TestDynatraceK8CredentialsReader_GetDynatraceCredentials(t *testing.T) {
 	assert.NoError(t, err)
 	wantDynatraceHTTPCredentials, err := NewDynatraceCredentials("http://mySampleEnv.live.dynatrace.com", testDynatraceAPIToken)
 	assert.NoError(t, err)
+	maxRetries := 5
+	maxRetryBackoff := 10 * time.Second
+	requestsPerMinute := 120
+	wantDynatraceCredentialsWithAPISettings, err := NewDynatraceCredentialsWithAPISettings(
+		"https://mySampleEnv.live.dynatrace.com",
+		testDynatraceAPIToken,
+		DynatraceAPISettings{maxRetries: &maxRetries, maxRetryBackoff: &maxRetryBackoff, requestsPerMinute: &requestsPerMinute})
+	assert.NoError(t, err)
+	oauthCredentials, err := NewDynatraceOAuthCredentials("dt0s02.CLIENT", "dt0s02.CLIENT.SECRET", "https://sso.dynatrace.com/sso/oauth2/token")
+	assert.NoError(t, err)
+	wantDynatraceOAuthCredentials, err := NewDynatraceOAuthCredentialsWithAPISettings("https://mySampleEnv.live.dynatrace.com", *oauthCredentials, DynatraceAPISettings{})
+	assert.NoError(t, err)
 
 	type args struct {
 		secretName string
@@ -133,6 +146,113 @@ func TestDynatraceK8CredentialsReader_GetDynatraceCredentials(t *testing.T) {
 
 			wantErr: true,
 		},
+		{
+			name: "with dynatrace secret - with API settings",
+			secret: createTestSecret(
+				"dynatrace",
+				map[string]string{
+					"DT_TENANT":                        "https://mySampleEnv.live.dynatrace.com",
+					"DT_API_TOKEN":                     testDynatraceAPIToken,
+					"DT_API_MAX_RETRIES":               "5",
+					"DT_API_RETRY_MAX_BACKOFF_SECONDS": "10",
+					"DT_API_REQUESTS_PER_MINUTE":       "120",
+				}),
+			args: args{
+				secretName: "dynatrace",
+			},
+			want:    wantDynatraceCredentialsWithAPISettings,
+			wantErr: false,
+		},
+		{
+			name: "with dynatrace secret - invalid max retries",
+			secret: createTestSecret(
+				"dynatrace",
+				map[string]string{
+					"DT_TENANT":          "https://mySampleEnv.live.dynatrace.com",
+					"DT_API_TOKEN":       testDynatraceAPIToken,
+					"DT_API_MAX_RETRIES": "-1",
+				}),
+			args: args{
+				secretName: "dynatrace",
+			},
+			wantErr: true,
+		},
+		{
+			name: "with dynatrace secret - invalid CA certificate",
+			secret: createTestSecret(
+				"dynatrace",
+				map[string]string{
+					"DT_TENANT":    "https://mySampleEnv.live.dynatrace.com",
+					"DT_API_TOKEN": testDynatraceAPIToken,
+					"DT_CA_CERT":   "not a certificate",
+				}),
+			args: args{
+				secretName: "dynatrace",
+			},
+			wantErr: true,
+		},
+		{
+			name: "with dynatrace secret - client certificate without key",
+			secret: createTestSecret(
+				"dynatrace",
+				map[string]string{
+					"DT_TENANT":      "https://mySampleEnv.live.dynatrace.com",
+					"DT_API_TOKEN":   testDynatraceAPIToken,
+					"DT_CLIENT_CERT": "-----BEGIN CERTIFICATE-----",
+				}),
+			args: args{
+				secretName: "dynatrace",
+			},
+			wantErr: true,
+		},
+		{
+			name: "with dynatrace secret - with OAuth client credentials",
+			secret: createTestSecret(
+				"dynatrace",
+				map[string]string{
+					"DT_TENANT":        "https://mySampleEnv.live.dynatrace.com",
+					"DT_CLIENT_ID":     "dt0s02.CLIENT",
+					"DT_CLIENT_SECRET": "dt0s02.CLIENT.SECRET",
+					"DT_SSO_URL":       "https://sso.dynatrace.com/sso/oauth2/token",
+				}),
+			args: args{
+				secretName: "dynatrace",
+			},
+			want:    wantDynatraceOAuthCredentials,
+			wantErr: false,
+		},
+		{
+			name: "with dynatrace secret - OAuth client credentials take precedence over API token",
+			secret: createTestSecret(
+				"dynatrace",
+				map[string]string{
+					"DT_TENANT":        "https://mySampleEnv.live.dynatrace.com",
+					"DT_API_TOKEN":     testDynatraceAPIToken,
+					"DT_CLIENT_ID":     "dt0s02.CLIENT",
+					"DT_CLIENT_SECRET": "dt0s02.CLIENT.SECRET",
+					"DT_SSO_URL":       "https://sso.dynatrace.com/sso/oauth2/token",
+				}),
+			args: args{
+				secretName: "dynatrace",
+			},
+			want:    wantDynatraceOAuthCredentials,
+			wantErr: false,
+		},
+		{
+			name: "with dynatrace secret - OAuth client credentials without client secret",
+			secret: createTestSecret(
+				"dynatrace",
+				map[string]string{
+					"DT_TENANT":    "https://mySampleEnv.live.dynatrace.com",
+					"DT_API_TOKEN": testDynatraceAPIToken,
+					"DT_CLIENT_ID": "dt0s02.CLIENT",
+					"DT_SSO_URL":   "https://sso.dynatrace.com/sso/oauth2/token",
+				}),
+			args: args{
+				secretName: "dynatrace",
+			},
+			wantErr: true,
+		},
 		{
 			name: "with dynatrace_other secret, with other secret name",
 			secret: createTestSecret(
@@ -0,0 +1,51 @@
+package credentials
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/url"
+)
+
+// DynatraceOAuthCredentials holds the OAuth client credentials used to obtain bearer tokens for the Dynatrace API.
+type DynatraceOAuthCredentials struct {
+	clientID     string
+	clientSecret string
+	ssoURL       string
+}
+
+// NewDynatraceOAuthCredentials creates new DynatraceOAuthCredentials or returns an error.
+func NewDynatraceOAuthCredentials(clientID string, clientSecret string, ssoURL string) (*DynatraceOAuthCredentials, error) {
+	clientID = strings.TrimSpace(clientID)
+	if clientID == "" {
+		return nil, errors.New("cannot create Dynatrace OAuth credentials: client ID must not be empty")
+	}
+
+	clientSecret = strings.TrimSpace(clientSecret)
+	if clientSecret == "" {
+		return nil, errors.New("cannot create Dynatrace OAuth credentials: client secret must not be empty")
+	}
+
+	ssoURL, err := url.CleanURL(ssoURL)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create Dynatrace OAuth credentials: %v", err)
+	}
+
+	return &DynatraceOAuthCredentials{clientID: clientID, clientSecret: clientSecret, ssoURL: ssoURL}, nil
+}
+
+// GetClientID gets the OAuth client ID.
+func (c *DynatraceOAuthCredentials) GetClientID() string {
+	return c.clientID
+}
+
+// GetClientSecret gets the OAuth client secret.
+func (c *DynatraceOAuthCredentials) GetClientSecret() string {
+	return c.clientSecret
+}
+
+// GetSSOURL gets the URL of the token endpoint used to obtain bearer tokens.
+func (c *DynatraceOAuthCredentials) GetSSOURL() string {
+	return c.ssoURL
+}
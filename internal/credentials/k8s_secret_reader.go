@@ -32,14 +32,44 @@ func NewDefaultK8sSecretReader() (*K8sSecretReader, error) {
 
 // ReadSecret reads the value of a key from the specified secret or returns an error.
 func (kcr *K8sSecretReader) ReadSecret(ctx context.Context, secretName string, secretKey string) (string, error) {
-	secret, err := kcr.K8sClient.CoreV1().Secrets(env.GetPodNamespace()).Get(ctx, secretName, metav1.GetOptions{})
+	values, err := kcr.ReadSecretValues(ctx, secretName)
 	if err != nil {
 		return "", err
 	}
 
-	secretData, found := secret.Data[secretKey]
+	return values.Get(secretKey)
+}
+
+// ReadSecretValues reads all values from the specified secret or returns an error.
+func (kcr *K8sSecretReader) ReadSecretValues(ctx context.Context, secretName string) (*SecretValues, error) {
+	secret, err := kcr.K8sClient.CoreV1().Secrets(env.GetPodNamespace()).Get(ctx, secretName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &SecretValues{secretName: secretName, data: secret.Data}, nil
+}
+
+// SecretValues contains the values read from a K8s secret.
+type SecretValues struct {
+	secretName string
+	data       map[string][]byte
+}
+
+// Get gets the value of the specified key or returns an error if it is not available.
+func (v *SecretValues) Get(secretKey string) (string, error) {
+	value, found := v.GetOptional(secretKey)
+	if !found {
+		return "", fmt.Errorf("key \"%s\" was not found in secret \"%s\"", secretKey, v.secretName)
+	}
+	return value, nil
+}
+
+// GetOptional gets the value of the specified key and whether it was found.
+func (v *SecretValues) GetOptional(secretKey string) (string, bool) {
+	value, found := v.data[secretKey]
 	if !found {
-		return "", fmt.Errorf("key \"%s\" was not found in secret \"%s\"", secretKey, secretName)
+		return "", false
 	}
-	return string(secretData), nil
+	return string(value), true
 }
@@ -0,0 +1,70 @@
+package deduplication
+
+import "container/list"
+
+// boundedSet is an insertion-ordered set of keys. The number of keys is bounded; once full, the oldest key is evicted.
+// It is not thread-safe.
+type boundedSet struct {
+	maxEntries int
+	elements   map[string]*list.Element
+	order      *list.List
+
+	// onEvict is called with each key evicted by add, if set
+	onEvict func(key string)
+}
+
+func newBoundedSet(maxEntries int, keys []string) *boundedSet {
+	s := &boundedSet{
+		maxEntries: maxEntries,
+		elements:   make(map[string]*list.Element),
+		order:      list.New(),
+	}
+	for _, key := range keys {
+		s.add(key)
+	}
+	return s
+}
+
+// add adds the key and returns true, or returns false if the key is already contained.
+func (s *boundedSet) add(key string) bool {
+	if _, ok := s.elements[key]; ok {
+		return false
+	}
+
+	s.elements[key] = s.order.PushBack(key)
+	for s.order.Len() > s.maxEntries {
+		evictedKey := s.order.Front().Value.(string)
+		s.remove(evictedKey)
+		if s.onEvict != nil {
+			s.onEvict(evictedKey)
+		}
+	}
+	return true
+}
+
+// contains returns whether the key is contained.
+func (s *boundedSet) contains(key string) bool {
+	_, ok := s.elements[key]
+	return ok
+}
+
+// remove removes the key and returns whether it was contained.
+func (s *boundedSet) remove(key string) bool {
+	element, ok := s.elements[key]
+	if !ok {
+		return false
+	}
+
+	s.order.Remove(element)
+	delete(s.elements, key)
+	return true
+}
+
+// keys returns all keys, oldest first.
+func (s *boundedSet) keys() []string {
+	keys := make([]string, 0, s.order.Len())
+	for element := s.order.Front(); element != nil; element = element.Next() {
+		keys = append(keys, element.Value.(string))
+	}
+	return keys
+}
@@ -0,0 +1,161 @@
+package deduplication
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/keptn/go-utils/pkg/common/kubeutils"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/env"
+)
+
+const configMapKeysKey = "events"
+
+// maxConfigMapKeysSize is the maximum size of the serialized keys, leaving headroom for the metadata within the 1 MiB size limit of a ConfigMap.
+const maxConfigMapKeysSize = 1024*1024 - 16*1024
+
+// ConfigMapStore is a Store persisting the keys of the most recently received events in a K8s ConfigMap, so that they survive restarts and are shared between replicas.
+type ConfigMapStore struct {
+	k8sClient  kubernetes.Interface
+	namespace  string
+	name       string
+	maxEntries int
+	maxSize    int
+}
+
+// NewConfigMapStore creates a new ConfigMapStore holding at most maxEntries keys in the specified ConfigMap, which is created if it does not exist.
+func NewConfigMapStore(k8sClient kubernetes.Interface, namespace string, name string, maxEntries int) *ConfigMapStore {
+	return &ConfigMapStore{
+		k8sClient:  k8sClient,
+		namespace:  namespace,
+		name:       name,
+		maxEntries: maxEntries,
+		maxSize:    maxConfigMapKeysSize,
+	}
+}
+
+// NewDefaultConfigMapStore creates a new ConfigMapStore using the default K8s client and the namespace of the pod.
+func NewDefaultConfigMapStore(name string, maxEntries int) (*ConfigMapStore, error) {
+	useInClusterConfig := env.GetKubernetesServiceHost() != ""
+	k8sClient, err := kubeutils.GetClientSet(useInClusterConfig)
+	if err != nil {
+		return nil, fmt.Errorf("could not initialize ConfigMapStore: %w", err)
+	}
+	return NewConfigMapStore(k8sClient, env.GetPodNamespace(), name, maxEntries), nil
+}
+
+// Contains returns whether the key has been recorded.
+func (s *ConfigMapStore) Contains(ctx context.Context, key string) (bool, error) {
+	data, err := getConfigMapData(ctx, s.k8sClient, s.namespace, s.name, configMapKeysKey)
+	if err != nil {
+		return false, err
+	}
+
+	keys, err := parseKeys(data)
+	if err != nil {
+		return false, err
+	}
+	return newBoundedSet(s.maxEntries, keys).contains(key), nil
+}
+
+// Add records the key.
+func (s *ConfigMapStore) Add(ctx context.Context, key string) error {
+	return s.update(ctx, func(keys *boundedSet) bool {
+		return keys.add(key)
+	})
+}
+
+// update reads the keys from the ConfigMap, applies the modification and writes the keys back if they were modified, retrying on conflicting updates.
+func (s *ConfigMapStore) update(ctx context.Context, modify func(keys *boundedSet) bool) error {
+	return updateConfigMapData(ctx, s.k8sClient, s.namespace, s.name, configMapKeysKey, func(data string) (string, bool, error) {
+		keys, err := parseKeys(data)
+		if err != nil {
+			return "", false, err
+		}
+
+		set := newBoundedSet(s.maxEntries, keys)
+		if !modify(set) {
+			return "", false, nil
+		}
+
+		newData, err := json.Marshal(set.keys())
+		if err != nil {
+			return "", false, fmt.Errorf("could not marshal event deduplication keys: %w", err)
+		}
+
+		if len(newData) > s.maxSize {
+			return "", false, fmt.Errorf("event deduplication keys of %d bytes exceed the maximum size of %d bytes of ConfigMap %s, reduce EVENT_DEDUPLICATION_CONFIGMAP_MAX_ENTRIES", len(newData), s.maxSize, s.name)
+		}
+		return string(newData), true, nil
+	})
+}
+
+func parseKeys(data string) ([]string, error) {
+	if data == "" {
+		return nil, nil
+	}
+
+	var keys []string
+	if err := json.Unmarshal([]byte(data), &keys); err != nil {
+		return nil, fmt.Errorf("could not parse event deduplication ConfigMap: %w", err)
+	}
+	return keys, nil
+}
+
+// getConfigMapData returns the value of the data key of the ConfigMap or an empty string if either does not exist.
+func getConfigMapData(ctx context.Context, k8sClient kubernetes.Interface, namespace string, name string, dataKey string) (string, error) {
+	configMap, err := k8sClient.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("could not get deduplication ConfigMap: %w", err)
+	}
+	return configMap.Data[dataKey], nil
+}
+
+// updateConfigMapData reads the value of the data key of the ConfigMap, applies the modification and writes the value back if it was modified, retrying on conflicting updates.
+// The ConfigMap is created if it does not exist.
+func updateConfigMapData(ctx context.Context, k8sClient kubernetes.Interface, namespace string, name string, dataKey string, modify func(data string) (string, bool, error)) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		configMap, err := k8sClient.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+		exists := err == nil
+		if k8serrors.IsNotFound(err) {
+			configMap = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: namespace,
+				},
+			}
+		} else if err != nil {
+			return fmt.Errorf("could not get deduplication ConfigMap: %w", err)
+		}
+
+		data, modified, err := modify(configMap.Data[dataKey])
+		if err != nil || !modified {
+			return err
+		}
+
+		if configMap.Data == nil {
+			configMap.Data = map[string]string{}
+		}
+		configMap.Data[dataKey] = data
+
+		if !exists {
+			_, err = k8sClient.CoreV1().ConfigMaps(namespace).Create(ctx, configMap, metav1.CreateOptions{})
+			if k8serrors.IsAlreadyExists(err) {
+				// another replica created the ConfigMap in the meantime, so treat it as a conflict and retry
+				return k8serrors.NewConflict(corev1.Resource("configmaps"), name, err)
+			}
+		} else {
+			_, err = k8sClient.CoreV1().ConfigMaps(namespace).Update(ctx, configMap, metav1.UpdateOptions{})
+		}
+		return err
+	})
+}
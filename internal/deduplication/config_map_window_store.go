@@ -0,0 +1,103 @@
+package deduplication
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/keptn/go-utils/pkg/common/kubeutils"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/env"
+)
+
+const configMapWindowsKey = "windows"
+
+// ConfigMapWindowStore is a WindowStore persisting the most recently added keys in a K8s ConfigMap, so that they survive restarts and are shared between replicas.
+type ConfigMapWindowStore struct {
+	k8sClient  kubernetes.Interface
+	namespace  string
+	name       string
+	maxEntries int
+	maxSize    int
+}
+
+// NewConfigMapWindowStore creates a new ConfigMapWindowStore holding at most maxEntries keys in the specified ConfigMap, which is created if it does not exist.
+func NewConfigMapWindowStore(k8sClient kubernetes.Interface, namespace string, name string, maxEntries int) *ConfigMapWindowStore {
+	return &ConfigMapWindowStore{
+		k8sClient:  k8sClient,
+		namespace:  namespace,
+		name:       name,
+		maxEntries: maxEntries,
+		maxSize:    maxConfigMapKeysSize,
+	}
+}
+
+// NewDefaultConfigMapWindowStore creates a new ConfigMapWindowStore using the default K8s client and the namespace of the pod.
+func NewDefaultConfigMapWindowStore(name string, maxEntries int) (*ConfigMapWindowStore, error) {
+	useInClusterConfig := env.GetKubernetesServiceHost() != ""
+	k8sClient, err := kubeutils.GetClientSet(useInClusterConfig)
+	if err != nil {
+		return nil, fmt.Errorf("could not initialize ConfigMapWindowStore: %w", err)
+	}
+	return NewConfigMapWindowStore(k8sClient, env.GetPodNamespace(), name, maxEntries), nil
+}
+
+// Reserve records the key at the specified time and returns true, or returns false if the key has already been recorded within the window before the specified time.
+// Conflicting updates by other replicas are retried, so that only one of several concurrent reservations of the same key succeeds.
+func (s *ConfigMapWindowStore) Reserve(ctx context.Context, key string, now time.Time, window time.Duration) (bool, error) {
+	reserved := false
+	err := s.update(ctx, func(windows *InMemoryWindowStore) bool {
+		reserved = windows.reserve(key, now, window)
+		return reserved
+	})
+	if err != nil {
+		return false, err
+	}
+	return reserved, nil
+}
+
+// Release forgets the key if it was recorded at the specified time.
+func (s *ConfigMapWindowStore) Release(ctx context.Context, key string, now time.Time) error {
+	return s.update(ctx, func(windows *InMemoryWindowStore) bool {
+		return windows.release(key, now)
+	})
+}
+
+// update reads the windows from the ConfigMap, applies the modification and writes the windows back if they were modified, retrying on conflicting updates.
+func (s *ConfigMapWindowStore) update(ctx context.Context, modify func(windows *InMemoryWindowStore) bool) error {
+	return updateConfigMapData(ctx, s.k8sClient, s.namespace, s.name, configMapWindowsKey, func(data string) (string, bool, error) {
+		entries, err := parseWindowEntries(data)
+		if err != nil {
+			return "", false, err
+		}
+
+		windows := newInMemoryWindowStore(s.maxEntries, entries)
+		if !modify(windows) {
+			return "", false, nil
+		}
+
+		newData, err := json.Marshal(windows.entries())
+		if err != nil {
+			return "", false, fmt.Errorf("could not marshal deduplication windows: %w", err)
+		}
+
+		if len(newData) > s.maxSize {
+			return "", false, fmt.Errorf("deduplication windows of %d bytes exceed the maximum size of %d bytes of ConfigMap %s, reduce EVENT_DEDUPLICATION_CONFIGMAP_MAX_ENTRIES", len(newData), s.maxSize, s.name)
+		}
+		return string(newData), true, nil
+	})
+}
+
+func parseWindowEntries(data string) ([]windowEntry, error) {
+	if data == "" {
+		return nil, nil
+	}
+
+	var entries []windowEntry
+	if err := json.Unmarshal([]byte(data), &entries); err != nil {
+		return nil, fmt.Errorf("could not parse deduplication window ConfigMap: %w", err)
+	}
+	return entries, nil
+}
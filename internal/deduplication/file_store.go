@@ -0,0 +1,108 @@
+package deduplication
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// FileStore is a Store holding the keys of the most recently received events in memory and persisting them to a file, so that they survive restarts.
+// The file must not be shared between multiple instances.
+type FileStore struct {
+	mutex sync.Mutex
+	path  string
+	keys  *boundedSet
+}
+
+// NewFileStore creates a new FileStore holding at most maxEntries keys, loading any keys previously persisted to the file at path.
+func NewFileStore(path string, maxEntries int) (*FileStore, error) {
+	if path == "" {
+		return nil, errors.New("no file for the event deduplication store has been specified")
+	}
+
+	keys, err := readKeysFromFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileStore{
+		path: path,
+		keys: newBoundedSet(maxEntries, keys),
+	}, nil
+}
+
+func readKeysFromFile(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read event deduplication file: %w", err)
+	}
+
+	var keys []string
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return nil, fmt.Errorf("could not parse event deduplication file: %w", err)
+	}
+	return keys, nil
+}
+
+// Contains returns whether the key has been recorded.
+func (s *FileStore) Contains(_ context.Context, key string) (bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.keys.contains(key), nil
+}
+
+// Add records and persists the key.
+func (s *FileStore) Add(_ context.Context, key string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if !s.keys.add(key) {
+		return nil
+	}
+
+	if err := s.write(); err != nil {
+		s.keys.remove(key)
+		return err
+	}
+	return nil
+}
+
+// write atomically replaces the file with the current keys. The caller must hold the mutex.
+func (s *FileStore) write() error {
+	data, err := json.Marshal(s.keys.keys())
+	if err != nil {
+		return fmt.Errorf("could not marshal event deduplication keys: %w", err)
+	}
+	return writeFileAtomically(s.path, data)
+}
+
+// writeFileAtomically replaces the file at path with the data by writing it to a temporary file first.
+func writeFileAtomically(path string, data []byte) error {
+	tempFile, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("could not create temporary deduplication file: %w", err)
+	}
+	defer os.Remove(tempFile.Name())
+
+	_, err = tempFile.Write(data)
+	closeErr := tempFile.Close()
+	if err != nil {
+		return fmt.Errorf("could not write deduplication file: %w", err)
+	}
+	if closeErr != nil {
+		return fmt.Errorf("could not write deduplication file: %w", closeErr)
+	}
+
+	if err := os.Rename(tempFile.Name(), path); err != nil {
+		return fmt.Errorf("could not replace deduplication file: %w", err)
+	}
+	return nil
+}
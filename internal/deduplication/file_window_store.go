@@ -0,0 +1,89 @@
+package deduplication
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// FileWindowStore is a WindowStore holding the most recently added keys in memory and persisting them to a file, so that they survive restarts.
+// The file must not be shared between multiple instances.
+type FileWindowStore struct {
+	mutex   sync.Mutex
+	path    string
+	windows *InMemoryWindowStore
+}
+
+// NewFileWindowStore creates a new FileWindowStore holding at most maxEntries keys, loading any keys previously persisted to the file at path.
+func NewFileWindowStore(path string, maxEntries int) (*FileWindowStore, error) {
+	if path == "" {
+		return nil, errors.New("no file for the deduplication window store has been specified")
+	}
+
+	entries, err := readWindowEntriesFromFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileWindowStore{
+		path:    path,
+		windows: newInMemoryWindowStore(maxEntries, entries),
+	}, nil
+}
+
+func readWindowEntriesFromFile(path string) ([]windowEntry, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read deduplication window file: %w", err)
+	}
+
+	var entries []windowEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("could not parse deduplication window file: %w", err)
+	}
+	return entries, nil
+}
+
+// Reserve records the key at the specified time and persists the change and returns true, or returns false if the key has already been recorded within the window before the specified time.
+func (s *FileWindowStore) Reserve(ctx context.Context, key string, now time.Time, window time.Duration) (bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	reserved, err := s.windows.Reserve(ctx, key, now, window)
+	if err != nil || !reserved {
+		return false, err
+	}
+
+	if err := s.write(); err != nil {
+		_ = s.windows.Release(ctx, key, now)
+		return false, err
+	}
+	return true, nil
+}
+
+// Release forgets the key if it was recorded at the specified time and persists the change.
+func (s *FileWindowStore) Release(ctx context.Context, key string, now time.Time) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if err := s.windows.Release(ctx, key, now); err != nil {
+		return err
+	}
+	return s.write()
+}
+
+// write atomically replaces the file with the current windows. The caller must hold the mutex.
+func (s *FileWindowStore) write() error {
+	data, err := json.Marshal(s.windows.entries())
+	if err != nil {
+		return fmt.Errorf("could not marshal deduplication windows: %w", err)
+	}
+	return writeFileAtomically(s.path, data)
+}
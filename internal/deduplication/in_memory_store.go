@@ -0,0 +1,54 @@
+package deduplication
+
+import (
+	"context"
+	"sync"
+)
+
+// InMemoryStore is a Store holding the keys of the most recently received events in memory.
+type InMemoryStore struct {
+	mutex sync.Mutex
+	keys  *boundedSet
+}
+
+// NewInMemoryStore creates a new InMemoryStore holding at most maxEntries keys.
+func NewInMemoryStore(maxEntries int) *InMemoryStore {
+	return &InMemoryStore{
+		keys: newBoundedSet(maxEntries, nil),
+	}
+}
+
+// Contains returns whether the key has been recorded.
+func (s *InMemoryStore) Contains(_ context.Context, key string) (bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.keys.contains(key), nil
+}
+
+// Add records the key.
+func (s *InMemoryStore) Add(_ context.Context, key string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.keys.add(key)
+	return nil
+}
+
+// NoOpStore is a Store that does not record any keys, i.e. disables deduplication.
+type NoOpStore struct{}
+
+// NewNoOpStore creates a new NoOpStore.
+func NewNoOpStore() NoOpStore {
+	return NoOpStore{}
+}
+
+// Contains returns false, i.e. every event is considered new.
+func (NoOpStore) Contains(_ context.Context, _ string) (bool, error) {
+	return false, nil
+}
+
+// Add does nothing.
+func (NoOpStore) Add(_ context.Context, _ string) error {
+	return nil
+}
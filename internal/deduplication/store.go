@@ -0,0 +1,48 @@
+package deduplication
+
+import (
+	"context"
+	"fmt"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/env"
+)
+
+const (
+	storeTypeNone      = "none"
+	storeTypeMemory    = "memory"
+	storeTypeConfigMap = "configmap"
+	storeTypeFile      = "file"
+)
+
+// Store records the keys of events that have already been processed.
+// Checking and recording a key are separate steps, so that a key is only recorded once its event has been processed successfully.
+type Store interface {
+	// Contains returns whether the key has been recorded.
+	Contains(ctx context.Context, key string) (bool, error)
+
+	// Add records the key.
+	Add(ctx context.Context, key string) error
+}
+
+// GetEventKey gets the key identifying duplicate deliveries of the event, consisting of its type and ID.
+func GetEventKey(event cloudevents.Event) string {
+	return event.Type() + "/" + event.ID()
+}
+
+// NewDefaultStore creates the Store configured by the EVENT_DEDUPLICATION_* environment variables.
+func NewDefaultStore() (Store, error) {
+	switch storeType := env.GetEventDeduplicationStore(); storeType {
+	case storeTypeNone:
+		return NewNoOpStore(), nil
+	case storeTypeMemory:
+		return NewInMemoryStore(env.GetEventDeduplicationMaxEntries()), nil
+	case storeTypeConfigMap:
+		return NewDefaultConfigMapStore(env.GetEventDeduplicationConfigMap(), env.GetEventDeduplicationConfigMapMaxEntries())
+	case storeTypeFile:
+		return NewFileStore(env.GetEventDeduplicationFile(), env.GetEventDeduplicationMaxEntries())
+	default:
+		return nil, fmt.Errorf("unknown event deduplication store type: %s", storeType)
+	}
+}
@@ -0,0 +1,265 @@
+package deduplication
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const testNamespace = "keptn"
+const testConfigMapName = "dynatrace-service-received-events"
+
+// TestStores tests the behavior common to all stores: keys are only contained once added and the oldest keys are evicted once full.
+func TestStores(t *testing.T) {
+	tests := []struct {
+		name        string
+		createStore func(t *testing.T) Store
+	}{
+		{
+			name: "in-memory store",
+			createStore: func(t *testing.T) Store {
+				return NewInMemoryStore(2)
+			},
+		},
+		{
+			name: "file store",
+			createStore: func(t *testing.T) Store {
+				store, err := NewFileStore(filepath.Join(t.TempDir(), "events.json"), 2)
+				assert.NoError(t, err)
+				return store
+			},
+		},
+		{
+			name: "ConfigMap store",
+			createStore: func(t *testing.T) Store {
+				return NewConfigMapStore(fake.NewSimpleClientset(), testNamespace, testConfigMapName, 2)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := tt.createStore(t)
+
+			// checking a key does not add it
+			assertContains(t, store, "a", false)
+			assertContains(t, store, "a", false)
+
+			assertAdd(t, store, "a")
+			assertContains(t, store, "a", true)
+
+			// adding a key again does not change its position
+			assertAdd(t, store, "b")
+			assertAdd(t, store, "a")
+
+			// adding a third key evicts the oldest one
+			assertAdd(t, store, "c")
+			assertContains(t, store, "a", false)
+			assertContains(t, store, "b", true)
+			assertContains(t, store, "c", true)
+		})
+	}
+}
+
+// TestFileStore_SurvivesRestart tests that keys recorded by a FileStore are loaded by a new FileStore using the same file.
+func TestFileStore_SurvivesRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.json")
+
+	store, err := NewFileStore(path, 10)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assertAdd(t, store, "a")
+
+	restartedStore, err := NewFileStore(path, 10)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assertContains(t, restartedStore, "a", true)
+	assertContains(t, restartedStore, "b", false)
+}
+
+func TestNewFileStore_Errors(t *testing.T) {
+	_, err := NewFileStore("", 10)
+	assert.Error(t, err)
+
+	path := filepath.Join(t.TempDir(), "events.json")
+	assert.NoError(t, os.WriteFile(path, []byte("not json"), 0600))
+	_, err = NewFileStore(path, 10)
+	assert.ErrorContains(t, err, "could not parse event deduplication file")
+}
+
+// TestConfigMapStore_UsesExistingConfigMap tests that a ConfigMapStore reads the keys recorded in an existing ConfigMap, e.g. by another replica.
+func TestConfigMapStore_UsesExistingConfigMap(t *testing.T) {
+	k8sClient := fake.NewSimpleClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            testConfigMapName,
+			Namespace:       testNamespace,
+			ResourceVersion: "1",
+		},
+		Data: map[string]string{
+			configMapKeysKey: `["a"]`,
+		},
+	})
+	store := NewConfigMapStore(k8sClient, testNamespace, testConfigMapName, 10)
+
+	assertContains(t, store, "a", true)
+	assertAdd(t, store, "b")
+
+	configMap, err := k8sClient.CoreV1().ConfigMaps(testNamespace).Get(context.Background(), testConfigMapName, metav1.GetOptions{})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, `["a","b"]`, configMap.Data[configMapKeysKey])
+}
+
+// TestConfigMapStore_ExceedsMaxSize tests that a ConfigMapStore returns an error rather than writing keys exceeding the maximum size of the ConfigMap.
+func TestConfigMapStore_ExceedsMaxSize(t *testing.T) {
+	k8sClient := fake.NewSimpleClientset()
+	store := NewConfigMapStore(k8sClient, testNamespace, testConfigMapName, 10)
+	store.maxSize = 10
+
+	assertAdd(t, store, "a")
+
+	err := store.Add(context.Background(), "abcdefghij")
+	assert.ErrorContains(t, err, "exceed the maximum size of 10 bytes of ConfigMap "+testConfigMapName)
+
+	configMap, err := k8sClient.CoreV1().ConfigMaps(testNamespace).Get(context.Background(), testConfigMapName, metav1.GetOptions{})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, `["a"]`, configMap.Data[configMapKeysKey])
+}
+
+func assertContains(t *testing.T, store Store, key string, wantContained bool) {
+	contained, err := store.Contains(context.Background(), key)
+	assert.NoError(t, err)
+	assert.Equal(t, wantContained, contained, "checking key %s", key)
+}
+
+func assertAdd(t *testing.T, store Store, key string) {
+	assert.NoError(t, store.Add(context.Background(), key))
+}
+
+// TestWindowStores tests the behavior common to all window stores: keys can only be reserved again outside the window, the window is measured from the last reservation, reservations can be released and the oldest keys are evicted once full.
+func TestWindowStores(t *testing.T) {
+	tests := []struct {
+		name        string
+		createStore func(t *testing.T) WindowStore
+	}{
+		{
+			name: "in-memory window store",
+			createStore: func(t *testing.T) WindowStore {
+				return NewInMemoryWindowStore(2)
+			},
+		},
+		{
+			name: "file window store",
+			createStore: func(t *testing.T) WindowStore {
+				store, err := NewFileWindowStore(filepath.Join(t.TempDir(), "events.json-problem-windows"), 2)
+				assert.NoError(t, err)
+				return store
+			},
+		},
+		{
+			name: "ConfigMap window store",
+			createStore: func(t *testing.T) WindowStore {
+				return NewConfigMapWindowStore(fake.NewSimpleClientset(), testNamespace, testConfigMapName+problemWindowsSuffix, 2)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := tt.createStore(t)
+			start := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+
+			assertReserve(t, store, "a", start, true)
+			assertReserve(t, store, "a", start.Add(5*time.Minute), false)
+
+			// the window is measured from the last successful reservation
+			assertReserve(t, store, "a", start.Add(10*time.Minute), true)
+			assertReserve(t, store, "a", start.Add(15*time.Minute), false)
+
+			// only the reservation made at the specified time is released
+			assertRelease(t, store, "a", start)
+			assertReserve(t, store, "a", start.Add(16*time.Minute), false)
+			assertRelease(t, store, "a", start.Add(10*time.Minute))
+			assertReserve(t, store, "a", start.Add(17*time.Minute), true)
+
+			// reserving further keys evicts the oldest one
+			assertReserve(t, store, "b", start.Add(18*time.Minute), true)
+			assertReserve(t, store, "c", start.Add(19*time.Minute), true)
+			assertReserve(t, store, "a", start.Add(20*time.Minute), true)
+			assertReserve(t, store, "c", start.Add(21*time.Minute), false)
+		})
+	}
+}
+
+// TestFileWindowStore_SurvivesRestart tests that keys recorded by a FileWindowStore are loaded by a new FileWindowStore using the same file.
+func TestFileWindowStore_SurvivesRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.json-problem-windows")
+	start := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	store, err := NewFileWindowStore(path, 10)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assertReserve(t, store, "a", start, true)
+
+	restartedStore, err := NewFileWindowStore(path, 10)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assertReserve(t, restartedStore, "a", start.Add(5*time.Minute), false)
+	assertReserve(t, restartedStore, "b", start.Add(5*time.Minute), true)
+}
+
+// TestConfigMapWindowStore_SharedBetweenReplicas tests that keys recorded by a ConfigMapWindowStore are seen by another ConfigMapWindowStore using the same ConfigMap.
+func TestConfigMapWindowStore_SharedBetweenReplicas(t *testing.T) {
+	k8sClient := fake.NewSimpleClientset()
+	start := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	assertReserve(t, NewConfigMapWindowStore(k8sClient, testNamespace, testConfigMapName+problemWindowsSuffix, 10), "a", start, true)
+	assertReserve(t, NewConfigMapWindowStore(k8sClient, testNamespace, testConfigMapName+problemWindowsSuffix, 10), "a", start.Add(5*time.Minute), false)
+}
+
+// TestInMemoryWindowStore_ConcurrentReservations tests that only one of several concurrent reservations of the same key succeeds.
+func TestInMemoryWindowStore_ConcurrentReservations(t *testing.T) {
+	store := NewInMemoryWindowStore(10)
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	var reservations int32
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			reserved, err := store.Reserve(context.Background(), "a", now, 10*time.Minute)
+			assert.NoError(t, err)
+			if reserved {
+				atomic.AddInt32(&reservations, 1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.EqualValues(t, 1, reservations)
+}
+
+func assertReserve(t *testing.T, store WindowStore, key string, now time.Time, wantReserved bool) {
+	reserved, err := store.Reserve(context.Background(), key, now, 10*time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, wantReserved, reserved, "key %s at %s", key, now)
+}
+
+func assertRelease(t *testing.T, store WindowStore, key string, now time.Time) {
+	assert.NoError(t, store.Release(context.Background(), key, now))
+}
@@ -0,0 +1,136 @@
+package deduplication
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/env"
+)
+
+// WindowStore records when keys were last reserved, so that keys reserved again within a time window can be recognized.
+// Checking and recording a key is a single atomic step, so that concurrent reservations of the same key cannot both succeed.
+// If the action guarded by a reservation fails, the reservation can be released so that the action can be retried.
+type WindowStore interface {
+	// Reserve records the key at the specified time and returns true, or returns false if the key has already been recorded within the window before the specified time.
+	Reserve(ctx context.Context, key string, now time.Time, window time.Duration) (bool, error)
+
+	// Release forgets the key if it was recorded at the specified time, i.e. releases the reservation made at that time.
+	Release(ctx context.Context, key string, now time.Time) error
+}
+
+// windowEntry is a key and the time it was last recorded, as persisted by the file and ConfigMap window stores.
+type windowEntry struct {
+	Key  string    `json:"key"`
+	Time time.Time `json:"time"`
+}
+
+// problemWindowsSuffix is appended to the name of the file or ConfigMap of the event deduplication store to persist the problem deduplication windows.
+const problemWindowsSuffix = "-problem-windows"
+
+// NewDefaultWindowStore creates the WindowStore for the problem deduplication windows and cooldowns using the backend configured by the EVENT_DEDUPLICATION_* environment variables.
+// If the event deduplication store is disabled, the windows are held in memory.
+func NewDefaultWindowStore() (WindowStore, error) {
+	switch storeType := env.GetEventDeduplicationStore(); storeType {
+	case storeTypeNone, storeTypeMemory:
+		return NewInMemoryWindowStore(env.GetEventDeduplicationMaxEntries()), nil
+	case storeTypeConfigMap:
+		return NewDefaultConfigMapWindowStore(env.GetEventDeduplicationConfigMap()+problemWindowsSuffix, env.GetEventDeduplicationConfigMapMaxEntries())
+	case storeTypeFile:
+		path := env.GetEventDeduplicationFile()
+		if path == "" {
+			return nil, fmt.Errorf("no file for the event deduplication store has been specified")
+		}
+		return NewFileWindowStore(path+problemWindowsSuffix, env.GetEventDeduplicationMaxEntries())
+	default:
+		return nil, fmt.Errorf("unknown event deduplication store type: %s", storeType)
+	}
+}
+
+// InMemoryWindowStore is a WindowStore holding the most recently added keys in memory.
+type InMemoryWindowStore struct {
+	mutex     sync.Mutex
+	keys      *boundedSet
+	addedTime map[string]time.Time
+}
+
+// NewInMemoryWindowStore creates a new InMemoryWindowStore holding at most maxEntries keys.
+func NewInMemoryWindowStore(maxEntries int) *InMemoryWindowStore {
+	return newInMemoryWindowStore(maxEntries, nil)
+}
+
+// newInMemoryWindowStore creates a new InMemoryWindowStore holding at most maxEntries keys, initialized with the entries ordered oldest first.
+func newInMemoryWindowStore(maxEntries int, entries []windowEntry) *InMemoryWindowStore {
+	s := &InMemoryWindowStore{
+		keys:      newBoundedSet(maxEntries, nil),
+		addedTime: make(map[string]time.Time),
+	}
+	s.keys.onEvict = func(key string) {
+		delete(s.addedTime, key)
+	}
+	for _, entry := range entries {
+		s.record(entry.Key, entry.Time)
+	}
+	return s
+}
+
+// Reserve records the key at the specified time and returns true, or returns false if the key has already been recorded within the window before the specified time.
+func (s *InMemoryWindowStore) Reserve(_ context.Context, key string, now time.Time, window time.Duration) (bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.reserve(key, now, window), nil
+}
+
+// Release forgets the key if it was recorded at the specified time.
+func (s *InMemoryWindowStore) Release(_ context.Context, key string, now time.Time) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.release(key, now)
+	return nil
+}
+
+// reserve records the key at the specified time and returns true, or returns false if the key has already been recorded within the window. The caller must hold the mutex.
+func (s *InMemoryWindowStore) reserve(key string, now time.Time, window time.Duration) bool {
+	if addedTime, ok := s.addedTime[key]; ok && now.Sub(addedTime) < window {
+		return false
+	}
+
+	s.record(key, now)
+	return true
+}
+
+// release forgets the key and returns true if it was recorded at the specified time, or returns false otherwise. The caller must hold the mutex.
+func (s *InMemoryWindowStore) release(key string, now time.Time) bool {
+	addedTime, ok := s.addedTime[key]
+	if !ok || !addedTime.Equal(now) {
+		return false
+	}
+
+	s.keys.remove(key)
+	delete(s.addedTime, key)
+	return true
+}
+
+// record records the key at the specified time. The caller must hold the mutex.
+func (s *InMemoryWindowStore) record(key string, now time.Time) {
+	// re-adding the key makes it the most recent one, so that it is evicted last
+	s.keys.remove(key)
+	s.keys.add(key)
+	s.addedTime[key] = now
+}
+
+// entries returns the recorded keys and times, oldest first.
+func (s *InMemoryWindowStore) entries() []windowEntry {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	keys := s.keys.keys()
+	entries := make([]windowEntry, 0, len(keys))
+	for _, key := range keys {
+		entries = append(entries, windowEntry{Key: key, Time: s.addedTime[key]})
+	}
+	return entries
+}
@@ -106,3 +106,34 @@ func (apc *AlertingProfilesClient) Create(ctx context.Context, alertingProfile *
 
 	return createdItem.ID, nil
 }
+
+// GetByID gets the alerting profile with the specified ID.
+func (apc *AlertingProfilesClient) GetByID(ctx context.Context, id string) (*AlertingProfile, error) {
+	response, err := apc.client.Get(ctx, alertingProfilesPath+"/"+id)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve alerting profile: %v", err)
+	}
+
+	alertingProfile := &AlertingProfile{}
+	err = json.Unmarshal(response, alertingProfile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal alerting profile: %v", err)
+	}
+
+	return alertingProfile, nil
+}
+
+// Update updates the alerting profile with the specified ID.
+func (apc *AlertingProfilesClient) Update(ctx context.Context, id string, alertingProfile *AlertingProfile) error {
+	alertingProfilePayload, err := json.Marshal(alertingProfile)
+	if err != nil {
+		return fmt.Errorf("failed to marshal alerting profile: %v", err)
+	}
+
+	_, err = apc.client.Put(ctx, alertingProfilesPath+"/"+id, alertingProfilePayload)
+	if err != nil {
+		return fmt.Errorf("failed to update alerting profile: %v", err)
+	}
+
+	return nil
+}
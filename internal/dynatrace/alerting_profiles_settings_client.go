@@ -0,0 +1,196 @@
+package dynatrace
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+const alertingProfilesSchemaID = "builtin:alerting.profile"
+
+const alertingProfileEventFilterTypeCustom = "CUSTOM"
+
+type alertingProfileSettings struct {
+	Name           string                                `json:"name"`
+	ManagementZone string                                `json:"managementZone,omitempty"`
+	SeverityRules  []alertingProfileSettingsSeverityRule `json:"severityRules"`
+	EventFilters   []alertingProfileSettingsEventFilter  `json:"eventFilters"`
+}
+
+type alertingProfileSettingsSeverityRule struct {
+	SeverityLevel        string   `json:"severityLevel"`
+	DelayInMinutes       int      `json:"delayInMinutes"`
+	TagFilterIncludeMode string   `json:"tagFilterIncludeMode"`
+	TagFilter            []string `json:"tagFilter,omitempty"`
+}
+
+type alertingProfileSettingsEventFilter struct {
+	Type         string                               `json:"type"`
+	CustomFilter *alertingProfileSettingsCustomFilter `json:"customFilter,omitempty"`
+}
+
+type alertingProfileSettingsCustomFilter struct {
+	TitleFilter *alertingProfileSettingsTextFilter `json:"titleFilter,omitempty"`
+}
+
+type alertingProfileSettingsTextFilter struct {
+	Enabled       bool   `json:"enabled"`
+	Value         string `json:"value"`
+	Operator      string `json:"operator"`
+	Negate        bool   `json:"negate"`
+	CaseSensitive bool   `json:"caseSensitive"`
+}
+
+// AlertingProfilesSettingsClient is a client for alerting profiles using the Settings 2.0 API.
+type AlertingProfilesSettingsClient struct {
+	client *SettingsClient
+}
+
+// NewAlertingProfilesSettingsClient creates a new AlertingProfilesSettingsClient.
+func NewAlertingProfilesSettingsClient(client ClientInterface) *AlertingProfilesSettingsClient {
+	return &AlertingProfilesSettingsClient{
+		client: NewSettingsClient(client),
+	}
+}
+
+// GetProfileID returns the settings object ID for the given profileName if found, an empty string otherwise.
+func (apc *AlertingProfilesSettingsClient) GetProfileID(ctx context.Context, profileName string) (string, error) {
+	objects, err := apc.client.GetAll(ctx, alertingProfilesSchemaID)
+	if err != nil {
+		return "", fmt.Errorf("could not retrieve alerting profiles: %v", err)
+	}
+
+	for _, object := range objects {
+		alertingProfile := &alertingProfileSettings{}
+		err = json.Unmarshal(object.Value, alertingProfile)
+		if err != nil {
+			return "", fmt.Errorf("failed to unmarshal alerting profile: %v", err)
+		}
+
+		if alertingProfile.Name == profileName {
+			return object.ObjectID, nil
+		}
+	}
+
+	return "", nil
+}
+
+// Create creates an alerting profile and returns its settings object ID.
+func (apc *AlertingProfilesSettingsClient) Create(ctx context.Context, alertingProfile *AlertingProfile) (string, error) {
+	id, err := apc.client.Create(ctx, alertingProfilesSchemaID, toAlertingProfileSettings(alertingProfile))
+	if err != nil {
+		return "", fmt.Errorf("failed to setup alerting profile: %v", err)
+	}
+
+	return id, nil
+}
+
+// GetByID gets the alerting profile with the specified settings object ID.
+func (apc *AlertingProfilesSettingsClient) GetByID(ctx context.Context, id string) (*AlertingProfile, error) {
+	object, err := apc.client.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve alerting profile: %v", err)
+	}
+
+	alertingProfile := &alertingProfileSettings{}
+	err = json.Unmarshal(object.Value, alertingProfile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal alerting profile: %v", err)
+	}
+
+	return fromAlertingProfileSettings(id, alertingProfile), nil
+}
+
+// Update updates the alerting profile with the specified settings object ID.
+func (apc *AlertingProfilesSettingsClient) Update(ctx context.Context, id string, alertingProfile *AlertingProfile) error {
+	err := apc.client.Update(ctx, id, toAlertingProfileSettings(alertingProfile))
+	if err != nil {
+		return fmt.Errorf("failed to update alerting profile: %v", err)
+	}
+
+	return nil
+}
+
+// toAlertingProfileSettings converts an alerting profile to its Settings 2.0 representation. Only custom event title filters are supported.
+func toAlertingProfileSettings(alertingProfile *AlertingProfile) *alertingProfileSettings {
+	settings := &alertingProfileSettings{
+		Name:          alertingProfile.DisplayName,
+		SeverityRules: make([]alertingProfileSettingsSeverityRule, 0, len(alertingProfile.Rules)),
+		EventFilters:  make([]alertingProfileSettingsEventFilter, 0, len(alertingProfile.EventTypeFilters)),
+	}
+
+	if alertingProfile.ManagementZoneID != nil {
+		settings.ManagementZone = fmt.Sprint(alertingProfile.ManagementZoneID)
+	}
+
+	for _, rule := range alertingProfile.Rules {
+		settings.SeverityRules = append(settings.SeverityRules, alertingProfileSettingsSeverityRule{
+			SeverityLevel:        rule.SeverityLevel,
+			DelayInMinutes:       rule.DelayInMinutes,
+			TagFilterIncludeMode: rule.TagFilter.IncludeMode,
+			TagFilter:            rule.TagFilter.TagFilters,
+		})
+	}
+
+	for _, filter := range alertingProfile.EventTypeFilters {
+		if filter == nil {
+			continue
+		}
+
+		titleFilter := filter.CustomEventFilter.CustomTitleFilter
+		settings.EventFilters = append(settings.EventFilters, alertingProfileSettingsEventFilter{
+			Type: alertingProfileEventFilterTypeCustom,
+			CustomFilter: &alertingProfileSettingsCustomFilter{
+				TitleFilter: &alertingProfileSettingsTextFilter{
+					Enabled:       titleFilter.Enabled,
+					Value:         titleFilter.Value,
+					Operator:      titleFilter.Operator,
+					Negate:        titleFilter.Negate,
+					CaseSensitive: !titleFilter.CaseInsensitive,
+				},
+			},
+		})
+	}
+	return settings
+}
+
+// fromAlertingProfileSettings converts the Settings 2.0 representation of an alerting profile. Event filters other than custom event title filters are ignored.
+func fromAlertingProfileSettings(id string, settings *alertingProfileSettings) *AlertingProfile {
+	alertingProfile := &AlertingProfile{
+		ID:          id,
+		DisplayName: settings.Name,
+		Rules:       make([]AlertingProfileRules, 0, len(settings.SeverityRules)),
+	}
+
+	if settings.ManagementZone != "" {
+		alertingProfile.ManagementZoneID = settings.ManagementZone
+	}
+
+	for _, rule := range settings.SeverityRules {
+		alertingProfile.Rules = append(alertingProfile.Rules, AlertingProfileRules{
+			SeverityLevel:  rule.SeverityLevel,
+			TagFilter:      AlertingProfileTagFilter{IncludeMode: rule.TagFilterIncludeMode, TagFilters: rule.TagFilter},
+			DelayInMinutes: rule.DelayInMinutes,
+		})
+	}
+
+	for _, filter := range settings.EventFilters {
+		if filter.Type != alertingProfileEventFilterTypeCustom || filter.CustomFilter == nil || filter.CustomFilter.TitleFilter == nil {
+			continue
+		}
+
+		titleFilter := filter.CustomFilter.TitleFilter
+		alertingProfile.EventTypeFilters = append(alertingProfile.EventTypeFilters, &AlertingProfileEventTypeFilter{
+			CustomEventFilter: CustomEventFilter{
+				CustomTitleFilter: CustomTitleFilter{
+					Enabled:         titleFilter.Enabled,
+					Value:           titleFilter.Value,
+					Operator:        titleFilter.Operator,
+					Negate:          titleFilter.Negate,
+					CaseInsensitive: !titleFilter.CaseSensitive,
+				},
+			},
+		})
+	}
+	return alertingProfile
+}
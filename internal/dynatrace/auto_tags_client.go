@@ -3,6 +3,7 @@ package dynatrace
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 
 	log "github.com/sirupsen/logrus"
 )
@@ -87,3 +88,52 @@ func (atc *AutoTagsClient) GetAllTagNames(ctx context.Context) (*TagNames, error
 			func(values values) string { return values.Name }),
 	}, nil
 }
+
+// GetTagIDByName returns the ID of the tag rule with the specified name if found, an empty string otherwise.
+func (atc *AutoTagsClient) GetTagIDByName(ctx context.Context, name string) (string, error) {
+	response, err := atc.client.Get(ctx, autoTagsPath)
+	if err != nil {
+		return "", fmt.Errorf("could not retrieve tagging rules: %v", err)
+	}
+
+	existingDTRules := &listResponse{}
+	err = json.Unmarshal(response, existingDTRules)
+	if err != nil {
+		return "", fmt.Errorf("failed to unmarshal tagging rules: %v", err)
+	}
+
+	for _, rule := range existingDTRules.Values {
+		if rule.Name == name {
+			return rule.ID, nil
+		}
+	}
+	return "", nil
+}
+
+// GetByID gets the auto-tagging rule with the specified ID.
+func (atc *AutoTagsClient) GetByID(ctx context.Context, id string) (*DTTaggingRule, error) {
+	response, err := atc.client.Get(ctx, autoTagsPath+"/"+id)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve tagging rule: %v", err)
+	}
+
+	rule := &DTTaggingRule{}
+	err = json.Unmarshal(response, rule)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal tagging rule: %v", err)
+	}
+
+	return rule, nil
+}
+
+// Update updates the auto-tagging rule with the specified ID.
+func (atc *AutoTagsClient) Update(ctx context.Context, id string, rule *DTTaggingRule) error {
+	log.WithField("name", rule.Name).Info("Updating DT tagging rule")
+	payload, err := json.Marshal(rule)
+	if err != nil {
+		return err
+	}
+
+	_, err = atc.client.Put(ctx, autoTagsPath+"/"+id, payload)
+	return err
+}
@@ -0,0 +1,202 @@
+package dynatrace
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const autoTagsSchemaID = "builtin:tags.auto-tagging"
+
+type autoTagSettings struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Rules       []settingsRule `json:"rules"`
+}
+
+// AutoTagsSettingsClient is a client for auto-tagging rules using the Settings 2.0 API.
+type AutoTagsSettingsClient struct {
+	client *SettingsClient
+}
+
+// NewAutoTagsSettingsClient creates a new AutoTagsSettingsClient.
+func NewAutoTagsSettingsClient(client ClientInterface) *AutoTagsSettingsClient {
+	return &AutoTagsSettingsClient{
+		client: NewSettingsClient(client),
+	}
+}
+
+func (atc *AutoTagsSettingsClient) getAll(ctx context.Context) (*listResponse, error) {
+	objects, err := atc.client.GetAll(ctx, autoTagsSchemaID)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve tagging rules: %v", err)
+	}
+
+	response := &listResponse{}
+	for _, object := range objects {
+		rule := &autoTagSettings{}
+		err = json.Unmarshal(object.Value, rule)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal tagging rule: %v", err)
+		}
+		response.Values = append(response.Values, values{ID: object.ObjectID, Name: rule.Name})
+	}
+	return response, nil
+}
+
+// Create creates an auto-tagging rule.
+func (atc *AutoTagsSettingsClient) Create(ctx context.Context, rule *DTTaggingRule) error {
+	log.WithField("name", rule.Name).Info("Creating DT tagging rule")
+	_, err := atc.client.Create(ctx, autoTagsSchemaID, toAutoTagSettings(rule))
+	return err
+}
+
+// GetAllTagNames gets names of all tag rules.
+func (atc *AutoTagsSettingsClient) GetAllTagNames(ctx context.Context) (*TagNames, error) {
+	response, err := atc.getAll(ctx)
+	if err != nil {
+		log.WithError(err).Error("Could not get existing tagging rules")
+		return nil, err
+	}
+
+	return &TagNames{
+		response.ToStringSetWith(
+			func(values values) string { return values.Name }),
+	}, nil
+}
+
+// GetTagIDByName returns the settings object ID of the tag rule with the specified name if found, an empty string otherwise.
+func (atc *AutoTagsSettingsClient) GetTagIDByName(ctx context.Context, name string) (string, error) {
+	response, err := atc.getAll(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	for _, rule := range response.Values {
+		if rule.Name == name {
+			return rule.ID, nil
+		}
+	}
+	return "", nil
+}
+
+// GetByID gets the auto-tagging rule with the specified settings object ID.
+func (atc *AutoTagsSettingsClient) GetByID(ctx context.Context, id string) (*DTTaggingRule, error) {
+	object, err := atc.client.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve tagging rule: %v", err)
+	}
+
+	rule := &autoTagSettings{}
+	err = json.Unmarshal(object.Value, rule)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal tagging rule: %v", err)
+	}
+
+	return fromAutoTagSettings(rule), nil
+}
+
+// Update updates the auto-tagging rule with the specified settings object ID.
+func (atc *AutoTagsSettingsClient) Update(ctx context.Context, id string, rule *DTTaggingRule) error {
+	log.WithField("name", rule.Name).Info("Updating DT tagging rule")
+	return atc.client.Update(ctx, id, toAutoTagSettings(rule))
+}
+
+// toAutoTagSettings converts an auto-tagging rule to its Settings 2.0 representation. Only string and tag conditions are supported.
+func toAutoTagSettings(rule *DTTaggingRule) *autoTagSettings {
+	settings := &autoTagSettings{
+		Name:  rule.Name,
+		Rules: make([]settingsRule, 0, len(rule.Rules)),
+	}
+
+	for _, r := range rule.Rules {
+		conditions := make([]settingsAttributeCondition, 0, len(r.Conditions))
+		for _, condition := range r.Conditions {
+			settingsCondition := settingsAttributeCondition{
+				Key:              condition.Key.Attribute,
+				Operator:         toSettingsOperator(condition.ComparisonInfo.Operator, condition.ComparisonInfo.Negate),
+				DynamicKey:       condition.Key.DynamicKey.Key,
+				DynamicKeySource: condition.Key.DynamicKey.Source,
+			}
+
+			if caseSensitive, ok := condition.ComparisonInfo.CaseSensitive.(bool); ok {
+				settingsCondition.CaseSensitive = &caseSensitive
+			}
+
+			switch value := condition.ComparisonInfo.Value.(type) {
+			case string:
+				if condition.ComparisonInfo.Type == comparisonTypeTag {
+					settingsCondition.Tag = value
+				} else {
+					settingsCondition.StringValue = value
+				}
+			case map[string]interface{}:
+				context, _ := value["context"].(string)
+				key, _ := value["key"].(string)
+				tagValue, _ := value["value"].(string)
+				settingsCondition.Tag = formatSettingsTag(context, key, tagValue)
+			}
+
+			conditions = append(conditions, settingsCondition)
+		}
+
+		settings.Rules = append(settings.Rules, settingsRule{
+			Enabled:            r.Enabled,
+			Type:               settingsRuleTypeMonitoredEntity,
+			ValueFormat:        r.ValueFormat,
+			ValueNormalization: autoTagValueNormalizationAsIs,
+			AttributeRule:      newSettingsAttributeRule(r.Type, r.PropagationTypes, conditions),
+		})
+	}
+	return settings
+}
+
+// fromAutoTagSettings converts the Settings 2.0 representation of an auto-tagging rule. Rules other than attribute rules are ignored.
+func fromAutoTagSettings(settings *autoTagSettings) *DTTaggingRule {
+	rule := &DTTaggingRule{
+		Name:  settings.Name,
+		Rules: make([]Rules, 0, len(settings.Rules)),
+	}
+
+	for _, r := range settings.Rules {
+		if r.AttributeRule == nil {
+			continue
+		}
+
+		conditions := make([]Conditions, 0, len(r.AttributeRule.Conditions))
+		for _, condition := range r.AttributeRule.Conditions {
+			operator, negate := fromSettingsOperator(condition.Operator)
+
+			key := Key{Attribute: condition.Key, Type: keyTypeStatic}
+			if condition.DynamicKey != "" {
+				key.Type = keyTypeProcessCustomMetadataKey
+				key.DynamicKey = DynamicKey{Source: condition.DynamicKeySource, Key: condition.DynamicKey}
+			}
+
+			comparisonInfo := ComparisonInfo{Type: comparisonTypeString, Operator: operator, Negate: negate}
+			if condition.CaseSensitive != nil {
+				comparisonInfo.CaseSensitive = *condition.CaseSensitive
+			}
+			if condition.Tag != "" {
+				comparisonInfo.Type = comparisonTypeTag
+				context, tagKey, tagValue := parseSettingsTag(condition.Tag)
+				comparisonInfo.Value = map[string]interface{}{"context": context, "key": tagKey, "value": tagValue}
+			} else if condition.StringValue != "" {
+				comparisonInfo.Value = condition.StringValue
+			}
+
+			conditions = append(conditions, Conditions{Key: key, ComparisonInfo: comparisonInfo})
+		}
+
+		rule.Rules = append(rule.Rules, Rules{
+			Type:             r.AttributeRule.EntityType,
+			Enabled:          r.Enabled,
+			ValueFormat:      r.ValueFormat,
+			PropagationTypes: r.AttributeRule.getPropagationTypes(),
+			Conditions:       conditions,
+		})
+	}
+	return rule
+}
@@ -0,0 +1,210 @@
+package dynatrace
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/cache"
+	"github.com/keptn-contrib/dynatrace-service/internal/credentials"
+	"github.com/keptn-contrib/dynatrace-service/internal/env"
+	"github.com/keptn-contrib/dynatrace-service/internal/metrics"
+)
+
+const (
+	cacheCategoryMetricDefinitions = "metric_definitions"
+	cacheCategoryUnitConversions   = "unit_conversions"
+	cacheCategoryDashboards        = "dashboards"
+	cacheCategoryManagementZones   = "management_zones"
+)
+
+// cacheCategory describes a group of cacheable GET requests.
+type cacheCategory struct {
+	name string
+
+	// basePath is the path of the API. Any write to it invalidates all cached responses of the category.
+	basePath string
+
+	// matches returns whether a GET request to the API path can be cached.
+	matches func(apiPath string) bool
+
+	ttl time.Duration
+}
+
+// createCacheCategories creates the cache categories using the configured time-to-live values.
+func createCacheCategories() []cacheCategory {
+	return []cacheCategory{
+		{
+			name:     cacheCategoryMetricDefinitions,
+			basePath: MetricsPath,
+			matches: func(apiPath string) bool {
+				return strings.HasPrefix(apiPath, MetricsPath+"/") && !strings.HasPrefix(apiPath, MetricsQueryPath)
+			},
+			ttl: env.GetAPICacheMetricDefinitionsTTL(),
+		},
+		{
+			name:     cacheCategoryUnitConversions,
+			basePath: MetricsUnitsPath,
+			matches: func(apiPath string) bool {
+				return strings.HasPrefix(apiPath, MetricsUnitsPath+"/") && strings.Contains(apiPath, "/convert?")
+			},
+			ttl: env.GetAPICacheUnitConversionsTTL(),
+		},
+		{
+			name:     cacheCategoryDashboards,
+			basePath: DashboardsPath,
+			matches: func(apiPath string) bool {
+				return apiPath == DashboardsPath
+			},
+			ttl: env.GetAPICacheDashboardsTTL(),
+		},
+		{
+			name:     cacheCategoryManagementZones,
+			basePath: managementZonesPath,
+			matches: func(apiPath string) bool {
+				return apiPath == managementZonesPath
+			},
+			ttl: env.GetAPICacheManagementZonesTTL(),
+		},
+	}
+}
+
+// responseCacheKey identifies a cached response. Responses are cached per credentials, as credentials for the same tenant may differ in the scopes they grant.
+type responseCacheKey struct {
+	tenant          string
+	credentialsHash string
+	apiPath         string
+}
+
+type responseCacheEntry struct {
+	category string
+	body     []byte
+}
+
+// responseCache holds the cached responses of all tenants together with the cache categories.
+type responseCache struct {
+	categories []cacheCategory
+	entries    *cache.TTLCache[responseCacheKey, responseCacheEntry]
+}
+
+func newResponseCache(categories []cacheCategory, maxEntries int) *responseCache {
+	return &responseCache{
+		categories: categories,
+		entries:    cache.NewTTLCache[responseCacheKey, responseCacheEntry](maxEntries),
+	}
+}
+
+var defaultResponseCache *responseCache
+var defaultResponseCacheOnce sync.Once
+
+// getDefaultResponseCache returns the response cache shared by all clients of the process.
+func getDefaultResponseCache() *responseCache {
+	defaultResponseCacheOnce.Do(func() {
+		defaultResponseCache = newResponseCache(createCacheCategories(), env.GetAPICacheMaxEntries())
+	})
+	return defaultResponseCache
+}
+
+// CachingClient is a ClientInterface decorator that caches the responses of immutable or rarely changing lookups.
+type CachingClient struct {
+	client ClientInterface
+	cache  *responseCache
+}
+
+// NewCachingClient creates a new CachingClient using the response cache shared by all clients of the process.
+func NewCachingClient(client ClientInterface) *CachingClient {
+	return newCachingClientWithCache(client, getDefaultResponseCache())
+}
+
+func newCachingClientWithCache(client ClientInterface, cache *responseCache) *CachingClient {
+	return &CachingClient{
+		client: client,
+		cache:  cache,
+	}
+}
+
+// Get performs a get request, returning a cached response if available.
+func (c *CachingClient) Get(ctx context.Context, apiPath string) ([]byte, error) {
+	category, ok := c.getCategory(apiPath)
+	if !ok {
+		return c.client.Get(ctx, apiPath)
+	}
+
+	dynatraceCredentials := c.client.Credentials()
+	key := responseCacheKey{tenant: dynatraceCredentials.GetTenant(), credentialsHash: getCredentialsHash(dynatraceCredentials), apiPath: apiPath}
+	if entry, found := c.cache.entries.Get(key); found {
+		metrics.DynatraceAPICacheRequestsTotal.WithLabelValues(category.name, "hit").Inc()
+		return entry.body, nil
+	}
+
+	metrics.DynatraceAPICacheRequestsTotal.WithLabelValues(category.name, "miss").Inc()
+	body, err := c.client.Get(ctx, apiPath)
+	if err != nil {
+		return body, err
+	}
+
+	c.cache.entries.Set(key, responseCacheEntry{category: category.name, body: body}, category.ttl)
+	return body, nil
+}
+
+// Post performs a post request and invalidates any affected cached responses.
+func (c *CachingClient) Post(ctx context.Context, apiPath string, body []byte) ([]byte, error) {
+	defer c.invalidate(apiPath)
+	return c.client.Post(ctx, apiPath, body)
+}
+
+// Put performs a put request and invalidates any affected cached responses.
+func (c *CachingClient) Put(ctx context.Context, apiPath string, body []byte) ([]byte, error) {
+	defer c.invalidate(apiPath)
+	return c.client.Put(ctx, apiPath, body)
+}
+
+// Delete performs a delete request and invalidates any affected cached responses.
+func (c *CachingClient) Delete(ctx context.Context, apiPath string) ([]byte, error) {
+	defer c.invalidate(apiPath)
+	return c.client.Delete(ctx, apiPath)
+}
+
+// Credentials returns the credentials associated with the client.
+func (c *CachingClient) Credentials() *credentials.DynatraceCredentials {
+	return c.client.Credentials()
+}
+
+func (c *CachingClient) getCategory(apiPath string) (cacheCategory, bool) {
+	for _, category := range c.cache.categories {
+		if category.ttl > 0 && category.matches(apiPath) {
+			return category, true
+		}
+	}
+	return cacheCategory{}, false
+}
+
+// getCredentialsHash returns a hash of the API token or OAuth client credentials, so that these are not held in the cache keys themselves.
+func getCredentialsHash(dynatraceCredentials *credentials.DynatraceCredentials) string {
+	hash := sha256.New()
+	if oauthCredentials, ok := dynatraceCredentials.GetOAuthCredentials(); ok {
+		hash.Write([]byte("oauth\x00" + oauthCredentials.GetClientID() + "\x00" + oauthCredentials.GetClientSecret() + "\x00" + oauthCredentials.GetSSOURL()))
+	} else {
+		hash.Write([]byte("token\x00" + dynatraceCredentials.GetAPIToken()))
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// invalidate removes the cached responses of all categories whose API is modified by a write to the API path.
+// Responses cached for other credentials of the same tenant are removed as well, as they reflect the same configuration.
+func (c *CachingClient) invalidate(apiPath string) {
+	tenant := c.client.Credentials().GetTenant()
+	for _, category := range c.cache.categories {
+		if !strings.HasPrefix(apiPath, category.basePath) {
+			continue
+		}
+
+		categoryName := category.name
+		c.cache.entries.RemoveIf(func(key responseCacheKey, entry responseCacheEntry) bool {
+			return key.tenant == tenant && entry.category == categoryName
+		})
+	}
+}
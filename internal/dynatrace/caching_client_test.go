@@ -0,0 +1,119 @@
+package dynatrace
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/credentials"
+	"github.com/keptn-contrib/dynatrace-service/internal/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCachingClient tests that cacheable lookups are served from the cache, other requests are passed through and writes invalidate cached responses.
+func TestCachingClient(t *testing.T) {
+	requestCounts := map[string]*int32{}
+	handler := http.NewServeMux()
+	for _, path := range []string{DashboardsPath, MetricsPath + "/builtin:service.response.time", MetricsQueryPath, managementZonesPath} {
+		count := new(int32)
+		requestCounts[path] = count
+		handler.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(count, 1)
+			_, _ = w.Write([]byte(`{}`))
+		})
+	}
+
+	dtClient, _, teardown := createDynatraceClient(t, handler)
+	defer teardown()
+
+	categories := []cacheCategory{
+		{
+			name:     cacheCategoryMetricDefinitions,
+			basePath: MetricsPath,
+			matches: func(apiPath string) bool {
+				return apiPath == MetricsPath+"/builtin:service.response.time"
+			},
+			ttl: time.Minute,
+		},
+		{
+			name:     cacheCategoryDashboards,
+			basePath: DashboardsPath,
+			matches: func(apiPath string) bool {
+				return apiPath == DashboardsPath
+			},
+			ttl: time.Minute,
+		},
+		{
+			name:     cacheCategoryManagementZones,
+			basePath: managementZonesPath,
+			matches: func(apiPath string) bool {
+				return apiPath == managementZonesPath
+			},
+			ttl: 0,
+		},
+	}
+	client := newCachingClientWithCache(dtClient, newResponseCache(categories, 10))
+
+	for i := 0; i < 3; i++ {
+		for _, path := range []string{DashboardsPath, MetricsPath + "/builtin:service.response.time", MetricsQueryPath, managementZonesPath} {
+			_, err := client.Get(context.Background(), path)
+			assert.NoError(t, err)
+		}
+	}
+
+	assert.EqualValues(t, 1, atomic.LoadInt32(requestCounts[DashboardsPath]))
+	assert.EqualValues(t, 1, atomic.LoadInt32(requestCounts[MetricsPath+"/builtin:service.response.time"]))
+	assert.EqualValues(t, 3, atomic.LoadInt32(requestCounts[MetricsQueryPath]), "uncacheable requests should not be cached")
+	assert.EqualValues(t, 3, atomic.LoadInt32(requestCounts[managementZonesPath]), "categories without time-to-live should not be cached")
+
+	// creating a dashboard should invalidate the list of dashboards
+	_, _ = client.Post(context.Background(), DashboardsPath, []byte(`{}`))
+	_, err := client.Get(context.Background(), DashboardsPath)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 3, atomic.LoadInt32(requestCounts[DashboardsPath]))
+
+	_, err = client.Get(context.Background(), MetricsPath+"/builtin:service.response.time")
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, atomic.LoadInt32(requestCounts[MetricsPath+"/builtin:service.response.time"]))
+}
+
+// TestCachingClient_DifferentCredentials tests that responses cached for one API token of a tenant are not served to clients using another API token of the same tenant.
+func TestCachingClient_DifferentCredentials(t *testing.T) {
+	var requestCount int32
+	handler := http.NewServeMux()
+	handler.HandleFunc(DashboardsPath, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requestCount, 1)
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	httpClient, url, teardown := test.CreateHTTPSClient(handler)
+	defer teardown()
+
+	otherCredentials, err := credentials.NewDynatraceCredentials(url, "dt0c01.AAAAAAAAAAAAAAAAAAAAAAAA.BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB")
+	assert.NoError(t, err)
+
+	categories := []cacheCategory{
+		{
+			name:     cacheCategoryDashboards,
+			basePath: DashboardsPath,
+			matches: func(apiPath string) bool {
+				return apiPath == DashboardsPath
+			},
+			ttl: time.Minute,
+		},
+	}
+	responseCache := newResponseCache(categories, 10)
+	client := newCachingClientWithCache(NewClientWithHTTP(createDynatraceCredentials(t, url), httpClient), responseCache)
+	otherClient := newCachingClientWithCache(NewClientWithHTTP(otherCredentials, httpClient), responseCache)
+
+	for i := 0; i < 2; i++ {
+		_, err = client.Get(context.Background(), DashboardsPath)
+		assert.NoError(t, err)
+		_, err = otherClient.Get(context.Background(), DashboardsPath)
+		assert.NoError(t, err)
+	}
+
+	assert.EqualValues(t, 2, atomic.LoadInt32(&requestCount), "each API token should be served from its own cached response")
+}
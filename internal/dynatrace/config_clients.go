@@ -0,0 +1,138 @@
+package dynatrace
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// ConfigAPIVersion1 selects the deprecated Configuration API v1.
+const ConfigAPIVersion1 = "v1"
+
+// ConfigAPISettings selects the Settings 2.0 API.
+const ConfigAPISettings = "settings"
+
+// ConfigAPIAuto selects the Settings 2.0 API if it is available and the Configuration API v1 otherwise.
+const ConfigAPIAuto = "auto"
+
+// ManagementZonesClientInterface manages management zones.
+type ManagementZonesClientInterface interface {
+	// GetAll gets all management zones.
+	GetAll(ctx context.Context) (*ManagementZones, error)
+
+	// Create creates a management zone.
+	Create(ctx context.Context, managementZone *ManagementZone) error
+
+	// GetByID gets the management zone with the specified ID.
+	GetByID(ctx context.Context, id string) (*ManagementZone, error)
+
+	// Update updates the management zone with the specified ID.
+	Update(ctx context.Context, id string, managementZone *ManagementZone) error
+
+	// Delete deletes the management zone with the specified ID.
+	Delete(ctx context.Context, id string) error
+}
+
+// AutoTagsClientInterface manages auto-tagging rules.
+type AutoTagsClientInterface interface {
+	// Create creates an auto-tagging rule.
+	Create(ctx context.Context, rule *DTTaggingRule) error
+
+	// GetAllTagNames gets names of all tag rules.
+	GetAllTagNames(ctx context.Context) (*TagNames, error)
+
+	// GetTagIDByName returns the ID of the tag rule with the specified name if found, an empty string otherwise.
+	GetTagIDByName(ctx context.Context, name string) (string, error)
+
+	// GetByID gets the auto-tagging rule with the specified ID.
+	GetByID(ctx context.Context, id string) (*DTTaggingRule, error)
+
+	// Update updates the auto-tagging rule with the specified ID.
+	Update(ctx context.Context, id string, rule *DTTaggingRule) error
+}
+
+// AlertingProfilesClientInterface manages alerting profiles.
+type AlertingProfilesClientInterface interface {
+	// GetProfileID returns the profile ID for the given profileName if found, an empty string otherwise.
+	GetProfileID(ctx context.Context, profileName string) (string, error)
+
+	// Create creates an alerting profile and returns its ID.
+	Create(ctx context.Context, alertingProfile *AlertingProfile) (string, error)
+
+	// GetByID gets the alerting profile with the specified ID.
+	GetByID(ctx context.Context, id string) (*AlertingProfile, error)
+
+	// Update updates the alerting profile with the specified ID.
+	Update(ctx context.Context, id string, alertingProfile *AlertingProfile) error
+}
+
+// NotificationsClientInterface manages problem notifications.
+type NotificationsClientInterface interface {
+	// GetKeptnProblemNotificationIDs returns the IDs of all existing Keptn problem notifications, i.e. of all notifications whose names start with KeptnProblemNotificationName.
+	GetKeptnProblemNotificationIDs(ctx context.Context) ([]string, error)
+
+	// GetIDsByName returns the IDs of all existing problem notifications with the specified name.
+	GetIDsByName(ctx context.Context, name string) ([]string, error)
+
+	// Create creates a problem notification.
+	Create(ctx context.Context, notification *Notification) error
+
+	// GetByID gets the problem notification with the specified ID.
+	GetByID(ctx context.Context, id string) (*Notification, error)
+
+	// Update updates the problem notification with the specified ID.
+	Update(ctx context.Context, id string, notification *Notification) error
+
+	// DeleteByID deletes the problem notification with the specified ID.
+	DeleteByID(ctx context.Context, id string) error
+}
+
+// ConfigClients contains the clients for the configuration entities of a Dynatrace tenant generated by the dynatrace-service.
+// All clients use the same API, so the IDs of entities, e.g. of the alerting profile referenced by a problem notification, are consistent.
+type ConfigClients struct {
+	ManagementZones  ManagementZonesClientInterface
+	AutoTags         AutoTagsClientInterface
+	AlertingProfiles AlertingProfilesClientInterface
+	Notifications    NotificationsClientInterface
+}
+
+// NewConfigClients creates ConfigClients using the Configuration API v1.
+func NewConfigClients(client ClientInterface) *ConfigClients {
+	return &ConfigClients{
+		ManagementZones:  NewManagementZonesClient(client),
+		AutoTags:         NewAutoTagClient(client),
+		AlertingProfiles: NewAlertingProfilesClient(client),
+		Notifications:    NewNotificationsClient(client),
+	}
+}
+
+// NewSettingsConfigClients creates ConfigClients using the Settings 2.0 API.
+func NewSettingsConfigClients(client ClientInterface) *ConfigClients {
+	return &ConfigClients{
+		ManagementZones:  NewManagementZonesSettingsClient(client),
+		AutoTags:         NewAutoTagsSettingsClient(client),
+		AlertingProfiles: NewAlertingProfilesSettingsClient(client),
+		Notifications:    NewNotificationsSettingsClient(client),
+	}
+}
+
+// NewConfigClientsForAPI creates ConfigClients using the specified API. For ConfigAPIAuto, the Settings 2.0 API is used if the management zones schema is available.
+func NewConfigClientsForAPI(ctx context.Context, client ClientInterface, api string) (*ConfigClients, error) {
+	switch api {
+	case "", ConfigAPIVersion1:
+		return NewConfigClients(client), nil
+	case ConfigAPISettings:
+		return NewSettingsConfigClients(client), nil
+	case ConfigAPIAuto:
+		if NewSettingsClient(client).IsSchemaAvailable(ctx, managementZonesSchemaID) {
+			log.Debug("Settings 2.0 API is available, using it for configuration entities")
+			return NewSettingsConfigClients(client), nil
+		}
+
+		log.Debug("Settings 2.0 API is not available, using Configuration API v1 for configuration entities")
+		return NewConfigClients(client), nil
+	default:
+		return nil, fmt.Errorf("unsupported configuration API: %s", api)
+	}
+}
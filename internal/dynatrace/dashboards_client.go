@@ -63,7 +63,13 @@ func (dc *DashboardsClient) Create(ctx context.Context, dashboard *Dashboard) er
 		return common.NewMarshalJSONError("Dynatrace dashboard", err)
 	}
 
-	_, err = dc.client.Post(ctx, DashboardsPath, dashboardPayload)
+	return dc.CreateFromJSON(ctx, dashboardPayload)
+}
+
+// CreateFromJSON creates a dashboard from its JSON representation or returns an error.
+// In contrast to Create, all properties of the dashboard and its tiles are retained, including those not modelled by Dashboard.
+func (dc *DashboardsClient) CreateFromJSON(ctx context.Context, dashboardPayload []byte) error {
+	_, err := dc.client.Post(ctx, DashboardsPath, dashboardPayload)
 	if err != nil {
 		return err
 	}
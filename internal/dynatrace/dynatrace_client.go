@@ -103,23 +103,79 @@ func NewClient(dynatraceCredentials *credentials.DynatraceCredentials) *Client {
 	return NewClientWithHTTP(
 		dynatraceCredentials,
 		&http.Client{
-			Transport: &http.Transport{
-				TLSClientConfig: &tls.Config{
-					InsecureSkipVerify: !env.IsHttpSSLVerificationEnabled()},
-				Proxy: http.ProxyFromEnvironment,
-			},
+			Transport: newRateLimitingRoundTripper(
+				dynatraceCredentials.GetTenant(),
+				rateLimiters.get(
+					dynatraceCredentials.GetTenant(),
+					getRequestsPerMinute(dynatraceCredentials.GetAPISettings()),
+					env.GetAPIRateLimitBurst()),
+				&http.Transport{
+					TLSClientConfig: createTLSConfig(dynatraceCredentials.GetAPISettings()),
+					Proxy:           http.ProxyFromEnvironment,
+				}),
 		},
 	)
 }
 
+// NewClientWithHTTP creates a new Client using the specified HTTP client.
+// If the credentials contain OAuth client credentials, a bearer token is used instead of the API token.
 func NewClientWithHTTP(dynatraceCredentials *credentials.DynatraceCredentials, httpClient *http.Client) *Client {
+	additionalHeader := rest.HTTPHeader{}
+	if oauthCredentials, isOAuth := dynatraceCredentials.GetOAuthCredentials(); isOAuth {
+		httpClient = newOAuthHTTPClient(httpClient, oauthTokenSources.get(oauthCredentials, httpClient))
+	} else {
+		additionalHeader = createAdditionalHeaders(dynatraceCredentials.GetAPIToken())
+	}
+
 	return &Client{
 		credentials: dynatraceCredentials,
-		restClient: rest.NewClient(
+		restClient: rest.NewClientWithRetryPolicy(
 			httpClient,
 			dynatraceCredentials.GetTenant(),
-			createAdditionalHeaders(dynatraceCredentials.GetAPIToken())),
+			additionalHeader,
+			createRetryPolicy(dynatraceCredentials.GetAPISettings())),
+	}
+}
+
+// createRetryPolicy creates a rest.RetryPolicy based on the defaults and any tenant-specific API settings.
+func createRetryPolicy(apiSettings credentials.DynatraceAPISettings) rest.RetryPolicy {
+	retryPolicy := rest.NewDefaultRetryPolicy()
+
+	if maxRetries, isSet := apiSettings.GetMaxRetries(); isSet {
+		retryPolicy.MaxRetries = maxRetries
 	}
+
+	if maxRetryBackoff, isSet := apiSettings.GetMaxRetryBackoff(); isSet {
+		retryPolicy.MaxBackoff = maxRetryBackoff
+	}
+
+	return retryPolicy
+}
+
+// createTLSConfig creates a tls.Config based on the defaults and any tenant-specific certificate authorities and client certificate.
+func createTLSConfig(apiSettings credentials.DynatraceAPISettings) *tls.Config {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: !env.IsHttpSSLVerificationEnabled(),
+	}
+
+	if rootCAs, isSet := apiSettings.GetRootCAs(); isSet {
+		tlsConfig.RootCAs = rootCAs
+	}
+
+	if clientCertificate, isSet := apiSettings.GetClientCertificate(); isSet {
+		tlsConfig.Certificates = []tls.Certificate{*clientCertificate}
+	}
+
+	return tlsConfig
+}
+
+// getRequestsPerMinute gets the maximum number of requests per minute based on the default and any tenant-specific API settings.
+func getRequestsPerMinute(apiSettings credentials.DynatraceAPISettings) int {
+	if requestsPerMinute, isSet := apiSettings.GetRequestsPerMinute(); isSet {
+		return requestsPerMinute
+	}
+
+	return env.GetAPIRequestsPerMinute()
 }
 
 // Get performs a get request.
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/keptn-contrib/dynatrace-service/internal/common"
@@ -83,6 +84,36 @@ func (ec *EntitiesClient) GetKeptnManagedServices(ctx context.Context) ([]Entity
 	return entities, nil
 }
 
+// GetEntitiesWithTagsByIDs returns the entities with the specified IDs including their tags.
+func (ec *EntitiesClient) GetEntitiesWithTagsByIDs(ctx context.Context, entityIDs []string) ([]Entity, error) {
+	if len(entityIDs) == 0 {
+		return nil, nil
+	}
+
+	quotedEntityIDs := make([]string, 0, len(entityIDs))
+	for _, entityID := range entityIDs {
+		quotedEntityIDs = append(quotedEntityIDs, "\""+entityID+"\"")
+	}
+
+	query := newQueryParameters()
+	query.add("entitySelector", "entityId("+strings.Join(quotedEntityIDs, ",")+")")
+	query.add("fields", "+tags")
+	query.add("pageSize", strconv.Itoa(len(entityIDs)))
+
+	response, err := ec.Client.Get(ctx, entitiesPath+"?"+query.encode())
+	if err != nil {
+		return nil, err
+	}
+
+	entitiesResponse := &EntitiesResponse{}
+	err = json.Unmarshal(response, entitiesResponse)
+	if err != nil {
+		return nil, common.NewUnmarshalJSONError("monitored entities", err)
+	}
+
+	return entitiesResponse.Entities, nil
+}
+
 type PGIQueryConfig struct {
 	Project string
 	Stage   string
@@ -186,3 +186,21 @@ func createEventsClient(t *testing.T, handler http.Handler) (*EntitiesClient, fu
 
 	return ec, teardown
 }
+
+func TestEntitiesClient_GetEntitiesWithTagsByIDs(t *testing.T) {
+	handler := test.NewFileBasedURLHandler(t)
+	handler.AddExact("/api/v2/entities?entitySelector=entityId%28%22SERVICE-XXXXXXXXXXXXX%22%2C%22HOST-XXXXXXXXXXXXX%22%29&fields=%2Btags&pageSize=2", "./testdata/entities_client/entities_with_tags.json")
+
+	client, teardown := createEventsClient(t, handler)
+	defer teardown()
+
+	entities, err := client.GetEntitiesWithTagsByIDs(context.Background(), []string{"SERVICE-XXXXXXXXXXXXX", "HOST-XXXXXXXXXXXXX"})
+	if assert.NoError(t, err) && assert.Len(t, entities, 2) {
+		assert.Equal(t, "SERVICE-XXXXXXXXXXXXX", entities[0].EntityID)
+		assert.Equal(t, []Tag{
+			{Context: "CONTEXTLESS", Key: "keptn_project", Value: "sockshop", StringRepresentation: "keptn_project:sockshop"},
+			{Context: "Kubernetes", Key: "app", Value: "carts", StringRepresentation: "[Kubernetes]app:carts"},
+		}, entities[0].Tags)
+		assert.Empty(t, entities[1].Tags)
+	}
+}
@@ -22,6 +22,15 @@ const DeploymentEventType = "CUSTOM_DEPLOYMENT"
 // InfoEventType is the type of a custom info event.
 const InfoEventType = "CUSTOM_INFO"
 
+// MarkedForTerminationEventType is the type of an event marking entities for termination.
+const MarkedForTerminationEventType = "MARKED_FOR_TERMINATION"
+
+// EventsAPIVersion1 selects the deprecated Events API v1.
+const EventsAPIVersion1 = "v1"
+
+// EventsAPIVersion2 selects the Events API v2.
+const EventsAPIVersion2 = "v2"
+
 // AnnotationEvent defines a Dynatrace custom annotation event.
 type AnnotationEvent struct {
 	EventType             string            `json:"eventType"`
@@ -66,6 +75,15 @@ type InfoEvent struct {
 	AttachRules      AttachRules       `json:"attachRules"`
 }
 
+// MarkedForTerminationEvent defines a Dynatrace event marking entities for termination.
+type MarkedForTerminationEvent struct {
+	EventType        string            `json:"eventType"`
+	Description      string            `json:"description"`
+	Source           string            `json:"source"`
+	CustomProperties map[string]string `json:"customProperties"`
+	AttachRules      AttachRules       `json:"attachRules"`
+}
+
 // TagEntry defines a Dynatrace configuration structure
 type TagEntry struct {
 	Context string `json:"context" yaml:"context"`
@@ -85,6 +103,38 @@ type AttachRules struct {
 	TagRule   []TagRule `json:"tagRule,omitempty" yaml:"tagRule,omitempty"`
 }
 
+// EventsClientInterface sends events to Dynatrace.
+type EventsClientInterface interface {
+	// AddAnnotationEvent sends an annotation event.
+	AddAnnotationEvent(ctx context.Context, ae AnnotationEvent) error
+
+	// AddConfigurationEvent sends a configuration event.
+	AddConfigurationEvent(ctx context.Context, ce ConfigurationEvent) error
+
+	// AddDeploymentEvent sends a deployment event.
+	AddDeploymentEvent(ctx context.Context, de DeploymentEvent) error
+
+	// AddInfoEvent sends an info event.
+	AddInfoEvent(ctx context.Context, ie InfoEvent) error
+
+	// AddMarkedForTerminationEvent sends an event marking entities for termination.
+	AddMarkedForTerminationEvent(ctx context.Context, me MarkedForTerminationEvent) error
+}
+
+// NewEventsClientForAPIVersion creates an EventsClientInterface using the specified version of the Dynatrace events API.
+// If no version is specified, the Events API v1 is used.
+func NewEventsClientForAPIVersion(client ClientInterface, version string) (EventsClientInterface, error) {
+	switch version {
+	case "", EventsAPIVersion1:
+		return NewEventsClient(client), nil
+	case EventsAPIVersion2:
+		return NewEventsV2Client(client), nil
+	default:
+		return nil, fmt.Errorf("unsupported events API version: %s", version)
+	}
+}
+
+// EventsClient is a client for sending events using the Events API v1.
 type EventsClient struct {
 	client ClientInterface
 }
@@ -139,6 +189,16 @@ func (ec *EventsClient) AddInfoEvent(ctx context.Context, ie InfoEvent) error {
 	return ec.addEventAndLog(ctx, ie)
 }
 
+// AddMarkedForTerminationEvent sends an event marking entities for termination to the Dynatrace events API.
+func (ec *EventsClient) AddMarkedForTerminationEvent(ctx context.Context, me MarkedForTerminationEvent) error {
+	log.WithFields(log.Fields{
+		"type":        me.EventType,
+		"description": me.Description,
+	}).Debug("Sending event to Dynatrace API")
+
+	return ec.addEventAndLog(ctx, me)
+}
+
 // addEventAndLog sends an event to the Dynatrace events API and logs errors if necessary.
 func (ec *EventsClient) addEventAndLog(ctx context.Context, dtEvent interface{}) error {
 	body, err := ec.addEvent(ctx, dtEvent)
@@ -0,0 +1,250 @@
+package dynatrace
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const eventsV2IngestPath = "/api/v2/events/ingest"
+
+const contextlessTagContext = "CONTEXTLESS"
+
+// well-known properties of events ingested via the Events API v2
+const (
+	descriptionPropertyKey                 = "dt.event.description"
+	deploymentNamePropertyKey              = "dt.event.deployment.name"
+	deploymentVersionPropertyKey           = "dt.event.deployment.version"
+	deploymentProjectPropertyKey           = "dt.event.deployment.project"
+	deploymentCIBackLinkPropertyKey        = "dt.event.deployment.ci_back_link"
+	deploymentRemediationActionPropertyKey = "dt.event.deployment.remediation_action_link"
+	sourcePropertyKey                      = "source"
+	configurationPropertyKey               = "configuration"
+	originalConfigurationPropertyKey       = "original"
+)
+
+// eventIngest defines an event sent to the Events API v2.
+type eventIngest struct {
+	EventType      string            `json:"eventType"`
+	Title          string            `json:"title"`
+	EntitySelector string            `json:"entitySelector,omitempty"`
+	Properties     map[string]string `json:"properties,omitempty"`
+}
+
+// EventsV2Client is a client for sending events using the Events API v2.
+// Events are attached to entities using entity selectors derived from their attach rules.
+type EventsV2Client struct {
+	client ClientInterface
+}
+
+// NewEventsV2Client creates a new EventsV2Client
+func NewEventsV2Client(client ClientInterface) *EventsV2Client {
+	return &EventsV2Client{
+		client: client,
+	}
+}
+
+// AddAnnotationEvent sends an annotation event to the Dynatrace Events API v2.
+func (ec *EventsV2Client) AddAnnotationEvent(ctx context.Context, ae AnnotationEvent) error {
+	properties := newEventProperties(ae.CustomProperties)
+	properties.addIfNonEmpty(sourcePropertyKey, ae.Source)
+	properties.addIfNonEmpty(descriptionPropertyKey, ae.AnnotationDescription)
+
+	return ec.ingestEvents(ctx, AnnotationEventType, ae.AnnotationType, properties, ae.AttachRules)
+}
+
+// AddConfigurationEvent sends a configuration event to the Dynatrace Events API v2.
+func (ec *EventsV2Client) AddConfigurationEvent(ctx context.Context, ce ConfigurationEvent) error {
+	properties := newEventProperties(ce.CustomProperties)
+	properties.addIfNonEmpty(sourcePropertyKey, ce.Source)
+	properties.addIfNonEmpty(descriptionPropertyKey, ce.Description)
+	properties.addIfNonEmpty(configurationPropertyKey, ce.Configuration)
+	properties.addIfNonEmpty(originalConfigurationPropertyKey, ce.Original)
+
+	return ec.ingestEvents(ctx, ConfigurationEventType, ce.Description, properties, ce.AttachRules)
+}
+
+// AddDeploymentEvent sends a deployment event to the Dynatrace Events API v2.
+func (ec *EventsV2Client) AddDeploymentEvent(ctx context.Context, de DeploymentEvent) error {
+	properties := newEventProperties(de.CustomProperties)
+	properties.addIfNonEmpty(sourcePropertyKey, de.Source)
+	properties.addIfNonEmpty(deploymentNamePropertyKey, de.DeploymentName)
+	properties.addIfNonEmpty(deploymentVersionPropertyKey, de.DeploymentVersion)
+	properties.addIfNonEmpty(deploymentProjectPropertyKey, de.DeploymentProject)
+	properties.addIfNonEmpty(deploymentCIBackLinkPropertyKey, de.CiBackLink)
+	properties.addIfNonEmpty(deploymentRemediationActionPropertyKey, de.RemediationAction)
+
+	return ec.ingestEvents(ctx, DeploymentEventType, de.DeploymentName, properties, de.AttachRules)
+}
+
+// AddInfoEvent sends an info event to the Dynatrace Events API v2.
+func (ec *EventsV2Client) AddInfoEvent(ctx context.Context, ie InfoEvent) error {
+	properties := newEventProperties(ie.CustomProperties)
+	properties.addIfNonEmpty(sourcePropertyKey, ie.Source)
+	properties.addIfNonEmpty(descriptionPropertyKey, ie.Description)
+
+	return ec.ingestEvents(ctx, InfoEventType, ie.Title, properties, ie.AttachRules)
+}
+
+// AddMarkedForTerminationEvent sends an event marking entities for termination to the Dynatrace Events API v2.
+func (ec *EventsV2Client) AddMarkedForTerminationEvent(ctx context.Context, me MarkedForTerminationEvent) error {
+	properties := newEventProperties(me.CustomProperties)
+	properties.addIfNonEmpty(sourcePropertyKey, me.Source)
+	properties.addIfNonEmpty(descriptionPropertyKey, me.Description)
+
+	return ec.ingestEvents(ctx, MarkedForTerminationEventType, me.Description, properties, me.AttachRules)
+}
+
+// ingestEvents sends an event for each entity selector derived from the attach rules, as a single entity selector cannot combine entity IDs and different entity types.
+// If the event could only be sent for some of the entity selectors, a PartialEventIngestError with the attach rules of the remaining entity selectors is returned.
+func (ec *EventsV2Client) ingestEvents(ctx context.Context, eventType string, title string, properties eventProperties, attachRules AttachRules) error {
+	splitRules := splitAttachRules(attachRules)
+	for i, rules := range splitRules {
+		entitySelector := getEntitySelector(rules)
+		log.WithFields(log.Fields{
+			"type":           eventType,
+			"title":          title,
+			"entitySelector": entitySelector,
+		}).Debug("Sending event to Dynatrace API")
+
+		payload, err := json.Marshal(eventIngest{
+			EventType:      eventType,
+			Title:          title,
+			EntitySelector: entitySelector,
+			Properties:     properties,
+		})
+		if err != nil {
+			return fmt.Errorf("could not marshal event payload: %v", err)
+		}
+
+		body, err := ec.client.Post(ctx, eventsV2IngestPath, payload)
+		if err != nil {
+			err = fmt.Errorf("could not create event: %w", err)
+			if i == 0 {
+				return err
+			}
+			return &PartialEventIngestError{
+				RemainingAttachRules: mergeAttachRules(splitRules[i:]),
+				cause:                err,
+			}
+		}
+
+		log.WithField("body", string(body)).Debug("Dynatrace API has accepted the event")
+	}
+	return nil
+}
+
+// PartialEventIngestError is returned if an event was only sent for some of the entity selectors derived from its attach rules.
+type PartialEventIngestError struct {
+	// RemainingAttachRules are the attach rules of the entity selectors for which the event has not been sent.
+	RemainingAttachRules AttachRules
+	cause                error
+}
+
+func (e *PartialEventIngestError) Error() string {
+	return fmt.Sprintf("event was only sent for some of its entity selectors: %v", e.cause)
+}
+
+// Unwrap returns the error that prevented sending the event for the remaining entity selectors.
+func (e *PartialEventIngestError) Unwrap() error {
+	return e.cause
+}
+
+type eventProperties map[string]string
+
+// newEventProperties creates eventProperties containing a copy of the custom properties.
+func newEventProperties(customProperties map[string]string) eventProperties {
+	properties := make(eventProperties, len(customProperties))
+	for key, value := range customProperties {
+		properties[key] = value
+	}
+	return properties
+}
+
+func (p eventProperties) addIfNonEmpty(key string, value string) {
+	if value == "" {
+		return
+	}
+	p[key] = value
+}
+
+// splitAttachRules splits attach rules into the attach rules of each entity selector, i.e. one for all entity IDs and one for each entity type of each tag rule.
+// If the attach rules are empty, a single empty attach rule is returned.
+func splitAttachRules(attachRules AttachRules) []AttachRules {
+	var splitRules []AttachRules
+	if len(attachRules.EntityIds) > 0 {
+		splitRules = append(splitRules, AttachRules{EntityIds: attachRules.EntityIds})
+	}
+
+	for _, tagRule := range attachRules.TagRule {
+		if len(tagRule.MeTypes) == 0 {
+			log.WithField("tagRule", tagRule).Warn("Ignoring tag rule without entity types, as it cannot be converted into an entity selector")
+			continue
+		}
+
+		for _, meType := range tagRule.MeTypes {
+			splitRules = append(splitRules, AttachRules{TagRule: []TagRule{{MeTypes: []string{meType}, Tags: tagRule.Tags}}})
+		}
+	}
+
+	if len(splitRules) == 0 {
+		return []AttachRules{{}}
+	}
+	return splitRules
+}
+
+// mergeAttachRules merges attach rules split by splitAttachRules.
+func mergeAttachRules(splitRules []AttachRules) AttachRules {
+	var attachRules AttachRules
+	for _, rules := range splitRules {
+		attachRules.EntityIds = append(attachRules.EntityIds, rules.EntityIds...)
+		attachRules.TagRule = append(attachRules.TagRule, rules.TagRule...)
+	}
+	return attachRules
+}
+
+// getEntitySelector converts attach rules containing either entity IDs or a tag rule with a single entity type into an entity selector.
+func getEntitySelector(attachRules AttachRules) string {
+	if len(attachRules.EntityIds) > 0 {
+		return "entityId(" + joinQuoted(attachRules.EntityIds) + ")"
+	}
+
+	if len(attachRules.TagRule) == 0 || len(attachRules.TagRule[0].MeTypes) == 0 {
+		return ""
+	}
+
+	tagRule := attachRules.TagRule[0]
+	criteria := []string{"type(" + quote(tagRule.MeTypes[0]) + ")"}
+	for _, tag := range tagRule.Tags {
+		criteria = append(criteria, "tag("+quote(formatTag(tag))+")")
+	}
+	return strings.Join(criteria, ",")
+}
+
+// formatTag formats the tag as [context]key:value, omitting the context if it is CONTEXTLESS and the value if it is empty.
+func formatTag(tag TagEntry) string {
+	formattedTag := tag.Key
+	if tag.Context != "" && tag.Context != contextlessTagContext {
+		formattedTag = "[" + tag.Context + "]" + formattedTag
+	}
+	if tag.Value != "" {
+		formattedTag = formattedTag + ":" + tag.Value
+	}
+	return formattedTag
+}
+
+func joinQuoted(values []string) string {
+	quotedValues := make([]string, 0, len(values))
+	for _, value := range values {
+		quotedValues = append(quotedValues, quote(value))
+	}
+	return strings.Join(quotedValues, ",")
+}
+
+// quote quotes the value for use in an entity selector, escaping the special characters ~ and ".
+func quote(value string) string {
+	return `"` + strings.NewReplacer(`~`, `~~`, `"`, `~"`).Replace(value) + `"`
+}
@@ -0,0 +1,174 @@
+package dynatrace
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestEventsV2Client_AddDeploymentEvent tests that a deployment event is sent to the Events API v2 with typed properties and an entity selector for the entity IDs and each entity type of the attach rules.
+func TestEventsV2Client_AddDeploymentEvent(t *testing.T) {
+	ingestedEvents, dtClient, teardown := createEventIngestRecordingClient(t)
+	defer teardown()
+
+	err := NewEventsV2Client(dtClient).AddDeploymentEvent(context.Background(), DeploymentEvent{
+		EventType:         DeploymentEventType,
+		Source:            "Keptn dynatrace-service",
+		DeploymentName:    "Deploy carts 0.1.0 with strategy direct",
+		DeploymentVersion: "0.1.0",
+		DeploymentProject: "sockshop",
+		CustomProperties:  map[string]string{"Stage": "dev"},
+		AttachRules: AttachRules{
+			EntityIds: []string{"PROCESS_GROUP_INSTANCE-1", "PROCESS_GROUP_INSTANCE-2"},
+			TagRule: []TagRule{
+				{
+					MeTypes: []string{"SERVICE", "PROCESS_GROUP"},
+					Tags: []TagEntry{
+						{Context: "CONTEXTLESS", Key: "keptn_project", Value: "sockshop"},
+						{Context: "ENVIRONMENT", Key: "owner"},
+					},
+				},
+			},
+		},
+	})
+	assert.NoError(t, err)
+
+	expectedProperties := map[string]string{
+		"Stage":                       "dev",
+		"source":                      "Keptn dynatrace-service",
+		"dt.event.deployment.name":    "Deploy carts 0.1.0 with strategy direct",
+		"dt.event.deployment.version": "0.1.0",
+		"dt.event.deployment.project": "sockshop",
+	}
+
+	assert.Equal(t, []eventIngest{
+		{
+			EventType:      DeploymentEventType,
+			Title:          "Deploy carts 0.1.0 with strategy direct",
+			EntitySelector: `entityId("PROCESS_GROUP_INSTANCE-1","PROCESS_GROUP_INSTANCE-2")`,
+			Properties:     expectedProperties,
+		},
+		{
+			EventType:      DeploymentEventType,
+			Title:          "Deploy carts 0.1.0 with strategy direct",
+			EntitySelector: `type("SERVICE"),tag("keptn_project:sockshop"),tag("[ENVIRONMENT]owner")`,
+			Properties:     expectedProperties,
+		},
+		{
+			EventType:      DeploymentEventType,
+			Title:          "Deploy carts 0.1.0 with strategy direct",
+			EntitySelector: `type("PROCESS_GROUP"),tag("keptn_project:sockshop"),tag("[ENVIRONMENT]owner")`,
+			Properties:     expectedProperties,
+		},
+	}, *ingestedEvents)
+}
+
+// TestEventsV2Client_AddMarkedForTerminationEvent tests that an event marking entities for termination is sent to the Events API v2 with its description as title.
+func TestEventsV2Client_AddMarkedForTerminationEvent(t *testing.T) {
+	ingestedEvents, dtClient, teardown := createEventIngestRecordingClient(t)
+	defer teardown()
+
+	err := NewEventsV2Client(dtClient).AddMarkedForTerminationEvent(context.Background(), MarkedForTerminationEvent{
+		EventType:   MarkedForTerminationEventType,
+		Source:      "Keptn dynatrace-service",
+		Description: "Keptn: carts in sockshop production is about to be replaced",
+		AttachRules: AttachRules{
+			EntityIds: []string{"PROCESS_GROUP_INSTANCE-1"},
+		},
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []eventIngest{
+		{
+			EventType:      MarkedForTerminationEventType,
+			Title:          "Keptn: carts in sockshop production is about to be replaced",
+			EntitySelector: `entityId("PROCESS_GROUP_INSTANCE-1")`,
+			Properties: map[string]string{
+				"source":               "Keptn dynatrace-service",
+				"dt.event.description": "Keptn: carts in sockshop production is about to be replaced",
+			},
+		},
+	}, *ingestedEvents)
+}
+
+func TestEventsV2Client_EntitySelectors(t *testing.T) {
+	tests := []struct {
+		name                    string
+		attachRules             AttachRules
+		expectedEntitySelectors []string
+	}{
+		{
+			name:                    "empty attach rules",
+			attachRules:             AttachRules{},
+			expectedEntitySelectors: []string{""},
+		},
+		{
+			name: "tag rule without entity types is ignored",
+			attachRules: AttachRules{
+				TagRule: []TagRule{{Tags: []TagEntry{{Context: "CONTEXTLESS", Key: "keptn_project", Value: "sockshop"}}}},
+			},
+			expectedEntitySelectors: []string{""},
+		},
+		{
+			name: "special characters are escaped",
+			attachRules: AttachRules{
+				TagRule: []TagRule{{MeTypes: []string{"SERVICE"}, Tags: []TagEntry{{Context: "CONTEXTLESS", Key: "note", Value: `say "hi" ~`}}}},
+			},
+			expectedEntitySelectors: []string{`type("SERVICE"),tag("note:say ~"hi~" ~~")`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ingestedEvents, dtClient, teardown := createEventIngestRecordingClient(t)
+			defer teardown()
+
+			err := NewEventsV2Client(dtClient).AddInfoEvent(context.Background(), InfoEvent{EventType: InfoEventType, Title: "my-title", AttachRules: tt.attachRules})
+			assert.NoError(t, err)
+
+			var entitySelectors []string
+			for _, ingestedEvent := range *ingestedEvents {
+				entitySelectors = append(entitySelectors, ingestedEvent.EntitySelector)
+			}
+			assert.Equal(t, tt.expectedEntitySelectors, entitySelectors)
+		})
+	}
+}
+
+func TestNewEventsClientForAPIVersion(t *testing.T) {
+	eventsClient, err := NewEventsClientForAPIVersion(nil, "")
+	assert.NoError(t, err)
+	assert.IsType(t, &EventsClient{}, eventsClient)
+
+	eventsClient, err = NewEventsClientForAPIVersion(nil, EventsAPIVersion2)
+	assert.NoError(t, err)
+	assert.IsType(t, &EventsV2Client{}, eventsClient)
+
+	_, err = NewEventsClientForAPIVersion(nil, "v3")
+	assert.EqualError(t, err, "unsupported events API version: v3")
+}
+
+// createEventIngestRecordingClient creates a client for a fake Events API v2 that accepts and records all ingested events.
+func createEventIngestRecordingClient(t *testing.T) (*[]eventIngest, ClientInterface, func()) {
+	var ingestedEvents []eventIngest
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, eventsV2IngestPath, r.URL.Path)
+
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+
+		var ingestedEvent eventIngest
+		assert.NoError(t, json.Unmarshal(body, &ingestedEvent))
+		ingestedEvents = append(ingestedEvents, ingestedEvent)
+
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"reportCount":1,"eventIngestResults":[{"correlationId":"abc","status":"OK"}]}`))
+	})
+
+	dtClient, _, teardown := createDynatraceClient(t, handler)
+	return &ingestedEvents, dtClient, teardown
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 )
 
 type ManagementZone struct {
@@ -52,6 +53,16 @@ func (mz *ManagementZones) Contains(name string) bool {
 	return exists
 }
 
+// GetNames returns the sorted names of all management zones.
+func (mz *ManagementZones) GetNames() []string {
+	names := make([]string, 0, len(mz.values))
+	for name := range mz.values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 type ManagementZonesClient struct {
 	client ClientInterface
 }
@@ -103,3 +114,44 @@ func (mzc *ManagementZonesClient) Create(ctx context.Context, managementZone *Ma
 
 	return nil
 }
+
+// GetByID gets the management zone with the specified ID.
+func (mzc *ManagementZonesClient) GetByID(ctx context.Context, id string) (*ManagementZone, error) {
+	response, err := mzc.client.Get(ctx, managementZonesPath+"/"+id)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve management zone: %v", err)
+	}
+
+	managementZone := &ManagementZone{}
+	err = json.Unmarshal(response, managementZone)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse management zone: %v", err)
+	}
+
+	return managementZone, nil
+}
+
+// Update updates the management zone with the specified ID.
+func (mzc *ManagementZonesClient) Update(ctx context.Context, id string, managementZone *ManagementZone) error {
+	mzPayload, err := json.Marshal(managementZone)
+	if err != nil {
+		return fmt.Errorf("failed to marshal management zone: %v", err)
+	}
+
+	_, err = mzc.client.Put(ctx, managementZonesPath+"/"+id, mzPayload)
+	if err != nil {
+		return fmt.Errorf("failed to update management zone: %v", err)
+	}
+
+	return nil
+}
+
+// Delete deletes the management zone with the specified ID.
+func (mzc *ManagementZonesClient) Delete(ctx context.Context, id string) error {
+	_, err := mzc.client.Delete(ctx, managementZonesPath+"/"+id)
+	if err != nil {
+		return fmt.Errorf("failed to delete management zone: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,166 @@
+package dynatrace
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+const managementZonesSchemaID = "builtin:management-zones"
+
+type managementZoneSettings struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Rules       []settingsRule `json:"rules"`
+}
+
+// ManagementZonesSettingsClient is a client for management zones using the Settings 2.0 API.
+type ManagementZonesSettingsClient struct {
+	client *SettingsClient
+}
+
+// NewManagementZonesSettingsClient creates a new ManagementZonesSettingsClient.
+func NewManagementZonesSettingsClient(client ClientInterface) *ManagementZonesSettingsClient {
+	return &ManagementZonesSettingsClient{
+		client: NewSettingsClient(client),
+	}
+}
+
+// GetAll gets all management zones. Their IDs are the IDs of the settings objects.
+func (mzc *ManagementZonesSettingsClient) GetAll(ctx context.Context) (*ManagementZones, error) {
+	objects, err := mzc.client.GetAll(ctx, managementZonesSchemaID)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve management zones: %v", err)
+	}
+
+	response := &listResponse{}
+	for _, object := range objects {
+		managementZone := &managementZoneSettings{}
+		err = json.Unmarshal(object.Value, managementZone)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse management zone: %v", err)
+		}
+		response.Values = append(response.Values, values{ID: object.ObjectID, Name: managementZone.Name})
+	}
+
+	return transformToManagementZones(response), nil
+}
+
+// Create creates a management zone.
+func (mzc *ManagementZonesSettingsClient) Create(ctx context.Context, managementZone *ManagementZone) error {
+	_, err := mzc.client.Create(ctx, managementZonesSchemaID, toManagementZoneSettings(managementZone))
+	if err != nil {
+		return fmt.Errorf("failed to create management zone: %v", err)
+	}
+
+	return nil
+}
+
+// GetByID gets the management zone with the specified settings object ID.
+func (mzc *ManagementZonesSettingsClient) GetByID(ctx context.Context, id string) (*ManagementZone, error) {
+	object, err := mzc.client.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve management zone: %v", err)
+	}
+
+	managementZone := &managementZoneSettings{}
+	err = json.Unmarshal(object.Value, managementZone)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse management zone: %v", err)
+	}
+
+	return fromManagementZoneSettings(managementZone), nil
+}
+
+// Update updates the management zone with the specified settings object ID.
+func (mzc *ManagementZonesSettingsClient) Update(ctx context.Context, id string, managementZone *ManagementZone) error {
+	err := mzc.client.Update(ctx, id, toManagementZoneSettings(managementZone))
+	if err != nil {
+		return fmt.Errorf("failed to update management zone: %v", err)
+	}
+
+	return nil
+}
+
+// Delete deletes the management zone with the specified settings object ID.
+func (mzc *ManagementZonesSettingsClient) Delete(ctx context.Context, id string) error {
+	err := mzc.client.Delete(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete management zone: %v", err)
+	}
+
+	return nil
+}
+
+// toManagementZoneSettings converts a management zone to its Settings 2.0 representation. Only tag conditions are supported.
+func toManagementZoneSettings(managementZone *ManagementZone) *managementZoneSettings {
+	settings := &managementZoneSettings{
+		Name:  managementZone.Name,
+		Rules: make([]settingsRule, 0, len(managementZone.Rules)),
+	}
+
+	for _, rule := range managementZone.Rules {
+		conditions := make([]settingsAttributeCondition, 0, len(rule.Conditions))
+		for _, condition := range rule.Conditions {
+			value := condition.ComparisonInfo.Value
+			operator := condition.ComparisonInfo.Operator
+			if value.Value == "" && operator == "EQUALS" {
+				operator = settingsTagKeyEqualsOperator
+			}
+
+			conditions = append(conditions, settingsAttributeCondition{
+				Key:      condition.Key.Attribute,
+				Operator: toSettingsOperator(operator, condition.ComparisonInfo.Negate),
+				Tag:      formatSettingsTag(value.Context, value.Key, value.Value),
+			})
+		}
+
+		settings.Rules = append(settings.Rules, settingsRule{
+			Enabled:       rule.Enabled,
+			Type:          settingsRuleTypeMonitoredEntity,
+			AttributeRule: newSettingsAttributeRule(rule.Type, rule.PropagationTypes, conditions),
+		})
+	}
+	return settings
+}
+
+// fromManagementZoneSettings converts the Settings 2.0 representation of a management zone. Rules other than attribute rules are ignored.
+func fromManagementZoneSettings(settings *managementZoneSettings) *ManagementZone {
+	managementZone := &ManagementZone{
+		Name:  settings.Name,
+		Rules: make([]MZRules, 0, len(settings.Rules)),
+	}
+
+	for _, rule := range settings.Rules {
+		if rule.AttributeRule == nil {
+			continue
+		}
+
+		conditions := make([]MZConditions, 0, len(rule.AttributeRule.Conditions))
+		for _, condition := range rule.AttributeRule.Conditions {
+			operator, negate := fromSettingsOperator(condition.Operator)
+			if operator == settingsTagKeyEqualsOperator {
+				operator = "EQUALS"
+			}
+
+			context, key, value := parseSettingsTag(condition.Tag)
+			conditions = append(conditions, MZConditions{
+				Key: MZKey{Attribute: condition.Key},
+				ComparisonInfo: MZComparisonInfo{
+					Type:     comparisonTypeTag,
+					Operator: operator,
+					Value:    MZValue{Context: context, Key: key, Value: value},
+					Negate:   negate,
+				},
+			})
+		}
+
+		managementZone.Rules = append(managementZone.Rules, MZRules{
+			Type:             rule.AttributeRule.EntityType,
+			Enabled:          rule.Enabled,
+			PropagationTypes: rule.AttributeRule.getPropagationTypes(),
+			Conditions:       conditions,
+		})
+	}
+	return managementZone
+}
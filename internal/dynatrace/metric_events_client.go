@@ -4,110 +4,120 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-
-	log "github.com/sirupsen/logrus"
 )
 
-const metricEventsPath = "/api/config/v1/anomalyDetection/metricEvents"
+const metricEventsSchemaID = "builtin:anomaly-detection.metric-events"
+
+const (
+	// MetricEventQueryTypeMetricSelector queries the values of a metric event using a metric selector.
+	MetricEventQueryTypeMetricSelector = "METRIC_SELECTOR"
+
+	// MetricEventModelTypeStaticThreshold raises an event if the values violate a static threshold.
+	MetricEventModelTypeStaticThreshold = "STATIC_THRESHOLD"
+
+	// MetricEventModelTypeAutoAdaptiveThreshold raises an event if the values violate a threshold derived from their baseline.
+	MetricEventModelTypeAutoAdaptiveThreshold = "AUTO_ADAPTIVE_THRESHOLD"
+
+	// MetricEventAlertConditionAbove raises an event if the values are above the threshold.
+	MetricEventAlertConditionAbove = "ABOVE"
 
+	// MetricEventAlertConditionBelow raises an event if the values are below the threshold.
+	MetricEventAlertConditionBelow = "BELOW"
+
+	// MetricEventTypeCustomAlert is the type of events opening a problem.
+	MetricEventTypeCustomAlert = "CUSTOM_ALERT"
+
+	// MetricEventTypeInfo is the type of events not opening a problem.
+	MetricEventTypeInfo = "INFO"
+)
+
+// MetricEvent is a metric event of the anomaly detection settings.
 type MetricEvent struct {
-	Metadata          MEMetadata        `json:"metadata"`
-	ID                string            `json:"id,omitempty"`
-	MetricID          string            `json:"metricId"`
-	Name              string            `json:"name"`
-	Description       string            `json:"description"`
-	AggregationType   string            `json:"aggregationType,omitempty"`
-	EventType         string            `json:"eventType"`
-	Severity          string            `json:"severity"`
-	AlertCondition    string            `json:"alertCondition"`
-	Samples           int               `json:"samples"`
-	ViolatingSamples  int               `json:"violatingSamples"`
-	DealertingSamples int               `json:"dealertingSamples"`
-	Threshold         float64           `json:"threshold"`
-	Enabled           bool              `json:"enabled"`
-	TagFilters        []METagFilter     `json:"tagFilters,omitempty"`
-	AlertingScope     []MEAlertingScope `json:"alertingScope"`
-	Unit              string            `json:"unit,omitempty"`
+	Enabled         bool                       `json:"enabled"`
+	Summary         string                     `json:"summary"`
+	QueryDefinition MetricEventQueryDefinition `json:"queryDefinition"`
+	ModelProperties MetricEventModelProperties `json:"modelProperties"`
+	EventTemplate   MetricEventTemplate        `json:"eventTemplate"`
 }
-type MEMetadata struct {
-	ConfigurationVersions []int  `json:"configurationVersions"`
-	ClusterVersion        string `json:"clusterVersion"`
+
+// MetricEventQueryDefinition defines the values monitored by a metric event.
+type MetricEventQueryDefinition struct {
+	Type           string `json:"type"`
+	MetricSelector string `json:"metricSelector"`
+	ManagementZone string `json:"managementZone,omitempty"`
 }
 
-type METagFilter struct {
-	Context string `json:"context"`
-	Key     string `json:"key"`
-	Value   string `json:"value"`
+// MetricEventModelProperties defines when a metric event raises an event. Threshold is only used by static thresholds and SignalFluctuation only by auto-adaptive thresholds.
+type MetricEventModelProperties struct {
+	Type              string   `json:"type"`
+	Threshold         *float64 `json:"threshold,omitempty"`
+	SignalFluctuation float64  `json:"signalFluctuation,omitempty"`
+	AlertOnNoData     bool     `json:"alertOnNoData"`
+	AlertCondition    string   `json:"alertCondition"`
+	Samples           int      `json:"samples"`
+	ViolatingSamples  int      `json:"violatingSamples"`
+	DealertingSamples int      `json:"dealertingSamples"`
 }
-type MEAlertingScope struct {
-	FilterType       string       `json:"filterType"`
-	TagFilter        *METagFilter `json:"tagFilter"`
-	ManagementZoneID int64        `json:"managementZoneId,omitempty"`
+
+// MetricEventTemplate defines the event raised by a metric event.
+type MetricEventTemplate struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	EventType   string `json:"eventType"`
+	DavisMerge  bool   `json:"davisMerge"`
 }
 
+// MetricEventsClient is a client for metric events using the Settings 2.0 API.
 type MetricEventsClient struct {
-	client ClientInterface
+	client *SettingsClient
 }
 
+// NewMetricEventsClient creates a new MetricEventsClient.
 func NewMetricEventsClient(client ClientInterface) *MetricEventsClient {
 	return &MetricEventsClient{
-		client: client,
+		client: NewSettingsClient(client),
 	}
 }
 
-func (mec *MetricEventsClient) getAll(ctx context.Context) (*listResponse, error) {
-	res, err := mec.client.Get(ctx, metricEventsPath)
+// GetIDsByName returns the settings object IDs of all metric events mapped by their names, i.e. their summaries.
+func (mec *MetricEventsClient) GetIDsByName(ctx context.Context) (map[string]string, error) {
+	objects, err := mec.client.GetAll(ctx, metricEventsSchemaID)
 	if err != nil {
-		return nil, fmt.Errorf("could not retrieve list of existing Dynatrace metric events: %v", err)
+		return nil, fmt.Errorf("could not retrieve metric events: %v", err)
 	}
 
-	response := &listResponse{}
-	err = json.Unmarshal(res, response)
-	if err != nil {
-		return nil, fmt.Errorf("could not parse list of existing Dynatrace metric events: %v", err)
-	}
+	ids := make(map[string]string, len(objects))
+	for _, object := range objects {
+		metricEvent := &MetricEvent{}
+		err = json.Unmarshal(object.Value, metricEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal metric event: %v", err)
+		}
 
-	return response, nil
+		ids[metricEvent.Summary] = object.ObjectID
+	}
+	return ids, nil
 }
 
-func (mec *MetricEventsClient) getByID(ctx context.Context, metricEventID string) (*MetricEvent, error) {
-	res, err := mec.client.Get(ctx, metricEventsPath+"/"+metricEventID)
+// GetByID gets the metric event with the specified settings object ID.
+func (mec *MetricEventsClient) GetByID(ctx context.Context, id string) (*MetricEvent, error) {
+	object, err := mec.client.GetByID(ctx, id)
 	if err != nil {
-		return nil, fmt.Errorf("could not retrieve metric event with ID: %s, %v", metricEventID, err)
+		return nil, fmt.Errorf("could not retrieve metric event: %v", err)
 	}
 
-	retrievedMetricEvent := &MetricEvent{}
-	err = json.Unmarshal(res, retrievedMetricEvent)
+	metricEvent := &MetricEvent{}
+	err = json.Unmarshal(object.Value, metricEvent)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to unmarshal metric event: %v", err)
 	}
 
-	return retrievedMetricEvent, nil
+	return metricEvent, nil
 }
 
 // Create creates a metric event.
 func (mec *MetricEventsClient) Create(ctx context.Context, metricEvent *MetricEvent) error {
-	mePayload, err := json.Marshal(metricEvent)
-	if err != nil {
-		return fmt.Errorf("could not marshal metric event: %v", err)
-	}
-
-	_, err = mec.client.Post(ctx, metricEventsPath, mePayload)
-	if err != nil {
-		return fmt.Errorf("could not create metric event: %v", err)
-	}
-
-	return nil
-}
-
-// Update updates a metric event.
-func (mec *MetricEventsClient) Update(ctx context.Context, metricEvent *MetricEvent) error {
-	mePayload, err := json.Marshal(metricEvent)
-	if err != nil {
-		return fmt.Errorf("could not marshal metric event: %v", err)
-	}
-
-	_, err = mec.client.Put(ctx, metricEventsPath, mePayload)
+	_, err := mec.client.Create(ctx, metricEventsSchemaID, metricEvent)
 	if err != nil {
 		return fmt.Errorf("could not create metric event: %v", err)
 	}
@@ -115,53 +125,22 @@ func (mec *MetricEventsClient) Update(ctx context.Context, metricEvent *MetricEv
 	return nil
 }
 
-func (mec *MetricEventsClient) deleteByID(ctx context.Context, metricEventID string) error {
-	_, err := mec.client.Delete(ctx, metricEventsPath+"/"+metricEventID)
+// Update updates the metric event with the specified settings object ID.
+func (mec *MetricEventsClient) Update(ctx context.Context, id string, metricEvent *MetricEvent) error {
+	err := mec.client.Update(ctx, id, metricEvent)
 	if err != nil {
-		return fmt.Errorf("could not delete metric event with ID: %s, %v", metricEventID, err)
+		return fmt.Errorf("could not update metric event: %v", err)
 	}
 
 	return nil
 }
 
-// GetMetricEventByName retrieves the MetricEvent identified by metricEventName, or nil if not found.
-func (mec *MetricEventsClient) GetMetricEventByName(ctx context.Context, metricEventName string) (*MetricEvent, error) {
-	res, err := mec.getAll(ctx)
+// DeleteByID deletes the metric event with the specified settings object ID.
+func (mec *MetricEventsClient) DeleteByID(ctx context.Context, id string) error {
+	err := mec.client.Delete(ctx, id)
 	if err != nil {
-		log.WithError(err).Error("Could not get existing Dynatrace metric events")
-		return nil, err
+		return fmt.Errorf("could not delete metric event with ID: %s, %v", id, err)
 	}
 
-	for _, metricEvent := range res.Values {
-		if metricEvent.Name == metricEventName {
-			res, err := mec.getByID(ctx, metricEvent.ID)
-			if err != nil {
-				log.WithError(err).WithField("eventKey", metricEventName).Error("Could not get existing metric event")
-				return nil, err
-			}
-
-			return res, nil
-		}
-	}
-	return nil, nil
-}
-
-// DeleteMetricEventByName deletes a metric event with the given name.
-func (mec *MetricEventsClient) DeleteMetricEventByName(ctx context.Context, metricEventName string) error {
-	res, err := mec.getAll(ctx)
-	if err != nil {
-		log.WithError(err).Error("Could not get existing Dynatrace metric events")
-		return err
-	}
-
-	for _, metricEvent := range res.Values {
-		if metricEvent.Name == metricEventName {
-			err := mec.deleteByID(ctx, metricEvent.ID)
-			if err != nil {
-				log.WithError(err).WithField("eventKey", metricEventName).Error("Could not delete existing metric event")
-				return err
-			}
-		}
-	}
 	return nil
 }
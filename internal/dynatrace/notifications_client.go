@@ -4,45 +4,64 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/keptn-contrib/dynatrace-service/internal/credentials"
 )
 
-const keptnProblemNotificationName = "Keptn Problem Notification"
-
-const problemNotificationPayload string = `{ 
-      "type": "WEBHOOK", 
-      "name": "$KEPTN_PROBLEM_NOTIFICATION_NAME", 
-      "alertingProfile": "$ALERTING_PROFILE_ID", 
-      "active": true, 
-      "url": "$KEPTN_DNS/v1/event", 
-      "acceptAnyCertificate": true, 
-      "headers": [ 
-        { "name": "x-token", "value": "$KEPTN_TOKEN" },
-        { "name": "Content-Type", "value": "application/cloudevents+json" }
-      ],
-      "payload": "{\n    \"specversion\":\"1.0\",\n    \"type\":\"sh.keptn.events.problem\",\n    \"shkeptncontext\":\"{PID}\",\n    \"source\":\"dynatrace\",\n    \"id\":\"{PID}\",\n    \"time\":\"\",\n    \"contenttype\":\"application/json\",\n    \"data\": {\n        \"State\":\"{State}\",\n        \"ProblemID\":\"{ProblemID}\",\n        \"PID\":\"{PID}\",\n        \"ProblemTitle\":\"{ProblemTitle}\",\n        \"ProblemURL\":\"{ProblemURL}\",\n        \"ProblemDetails\":{ProblemDetailsJSON},\n        \"Tags\":\"{Tags}\",\n        \"ImpactedEntities\":{ImpactedEntities},\n        \"ImpactedEntity\":\"{ImpactedEntity}\",\n        \"KeptnProject\":\"$KEPTN_PROJECT\"\n    }\n}\n" 
-
-      }`
-
-type NotificationsError struct {
-	errors []error
+// KeptnProblemNotificationName is the name of the problem notification sending problems to Keptn
+const KeptnProblemNotificationName = "Keptn Problem Notification"
+
+// KeptnProblemNotificationProjectPlaceholder is replaced by the Keptn project in the payload of a problem notification
+const KeptnProblemNotificationProjectPlaceholder = "$KEPTN_PROJECT"
+
+// defaultProblemNotificationPayload is the payload of the Keptn problem notification if no custom payload is configured
+const defaultProblemNotificationPayload = `{
+    "specversion":"1.0",
+    "type":"sh.keptn.events.problem",
+    "shkeptncontext":"{PID}",
+    "source":"dynatrace",
+    "id":"{PID}",
+    "time":"",
+    "contenttype":"application/json",
+    "data": {
+        "State":"{State}",
+        "ProblemID":"{ProblemID}",
+        "PID":"{PID}",
+        "ProblemTitle":"{ProblemTitle}",
+        "ProblemURL":"{ProblemURL}",
+        "ProblemDetails":{ProblemDetailsJSON},
+        "Tags":"{Tags}",
+        "ImpactedEntities":{ImpactedEntities},
+        "ImpactedEntity":"{ImpactedEntity}",
+        "KeptnProject":"$KEPTN_PROJECT"
+    }
 }
-
-func (ne *NotificationsError) HasErrors() bool {
-	return len(ne.errors) > 0
+`
+
+// notificationPlaceholderRegex matches the placeholders in the payload of a problem notification, e.g. {ProblemDetailsJSON}
+var notificationPlaceholderRegex = regexp.MustCompile(`\{[A-Za-z0-9]+\}`)
+
+// keptnTokenHeaderName is the name of the header containing the Keptn API token
+const keptnTokenHeaderName = "x-token"
+
+// Notification is the subset of the properties of a problem notification that is managed by the dynatrace-service.
+type Notification struct {
+	Type                 string               `json:"type"`
+	Name                 string               `json:"name"`
+	AlertingProfile      string               `json:"alertingProfile"`
+	Active               bool                 `json:"active"`
+	URL                  string               `json:"url"`
+	AcceptAnyCertificate bool                 `json:"acceptAnyCertificate"`
+	Headers              []NotificationHeader `json:"headers,omitempty"`
+	Payload              string               `json:"payload"`
 }
 
-func (ne *NotificationsError) Error() string {
-	sb := strings.Builder{}
-	for i, err := range ne.errors {
-		sb.WriteString(err.Error())
-		if i < len(ne.errors)-1 {
-			sb.WriteString(", ")
-		}
-	}
-	return sb.String()
+// NotificationHeader is an HTTP header sent by a webhook problem notification.
+type NotificationHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
 }
 
 const notificationsPath = "/api/config/v1/notifications"
@@ -72,54 +91,139 @@ func (nc *NotificationsClient) getAll(ctx context.Context) (*listResponse, error
 	return existingNotifications, nil
 }
 
-// DeleteExistingKeptnProblemNotifications deletes all existing Keptn problem notifications.
-func (nc *NotificationsClient) DeleteExistingKeptnProblemNotifications(ctx context.Context) error {
+// GetKeptnProblemNotificationIDs returns the IDs of all existing Keptn problem notifications, i.e. of all notifications whose names start with KeptnProblemNotificationName.
+func (nc *NotificationsClient) GetKeptnProblemNotificationIDs(ctx context.Context) ([]string, error) {
 	existingNotifications, err := nc.getAll(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to retrieve notifications: %v", err)
+		return nil, fmt.Errorf("failed to retrieve notifications: %v", err)
 	}
 
-	notificationError := &NotificationsError{}
+	var ids []string
 	for _, notification := range existingNotifications.Values {
-		if notification.Name == keptnProblemNotificationName {
-			err := nc.deleteBy(ctx, notification.ID)
-			if err != nil {
-				// Error occurred but continue
-				notificationError.errors = append(
-					notificationError.errors,
-					fmt.Errorf("failed to delete notification with ID: %s", notification.ID))
-			}
+		if IsKeptnProblemNotificationName(notification.Name) {
+			ids = append(ids, notification.ID)
 		}
 	}
+	return ids, nil
+}
 
-	if notificationError.HasErrors() {
-		return notificationError
+// GetIDsByName returns the IDs of all existing problem notifications with the specified name.
+func (nc *NotificationsClient) GetIDsByName(ctx context.Context, name string) ([]string, error) {
+	existingNotifications, err := nc.getAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve notifications: %v", err)
+	}
+
+	var ids []string
+	for _, notification := range existingNotifications.Values {
+		if notification.Name == name {
+			ids = append(ids, notification.ID)
+		}
+	}
+	return ids, nil
+}
+
+// Create creates a problem notification.
+func (nc *NotificationsClient) Create(ctx context.Context, notification *Notification) error {
+	notificationPayload, err := json.Marshal(notification)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification: %v", err)
+	}
+
+	_, err = nc.client.Post(ctx, notificationsPath, notificationPayload)
+	if err != nil {
+		return fmt.Errorf("could not create notification: %v", err)
 	}
 
 	return nil
 }
 
-// Create creates a new default notification for the given KeptnAPICredentials and the alertingProfileID.
-func (nc *NotificationsClient) Create(ctx context.Context, credentials *credentials.KeptnCredentials, alertingProfileID string, project string) error {
-	notification := problemNotificationPayload
-	notification = strings.ReplaceAll(notification, "$KEPTN_DNS", credentials.GetAPIURL())
-	notification = strings.ReplaceAll(notification, "$KEPTN_TOKEN", credentials.GetAPIToken())
-	notification = strings.ReplaceAll(notification, "$ALERTING_PROFILE_ID", alertingProfileID)
-	notification = strings.ReplaceAll(notification, "$KEPTN_PROBLEM_NOTIFICATION_NAME", keptnProblemNotificationName)
-	notification = strings.ReplaceAll(notification, "$KEPTN_PROJECT", project)
+// Update updates the problem notification with the specified ID.
+func (nc *NotificationsClient) Update(ctx context.Context, id string, notification *Notification) error {
+	notificationPayload, err := json.Marshal(notification)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification: %v", err)
+	}
 
-	_, err := nc.client.Post(ctx, notificationsPath, []byte(notification))
+	_, err = nc.client.Put(ctx, notificationsPath+"/"+id, notificationPayload)
 	if err != nil {
-		return err
+		return fmt.Errorf("could not update notification: %v", err)
 	}
 
 	return nil
 }
 
-func (nc *NotificationsClient) deleteBy(ctx context.Context, id string) error {
+// GetByID gets the problem notification with the specified ID.
+func (nc *NotificationsClient) GetByID(ctx context.Context, id string) (*Notification, error) {
+	response, err := nc.client.Get(ctx, notificationsPath+"/"+id)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve notification: %v", err)
+	}
+
+	notification := &Notification{}
+	err = json.Unmarshal(response, notification)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal notification: %v", err)
+	}
+
+	return notification, nil
+}
+
+// NewKeptnProblemNotification returns the webhook problem notification forwarding the problems selected by the alerting profile to the Keptn project.
+// If payload is empty, the default payload is used. The KeptnProblemNotificationProjectPlaceholder in the payload is replaced by the project.
+func NewKeptnProblemNotification(name string, credentials *credentials.KeptnCredentials, alertingProfileID string, project string, payload string) *Notification {
+	if payload == "" {
+		payload = defaultProblemNotificationPayload
+	}
+
+	return &Notification{
+		Type:                 "WEBHOOK",
+		Name:                 name,
+		AlertingProfile:      alertingProfileID,
+		Active:               true,
+		URL:                  credentials.GetAPIURL() + "/v1/event",
+		AcceptAnyCertificate: true,
+		Headers: []NotificationHeader{
+			{Name: keptnTokenHeaderName, Value: credentials.GetAPIToken()},
+			{Name: "Content-Type", Value: "application/cloudevents+json"},
+		},
+		Payload: strings.ReplaceAll(payload, KeptnProblemNotificationProjectPlaceholder, project),
+	}
+}
+
+// GetKeptnProject returns the Keptn project set in the data of the payload of the problem notification or an empty string if there is none.
+// As unquoted placeholders such as {ProblemDetailsJSON} are not valid JSON, all placeholders are replaced by null before the payload is parsed.
+func (n *Notification) GetKeptnProject() (string, error) {
+	var event struct {
+		Data struct {
+			KeptnProject string `json:"KeptnProject"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal([]byte(notificationPlaceholderRegex.ReplaceAllString(n.Payload, "null")), &event); err != nil {
+		return "", fmt.Errorf("could not parse payload of problem notification: %w", err)
+	}
+	return event.Data.KeptnProject, nil
+}
+
+// GetKeptnProblemNotificationName returns the name of the Keptn problem notification with the specified suffix.
+func GetKeptnProblemNotificationName(suffix string) string {
+	if suffix == "" {
+		return KeptnProblemNotificationName
+	}
+	return KeptnProblemNotificationName + " " + suffix
+}
+
+// IsKeptnProblemNotificationName returns whether the name is the name of a Keptn problem notification.
+func IsKeptnProblemNotificationName(name string) bool {
+	return name == KeptnProblemNotificationName || strings.HasPrefix(name, KeptnProblemNotificationName+" ")
+}
+
+// DeleteByID deletes the problem notification with the specified ID.
+func (nc *NotificationsClient) DeleteByID(ctx context.Context, id string) error {
 	_, err := nc.client.Delete(ctx, notificationsPath+"/"+id)
 	if err != nil {
-		return nil
+		return fmt.Errorf("could not delete notification: %v", err)
 	}
 
 	return nil
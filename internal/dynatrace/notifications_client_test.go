@@ -0,0 +1,62 @@
+package dynatrace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/credentials"
+)
+
+func TestNewKeptnProblemNotification(t *testing.T) {
+	keptnCredentials, err := credentials.NewKeptnCredentials("https://keptn.example.com/api", "token", "")
+	require.NoError(t, err)
+
+	notification := NewKeptnProblemNotification(KeptnProblemNotificationName, keptnCredentials, "profile-id", "sockshop", "")
+
+	assert.Equal(t, "WEBHOOK", notification.Type)
+	assert.Equal(t, KeptnProblemNotificationName, notification.Name)
+	assert.Equal(t, "profile-id", notification.AlertingProfile)
+	assert.True(t, notification.Active)
+	assert.Equal(t, "https://keptn.example.com/api/v1/event", notification.URL)
+	assert.Contains(t, notification.Headers, NotificationHeader{Name: "x-token", Value: "token"})
+	assert.Contains(t, notification.Payload, `"KeptnProject":"sockshop"`)
+	assert.Contains(t, notification.Payload, `"ProblemDetails":{ProblemDetailsJSON}`)
+}
+
+func TestNewKeptnProblemNotification_CustomPayload(t *testing.T) {
+	keptnCredentials, err := credentials.NewKeptnCredentials("https://keptn.example.com/api", "token", "")
+	require.NoError(t, err)
+
+	notification := NewKeptnProblemNotification(KeptnProblemNotificationName, keptnCredentials, "profile-id", "sockshop", `{"data": {"ProblemID":"{ProblemID}", "KeptnProject":"$KEPTN_PROJECT", "Stage":"production"}}`)
+
+	assert.Equal(t, `{"data": {"ProblemID":"{ProblemID}", "KeptnProject":"sockshop", "Stage":"production"}}`, notification.Payload)
+}
+
+func TestNotification_GetKeptnProject(t *testing.T) {
+	keptnCredentials, err := credentials.NewKeptnCredentials("https://keptn.example.com/api", "token", "")
+	require.NoError(t, err)
+
+	project, err := NewKeptnProblemNotification(KeptnProblemNotificationName, keptnCredentials, "profile-id", "sockshop", "").GetKeptnProject()
+	assert.NoError(t, err)
+	assert.Equal(t, "sockshop", project)
+
+	project, err = (&Notification{Payload: `{"data": {"ProblemDetails": {ProblemDetailsJSON}, "KeptnProject": "sockshop-legacy"}}`}).GetKeptnProject()
+	assert.NoError(t, err)
+	assert.Equal(t, "sockshop-legacy", project)
+
+	project, err = (&Notification{Payload: `{"specversion":"1.0","data":{ProblemDetailsJSONv2},"comment":"\"KeptnProject\":\"sockshop\""}`}).GetKeptnProject()
+	assert.NoError(t, err)
+	assert.Empty(t, project)
+
+	_, err = (&Notification{Payload: "not json"}).GetKeptnProject()
+	assert.Error(t, err)
+}
+
+func TestIsKeptnProblemNotificationName(t *testing.T) {
+	assert.True(t, IsKeptnProblemNotificationName("Keptn Problem Notification"))
+	assert.True(t, IsKeptnProblemNotificationName(GetKeptnProblemNotificationName("production")))
+	assert.False(t, IsKeptnProblemNotificationName("Keptn Problem Notifications"))
+	assert.False(t, IsKeptnProblemNotificationName("Other Notification"))
+}
@@ -0,0 +1,168 @@
+package dynatrace
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+const notificationsSchemaID = "builtin:problem.notifications"
+
+type notificationSettings struct {
+	Enabled             bool                         `json:"enabled"`
+	DisplayName         string                       `json:"displayName"`
+	Type                string                       `json:"type"`
+	AlertingProfile     string                       `json:"alertingProfile"`
+	WebHookNotification *webHookNotificationSettings `json:"webHookNotification,omitempty"`
+}
+
+type webHookNotificationSettings struct {
+	URL                      string                       `json:"url"`
+	AcceptAnyCertificate     bool                         `json:"acceptAnyCertificate"`
+	NotifyEventMergesEnabled bool                         `json:"notifyEventMergesEnabled"`
+	NotifyClosedProblems     bool                         `json:"notifyClosedProblems"`
+	Headers                  []notificationHeaderSettings `json:"headers"`
+	Payload                  string                       `json:"payload"`
+	UseOAuth2                bool                         `json:"useOAuth2"`
+}
+
+type notificationHeaderSettings struct {
+	Name   string `json:"name"`
+	Secret bool   `json:"secret"`
+	Value  string `json:"value,omitempty"`
+}
+
+// NotificationsSettingsClient is a client for problem notifications using the Settings 2.0 API.
+type NotificationsSettingsClient struct {
+	client *SettingsClient
+}
+
+// NewNotificationsSettingsClient creates a new NotificationsSettingsClient.
+func NewNotificationsSettingsClient(client ClientInterface) *NotificationsSettingsClient {
+	return &NotificationsSettingsClient{
+		client: NewSettingsClient(client),
+	}
+}
+
+// GetKeptnProblemNotificationIDs returns the settings object IDs of all existing Keptn problem notifications, i.e. of all notifications whose names start with KeptnProblemNotificationName.
+func (nc *NotificationsSettingsClient) GetKeptnProblemNotificationIDs(ctx context.Context) ([]string, error) {
+	return nc.getIDs(ctx, IsKeptnProblemNotificationName)
+}
+
+// GetIDsByName returns the settings object IDs of all existing problem notifications with the specified name.
+func (nc *NotificationsSettingsClient) GetIDsByName(ctx context.Context, name string) ([]string, error) {
+	return nc.getIDs(ctx, func(n string) bool { return n == name })
+}
+
+func (nc *NotificationsSettingsClient) getIDs(ctx context.Context, matchesName func(name string) bool) ([]string, error) {
+	objects, err := nc.client.GetAll(ctx, notificationsSchemaID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve notifications: %v", err)
+	}
+
+	var ids []string
+	for _, object := range objects {
+		notification := &notificationSettings{}
+		err = json.Unmarshal(object.Value, notification)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal notification: %v", err)
+		}
+
+		if matchesName(notification.DisplayName) {
+			ids = append(ids, object.ObjectID)
+		}
+	}
+	return ids, nil
+}
+
+// Create creates a problem notification.
+func (nc *NotificationsSettingsClient) Create(ctx context.Context, notification *Notification) error {
+	_, err := nc.client.Create(ctx, notificationsSchemaID, toNotificationSettings(notification))
+	if err != nil {
+		return fmt.Errorf("could not create notification: %v", err)
+	}
+
+	return nil
+}
+
+// Update updates the problem notification with the specified settings object ID.
+func (nc *NotificationsSettingsClient) Update(ctx context.Context, id string, notification *Notification) error {
+	err := nc.client.Update(ctx, id, toNotificationSettings(notification))
+	if err != nil {
+		return fmt.Errorf("could not update notification: %v", err)
+	}
+
+	return nil
+}
+
+// GetByID gets the problem notification with the specified settings object ID.
+func (nc *NotificationsSettingsClient) GetByID(ctx context.Context, id string) (*Notification, error) {
+	object, err := nc.client.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve notification: %v", err)
+	}
+
+	notification := &notificationSettings{}
+	err = json.Unmarshal(object.Value, notification)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal notification: %v", err)
+	}
+
+	return fromNotificationSettings(notification), nil
+}
+
+// DeleteByID deletes the problem notification with the specified settings object ID.
+func (nc *NotificationsSettingsClient) DeleteByID(ctx context.Context, id string) error {
+	err := nc.client.Delete(ctx, id)
+	if err != nil {
+		return fmt.Errorf("could not delete notification: %v", err)
+	}
+
+	return nil
+}
+
+// toNotificationSettings converts a webhook problem notification to its Settings 2.0 representation. The Keptn API token header is stored as a secret.
+func toNotificationSettings(notification *Notification) *notificationSettings {
+	headers := make([]notificationHeaderSettings, 0, len(notification.Headers))
+	for _, header := range notification.Headers {
+		headers = append(headers, notificationHeaderSettings{
+			Name:   header.Name,
+			Secret: header.Name == keptnTokenHeaderName,
+			Value:  header.Value,
+		})
+	}
+
+	return &notificationSettings{
+		Enabled:         notification.Active,
+		DisplayName:     notification.Name,
+		Type:            notification.Type,
+		AlertingProfile: notification.AlertingProfile,
+		WebHookNotification: &webHookNotificationSettings{
+			URL:                  notification.URL,
+			AcceptAnyCertificate: notification.AcceptAnyCertificate,
+			NotifyClosedProblems: true,
+			Headers:              headers,
+			Payload:              notification.Payload,
+		},
+	}
+}
+
+// fromNotificationSettings converts the Settings 2.0 representation of a problem notification. Only webhook notifications are fully supported.
+func fromNotificationSettings(settings *notificationSettings) *Notification {
+	notification := &Notification{
+		Type:            settings.Type,
+		Name:            settings.DisplayName,
+		AlertingProfile: settings.AlertingProfile,
+		Active:          settings.Enabled,
+	}
+
+	if settings.WebHookNotification != nil {
+		notification.URL = settings.WebHookNotification.URL
+		notification.AcceptAnyCertificate = settings.WebHookNotification.AcceptAnyCertificate
+		notification.Payload = settings.WebHookNotification.Payload
+		for _, header := range settings.WebHookNotification.Headers {
+			notification.Headers = append(notification.Headers, NotificationHeader{Name: header.Name, Value: header.Value})
+		}
+	}
+	return notification
+}
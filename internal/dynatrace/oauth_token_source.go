@@ -0,0 +1,77 @@
+package dynatrace
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/credentials"
+)
+
+// oauthTokenSources holds the OAuth token sources shared by all clients of the process, so that bearer tokens are reused across events.
+var oauthTokenSources = newOAuthTokenSourceRegistry()
+
+type oauthTokenSourceKey struct {
+	ssoURL       string
+	clientID     string
+	clientSecret string
+}
+
+// oauthTokenSourceRegistry provides a caching token source per OAuth client.
+type oauthTokenSourceRegistry struct {
+	mutex        sync.Mutex
+	tokenSources map[oauthTokenSourceKey]oauth2.TokenSource
+}
+
+func newOAuthTokenSourceRegistry() *oauthTokenSourceRegistry {
+	return &oauthTokenSourceRegistry{tokenSources: make(map[oauthTokenSourceKey]oauth2.TokenSource)}
+}
+
+// get returns the token source for the specified OAuth client, creating it if required.
+// Tokens are obtained using the client credentials flow, cached and refreshed shortly before they expire.
+// The specified HTTP client is only used if a new token source is created.
+func (r *oauthTokenSourceRegistry) get(oauthCredentials *credentials.DynatraceOAuthCredentials, httpClient *http.Client) oauth2.TokenSource {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	key := oauthTokenSourceKey{
+		ssoURL:       oauthCredentials.GetSSOURL(),
+		clientID:     oauthCredentials.GetClientID(),
+		clientSecret: oauthCredentials.GetClientSecret(),
+	}
+
+	tokenSource, ok := r.tokenSources[key]
+	if ok {
+		return tokenSource
+	}
+
+	config := clientcredentials.Config{
+		ClientID:     oauthCredentials.GetClientID(),
+		ClientSecret: oauthCredentials.GetClientSecret(),
+		TokenURL:     oauthCredentials.GetSSOURL(),
+		AuthStyle:    oauth2.AuthStyleInParams,
+	}
+
+	// the token source outlives any single event, so it must not be bound to an event's context
+	tokenSource = config.TokenSource(context.WithValue(context.Background(), oauth2.HTTPClient, httpClient))
+	r.tokenSources[key] = tokenSource
+	return tokenSource
+}
+
+// newOAuthHTTPClient creates a copy of the HTTP client that adds a bearer token obtained from the token source to each request.
+func newOAuthHTTPClient(httpClient *http.Client, tokenSource oauth2.TokenSource) *http.Client {
+	base := httpClient.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	oauthHTTPClient := *httpClient
+	oauthHTTPClient.Transport = &oauth2.Transport{
+		Source: tokenSource,
+		Base:   base,
+	}
+	return &oauthHTTPClient
+}
@@ -0,0 +1,51 @@
+package dynatrace
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/credentials"
+)
+
+// TestClient_UsesCachedOAuthBearerToken tests that clients authenticating via OAuth send a bearer token that is obtained once and shared between clients.
+func TestClient_UsesCachedOAuthBearerToken(t *testing.T) {
+	var tokenRequestCount int32
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/sso/oauth2/token", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&tokenRequestCount, 1)
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+		assert.Equal(t, "my-client-id", r.PostForm.Get("client_id"))
+		assert.Equal(t, "my-client-secret", r.PostForm.Get("client_secret"))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token": "my-bearer-token", "token_type": "Bearer", "expires_in": 300}`))
+	})
+	mux.HandleFunc("/api/v2/metrics", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer my-bearer-token", r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	oauthCredentials, err := credentials.NewDynatraceOAuthCredentials("my-client-id", "my-client-secret", server.URL+"/sso/oauth2/token")
+	assert.NoError(t, err)
+
+	dynatraceCredentials, err := credentials.NewDynatraceOAuthCredentialsWithAPISettings(server.URL, *oauthCredentials, credentials.DynatraceAPISettings{})
+	assert.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		client := NewClientWithHTTP(dynatraceCredentials, server.Client())
+		_, err = client.Get(context.Background(), "/api/v2/metrics")
+		assert.NoError(t, err)
+	}
+
+	assert.EqualValues(t, 1, atomic.LoadInt32(&tokenRequestCount))
+}
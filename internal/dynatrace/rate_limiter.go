@@ -0,0 +1,93 @@
+package dynatrace
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/metrics"
+)
+
+// rateLimiters holds the rate limiters shared by all clients of the process.
+var rateLimiters = newRateLimiterRegistry()
+
+// rateLimiterRegistry provides a token-bucket rate limiter per Dynatrace tenant.
+type rateLimiterRegistry struct {
+	mutex    sync.Mutex
+	limiters map[string]*rate.Limiter
+}
+
+func newRateLimiterRegistry() *rateLimiterRegistry {
+	return &rateLimiterRegistry{limiters: make(map[string]*rate.Limiter)}
+}
+
+// get returns the rate limiter for the specified tenant, creating it if required.
+// The limit and burst are set once when the limiter of a tenant is created, so that secrets for the same tenant with different limits do not keep overriding each other.
+func (r *rateLimiterRegistry) get(tenant string, requestsPerMinute int, burst int) *rate.Limiter {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	limit := getLimit(requestsPerMinute)
+	if burst < 1 {
+		burst = 1
+	}
+
+	limiter, ok := r.limiters[tenant]
+	if !ok {
+		limiter = rate.NewLimiter(limit, burst)
+		r.limiters[tenant] = limiter
+		return limiter
+	}
+
+	if limiter.Limit() != limit || limiter.Burst() != burst {
+		log.WithField("tenant", tenant).Warnf("Ignoring rate limit of %d requests per minute with burst %d as the rate limiter of the Dynatrace tenant has already been created with a different limit", requestsPerMinute, burst)
+	}
+	return limiter
+}
+
+// getLimit converts requests per minute into a rate.Limit. Zero or less means no limit.
+func getLimit(requestsPerMinute int) rate.Limit {
+	if requestsPerMinute <= 0 {
+		return rate.Inf
+	}
+	return rate.Limit(float64(requestsPerMinute) / time.Minute.Seconds())
+}
+
+// rateLimitingRoundTripper is a http.RoundTripper that waits for the rate limiter of the tenant before sending each request.
+type rateLimitingRoundTripper struct {
+	tenant  string
+	limiter *rate.Limiter
+	next    http.RoundTripper
+}
+
+func newRateLimitingRoundTripper(tenant string, limiter *rate.Limiter, next http.RoundTripper) *rateLimitingRoundTripper {
+	return &rateLimitingRoundTripper{
+		tenant:  tenant,
+		limiter: limiter,
+		next:    next,
+	}
+}
+
+// RoundTrip waits for the rate limiter and then executes the request using the next http.RoundTripper.
+func (rt *rateLimitingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	err := rt.wait(req.Context())
+	if err != nil {
+		return nil, fmt.Errorf("could not wait for rate limiter of Dynatrace tenant %s: %w", rt.tenant, err)
+	}
+
+	return rt.next.RoundTrip(req)
+}
+
+func (rt *rateLimitingRoundTripper) wait(ctx context.Context) error {
+	start := time.Now()
+	defer func() {
+		metrics.DynatraceAPIRateLimitWaitSeconds.WithLabelValues(rt.tenant).Observe(time.Since(start).Seconds())
+	}()
+
+	return rt.limiter.Wait(ctx)
+}
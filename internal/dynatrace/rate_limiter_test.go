@@ -0,0 +1,60 @@
+package dynatrace
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/time/rate"
+)
+
+// TestRateLimiterRegistry_get tests that clients of the same tenant share a rate limiter whose limit and burst are set once when it is created.
+func TestRateLimiterRegistry_get(t *testing.T) {
+	registry := newRateLimiterRegistry()
+
+	limiterA := registry.get("https://a.live.dynatrace.com", 60, 5)
+	assert.Equal(t, rate.Limit(1), limiterA.Limit())
+	assert.Equal(t, 5, limiterA.Burst())
+
+	limiterB := registry.get("https://b.live.dynatrace.com", 60, 5)
+	assert.NotSame(t, limiterA, limiterB)
+
+	otherLimiterA := registry.get("https://a.live.dynatrace.com", 120, 0)
+	assert.Same(t, limiterA, otherLimiterA)
+	assert.Equal(t, rate.Limit(1), otherLimiterA.Limit())
+	assert.Equal(t, 5, otherLimiterA.Burst())
+
+	unlimitedLimiterC := registry.get("https://c.live.dynatrace.com", 0, 0)
+	assert.Equal(t, rate.Inf, unlimitedLimiterC.Limit())
+	assert.Equal(t, 1, unlimitedLimiterC.Burst())
+}
+
+// TestRateLimitingRoundTripper_RoundTrip tests that requests wait for the rate limiter and that waiting respects the request's context.
+func TestRateLimitingRoundTripper_RoundTrip(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// allow a single request per minute
+	limiter := rate.NewLimiter(getLimit(1), 1)
+	client := &http.Client{Transport: newRateLimitingRoundTripper(server.URL, limiter, http.DefaultTransport)}
+
+	resp, err := client.Get(server.URL)
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		resp.Body.Close()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	assert.NoError(t, err)
+
+	_, err = client.Do(req)
+	assert.Error(t, err)
+}
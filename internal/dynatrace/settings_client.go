@@ -0,0 +1,158 @@
+package dynatrace
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+const settingsObjectsPath = "/api/v2/settings/objects"
+const settingsSchemasPath = "/api/v2/settings/schemas"
+
+// settingsEnvironmentScope is the scope of settings objects applying to the whole environment
+const settingsEnvironmentScope = "environment"
+
+// SettingsObject is a settings object of the Settings 2.0 API. Value contains the schema-specific payload.
+type SettingsObject struct {
+	ObjectID string          `json:"objectId,omitempty"`
+	SchemaID string          `json:"schemaId,omitempty"`
+	Scope    string          `json:"scope,omitempty"`
+	Value    json.RawMessage `json:"value"`
+}
+
+type settingsObjectsListResponse struct {
+	Items       []SettingsObject `json:"items"`
+	NextPageKey string           `json:"nextPageKey,omitempty"`
+}
+
+type settingsObjectCreateResponse struct {
+	Code     int    `json:"code"`
+	ObjectID string `json:"objectId"`
+}
+
+// SettingsClient is a client for managing settings objects using the Settings 2.0 API.
+type SettingsClient struct {
+	client ClientInterface
+}
+
+// NewSettingsClient creates a new SettingsClient.
+func NewSettingsClient(client ClientInterface) *SettingsClient {
+	return &SettingsClient{
+		client: client,
+	}
+}
+
+// IsSchemaAvailable returns whether the schema with the specified ID can be retrieved, i.e. whether the tenant supports it and the API token has access to it.
+func (sc *SettingsClient) IsSchemaAvailable(ctx context.Context, schemaID string) bool {
+	_, err := sc.client.Get(ctx, settingsSchemasPath+"/"+schemaID)
+	return err == nil
+}
+
+// GetAll gets all settings objects of the specified schema in the environment scope.
+func (sc *SettingsClient) GetAll(ctx context.Context, schemaID string) ([]SettingsObject, error) {
+	queryParameters := newQueryParameters()
+	queryParameters.add("schemaIds", schemaID)
+	queryParameters.add("scopes", settingsEnvironmentScope)
+	queryParameters.add("fields", "objectId,value")
+	queryParameters.add("pageSize", "500")
+
+	var objects []SettingsObject
+	for {
+		response, err := sc.client.Get(ctx, settingsObjectsPath+"?"+queryParameters.encode())
+		if err != nil {
+			return nil, fmt.Errorf("could not retrieve settings objects of schema %s: %v", schemaID, err)
+		}
+
+		list := &settingsObjectsListResponse{}
+		err = json.Unmarshal(response, list)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal settings objects of schema %s: %v", schemaID, err)
+		}
+
+		objects = append(objects, list.Items...)
+		if list.NextPageKey == "" {
+			return objects, nil
+		}
+
+		// the next page key already includes all other query parameters
+		queryParameters = newQueryParameters()
+		queryParameters.add("nextPageKey", list.NextPageKey)
+	}
+}
+
+// GetByID gets the settings object with the specified ID.
+func (sc *SettingsClient) GetByID(ctx context.Context, objectID string) (*SettingsObject, error) {
+	response, err := sc.client.Get(ctx, settingsObjectsPath+"/"+objectID)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve settings object: %v", err)
+	}
+
+	object := &SettingsObject{}
+	err = json.Unmarshal(response, object)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal settings object: %v", err)
+	}
+
+	return object, nil
+}
+
+// Create creates a settings object of the specified schema in the environment scope and returns its ID.
+func (sc *SettingsClient) Create(ctx context.Context, schemaID string, value interface{}) (string, error) {
+	valuePayload, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal settings object of schema %s: %v", schemaID, err)
+	}
+
+	payload, err := json.Marshal([]SettingsObject{{SchemaID: schemaID, Scope: settingsEnvironmentScope, Value: valuePayload}})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal settings object of schema %s: %v", schemaID, err)
+	}
+
+	response, err := sc.client.Post(ctx, settingsObjectsPath, payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to create settings object of schema %s: %v", schemaID, err)
+	}
+
+	var createdObjects []settingsObjectCreateResponse
+	err = json.Unmarshal(response, &createdObjects)
+	if err != nil {
+		err = CheckForUnexpectedHTMLResponseError(err)
+		return "", fmt.Errorf("failed to unmarshal created settings object of schema %s: %v", schemaID, err)
+	}
+
+	if len(createdObjects) != 1 {
+		return "", fmt.Errorf("expected a single created settings object of schema %s but got %d", schemaID, len(createdObjects))
+	}
+
+	return createdObjects[0].ObjectID, nil
+}
+
+// Update replaces the value of the settings object with the specified ID.
+func (sc *SettingsClient) Update(ctx context.Context, objectID string, value interface{}) error {
+	valuePayload, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal settings object: %v", err)
+	}
+
+	payload, err := json.Marshal(SettingsObject{Value: valuePayload})
+	if err != nil {
+		return fmt.Errorf("failed to marshal settings object: %v", err)
+	}
+
+	_, err = sc.client.Put(ctx, settingsObjectsPath+"/"+objectID, payload)
+	if err != nil {
+		return fmt.Errorf("failed to update settings object: %v", err)
+	}
+
+	return nil
+}
+
+// Delete deletes the settings object with the specified ID.
+func (sc *SettingsClient) Delete(ctx context.Context, objectID string) error {
+	_, err := sc.client.Delete(ctx, settingsObjectsPath+"/"+objectID)
+	if err != nil {
+		return fmt.Errorf("failed to delete settings object: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,193 @@
+package dynatrace
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/test"
+)
+
+const testManagementZonesSettingsObjectsURL = "/api/v2/settings/objects?fields=objectId%2Cvalue&pageSize=500&schemaIds=builtin%3Amanagement-zones&scopes=environment"
+
+// TestSettingsClient_GetAllFollowsNextPageKey tests that all pages of settings objects are retrieved.
+func TestSettingsClient_GetAllFollowsNextPageKey(t *testing.T) {
+	handler := test.NewPayloadBasedURLHandler(t)
+	handler.AddExact(testManagementZonesSettingsObjectsURL, []byte(`{"items":[{"objectId":"mz-1","value":{"name":"Keptn: sockshop","rules":[]}}],"nextPageKey":"page-2"}`))
+	handler.AddExact("/api/v2/settings/objects?nextPageKey=page-2", []byte(`{"items":[{"objectId":"mz-2","value":{"name":"Keptn: sockshop dev","rules":[]}}]}`))
+
+	dtClient, _, teardown := createDynatraceClient(t, handler)
+	defer teardown()
+
+	managementZones, err := NewManagementZonesSettingsClient(dtClient).GetAll(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"Keptn: sockshop", "Keptn: sockshop dev"}, managementZones.GetNames())
+	managementZone, found := managementZones.GetByName("Keptn: sockshop dev")
+	require.True(t, found)
+	assert.Equal(t, "mz-2", managementZone.ID)
+}
+
+// TestSettingsClient_CreateReturnsObjectID tests that the ID of the created settings object is returned.
+func TestSettingsClient_CreateReturnsObjectID(t *testing.T) {
+	handler := test.NewPayloadBasedURLHandler(t)
+	handler.AddExact("/api/v2/settings/objects", []byte(`[{"code":200,"objectId":"profile-1"}]`))
+
+	dtClient, _, teardown := createDynatraceClient(t, handler)
+	defer teardown()
+
+	id, err := NewAlertingProfilesSettingsClient(dtClient).Create(context.Background(), &AlertingProfile{DisplayName: "Keptn"})
+	require.NoError(t, err)
+	assert.Equal(t, "profile-1", id)
+}
+
+// TestNewConfigClientsForAPI tests that the configuration API is selected as configured or, for auto, by the availability of the Settings 2.0 API.
+func TestNewConfigClientsForAPI(t *testing.T) {
+	tests := []struct {
+		name             string
+		api              string
+		schemaStatusCode int
+		expectedClient   ManagementZonesClientInterface
+		expectError      bool
+	}{
+		{
+			name:           "default uses v1",
+			api:            "",
+			expectedClient: &ManagementZonesClient{},
+		},
+		{
+			name:           "v1",
+			api:            ConfigAPIVersion1,
+			expectedClient: &ManagementZonesClient{},
+		},
+		{
+			name:           "settings",
+			api:            ConfigAPISettings,
+			expectedClient: &ManagementZonesSettingsClient{},
+		},
+		{
+			name:             "auto with Settings 2.0 API available",
+			api:              ConfigAPIAuto,
+			schemaStatusCode: http.StatusOK,
+			expectedClient:   &ManagementZonesSettingsClient{},
+		},
+		{
+			name:             "auto without Settings 2.0 API available",
+			api:              ConfigAPIAuto,
+			schemaStatusCode: http.StatusNotFound,
+			expectedClient:   &ManagementZonesClient{},
+		},
+		{
+			name:        "unsupported",
+			api:         "v3",
+			expectError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := test.NewPayloadBasedURLHandler(t)
+			if tt.schemaStatusCode == http.StatusOK {
+				handler.AddExact("/api/v2/settings/schemas/builtin:management-zones", []byte(`{"schemaId":"builtin:management-zones"}`))
+			} else if tt.schemaStatusCode != 0 {
+				handler.AddExactError("/api/v2/settings/schemas/builtin:management-zones", tt.schemaStatusCode, []byte(`{"error":{"code":404,"message":"Schema not found"}}`))
+			}
+
+			dtClient, _, teardown := createDynatraceClient(t, handler)
+			defer teardown()
+
+			configClients, err := NewConfigClientsForAPI(context.Background(), dtClient, tt.api)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.IsType(t, tt.expectedClient, configClients.ManagementZones)
+		})
+	}
+}
+
+// TestManagementZoneSettingsRoundTrip tests that a management zone generated by the dynatrace-service is unchanged by its conversion to and from Settings 2.0.
+func TestManagementZoneSettingsRoundTrip(t *testing.T) {
+	managementZone := &ManagementZone{
+		Name: "Keptn: sockshop dev",
+		Rules: []MZRules{
+			{
+				Type:             ServiceEntityType,
+				Enabled:          true,
+				PropagationTypes: []string{},
+				Conditions: []MZConditions{
+					{
+						Key:            MZKey{Attribute: "SERVICE_TAGS"},
+						ComparisonInfo: MZComparisonInfo{Type: "TAG", Operator: "EQUALS", Value: MZValue{Context: "CONTEXTLESS", Key: KeptnProject, Value: "sockshop"}},
+					},
+					{
+						Key:            MZKey{Attribute: "SERVICE_TAGS"},
+						ComparisonInfo: MZComparisonInfo{Type: "TAG", Operator: "EQUALS", Value: MZValue{Context: "CONTEXTLESS", Key: KeptnStage, Value: "dev"}, Negate: true},
+					},
+				},
+			},
+		},
+	}
+
+	settings := toManagementZoneSettings(managementZone)
+	assert.Equal(t, "EQUALS", settings.Rules[0].AttributeRule.Conditions[0].Operator)
+	assert.Equal(t, "keptn_project:sockshop", settings.Rules[0].AttributeRule.Conditions[0].Tag)
+	assert.Equal(t, "NOT_EQUALS", settings.Rules[0].AttributeRule.Conditions[1].Operator)
+
+	assert.Equal(t, managementZone, fromManagementZoneSettings(settings))
+}
+
+// TestAutoTagSettingsRoundTrip tests that an auto-tagging rule generated by the dynatrace-service is unchanged by its conversion to and from Settings 2.0.
+func TestAutoTagSettingsRoundTrip(t *testing.T) {
+	rule := &DTTaggingRule{
+		Name: "keptn_stage",
+		Rules: []Rules{
+			{
+				Type:             ServiceEntityType,
+				Enabled:          true,
+				ValueFormat:      "{ProcessGroup:Environment:keptn_stage}",
+				PropagationTypes: []string{propagationTypeServiceToProcessGroup},
+				Conditions: []Conditions{
+					{
+						Key: Key{
+							Attribute:  "PROCESS_GROUP_CUSTOM_METADATA",
+							DynamicKey: DynamicKey{Source: "ENVIRONMENT", Key: "keptn_stage"},
+							Type:       keyTypeProcessCustomMetadataKey,
+						},
+						ComparisonInfo: ComparisonInfo{Type: comparisonTypeString, Operator: "EXISTS"},
+					},
+				},
+			},
+		},
+	}
+
+	settings := toAutoTagSettings(rule)
+	assert.True(t, settings.Rules[0].AttributeRule.ServiceToPGPropagation)
+	assert.Equal(t, "keptn_stage", settings.Rules[0].AttributeRule.Conditions[0].DynamicKey)
+
+	assert.Equal(t, rule, fromAutoTagSettings(settings))
+}
+
+// TestAlertingProfileSettingsRoundTrip tests that an alerting profile is unchanged by its conversion to and from Settings 2.0.
+func TestAlertingProfileSettingsRoundTrip(t *testing.T) {
+	alertingProfile := &AlertingProfile{
+		ID:          "profile-1",
+		DisplayName: "Keptn",
+		Rules: []AlertingProfileRules{
+			{SeverityLevel: "AVAILABILITY", TagFilter: AlertingProfileTagFilter{IncludeMode: "NONE"}},
+			{SeverityLevel: "ERROR", TagFilter: AlertingProfileTagFilter{IncludeMode: "INCLUDE_ANY", TagFilters: []string{"keptn_project:sockshop"}}, DelayInMinutes: 5},
+		},
+		EventTypeFilters: []*AlertingProfileEventTypeFilter{
+			{CustomEventFilter: CustomEventFilter{CustomTitleFilter: CustomTitleFilter{Enabled: true, Value: "Keptn", Operator: "CONTAINS", CaseInsensitive: true}}},
+		},
+	}
+
+	settings := toAlertingProfileSettings(alertingProfile)
+	assert.False(t, settings.EventFilters[0].CustomFilter.TitleFilter.CaseSensitive)
+
+	assert.Equal(t, alertingProfile, fromAlertingProfileSettings("profile-1", settings))
+}
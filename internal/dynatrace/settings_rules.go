@@ -0,0 +1,119 @@
+package dynatrace
+
+import "strings"
+
+const (
+	settingsRuleTypeMonitoredEntity = "ME"
+
+	propagationTypeServiceToHost         = "SERVICE_TO_HOST_LIKE"
+	propagationTypeServiceToProcessGroup = "SERVICE_TO_PROCESS_GROUP_LIKE"
+
+	settingsNegatedOperatorPrefix = "NOT_"
+	settingsTagKeyEqualsOperator  = "TAG_KEY_EQUALS"
+
+	comparisonTypeTag    = "TAG"
+	comparisonTypeString = "STRING"
+
+	keyTypeStatic                   = "STATIC"
+	keyTypeProcessCustomMetadataKey = "PROCESS_CUSTOM_METADATA_KEY"
+	tagContextContextless           = "CONTEXTLESS"
+	autoTagValueNormalizationAsIs   = "Leave text as-is"
+)
+
+// settingsRule is a rule of a management zone or an auto-tagging rule settings object.
+type settingsRule struct {
+	Enabled            bool                   `json:"enabled"`
+	Type               string                 `json:"type"`
+	ValueFormat        string                 `json:"valueFormat,omitempty"`
+	ValueNormalization string                 `json:"valueNormalization,omitempty"`
+	AttributeRule      *settingsAttributeRule `json:"attributeRule,omitempty"`
+}
+
+type settingsAttributeRule struct {
+	EntityType               string                       `json:"entityType"`
+	ServiceToHostPropagation bool                         `json:"serviceToHostPropagation"`
+	ServiceToPGPropagation   bool                         `json:"serviceToPGPropagation"`
+	Conditions               []settingsAttributeCondition `json:"conditions"`
+}
+
+type settingsAttributeCondition struct {
+	Key              string `json:"key"`
+	Operator         string `json:"operator"`
+	StringValue      string `json:"stringValue,omitempty"`
+	CaseSensitive    *bool  `json:"caseSensitive,omitempty"`
+	Tag              string `json:"tag,omitempty"`
+	DynamicKey       string `json:"dynamicKey,omitempty"`
+	DynamicKeySource string `json:"dynamicKeySource,omitempty"`
+}
+
+// newSettingsAttributeRule creates an attribute rule for the specified entity type, propagation types and conditions.
+func newSettingsAttributeRule(entityType string, propagationTypes []string, conditions []settingsAttributeCondition) *settingsAttributeRule {
+	rule := &settingsAttributeRule{
+		EntityType: entityType,
+		Conditions: conditions,
+	}
+
+	for _, propagationType := range propagationTypes {
+		switch propagationType {
+		case propagationTypeServiceToHost:
+			rule.ServiceToHostPropagation = true
+		case propagationTypeServiceToProcessGroup:
+			rule.ServiceToPGPropagation = true
+		}
+	}
+	return rule
+}
+
+// getPropagationTypes returns the Configuration API v1 propagation types of the attribute rule.
+func (r *settingsAttributeRule) getPropagationTypes() []string {
+	propagationTypes := []string{}
+	if r.ServiceToHostPropagation {
+		propagationTypes = append(propagationTypes, propagationTypeServiceToHost)
+	}
+	if r.ServiceToPGPropagation {
+		propagationTypes = append(propagationTypes, propagationTypeServiceToProcessGroup)
+	}
+	return propagationTypes
+}
+
+// toSettingsOperator returns the Settings 2.0 operator, which includes the negation.
+func toSettingsOperator(operator string, negate bool) string {
+	if negate {
+		return settingsNegatedOperatorPrefix + operator
+	}
+	return operator
+}
+
+// fromSettingsOperator returns the Configuration API v1 operator and negation.
+func fromSettingsOperator(operator string) (string, bool) {
+	if strings.HasPrefix(operator, settingsNegatedOperatorPrefix) {
+		return strings.TrimPrefix(operator, settingsNegatedOperatorPrefix), true
+	}
+	return operator, false
+}
+
+// formatSettingsTag returns the tag in the format used by the Settings 2.0 API, i.e. [context]key:value, omitting the context if it is CONTEXTLESS.
+func formatSettingsTag(context string, key string, value string) string {
+	tag := key
+	if value != "" {
+		tag = tag + ":" + value
+	}
+	if context != "" && context != tagContextContextless {
+		tag = "[" + context + "]" + tag
+	}
+	return tag
+}
+
+// parseSettingsTag returns the context, key and value of a tag in the format used by the Settings 2.0 API.
+func parseSettingsTag(tag string) (string, string, string) {
+	context := tagContextContextless
+	if strings.HasPrefix(tag, "[") {
+		if end := strings.Index(tag, "]"); end > 0 {
+			context = tag[1:end]
+			tag = tag[end+1:]
+		}
+	}
+
+	key, value, _ := strings.Cut(tag, ":")
+	return context, key, value
+}
@@ -0,0 +1,131 @@
+package dynatrace
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// SLOEvaluationTypeAggregate evaluates an SLO by aggregating its metric expression over the timeframe.
+const SLOEvaluationTypeAggregate = "AGGREGATE"
+
+// SLODefinition is the definition of a Dynatrace SLO.
+type SLODefinition struct {
+	ID                string  `json:"id,omitempty"`
+	Enabled           bool    `json:"enabled"`
+	Name              string  `json:"name"`
+	CustomDescription string  `json:"customDescription,omitempty"`
+	MetricName        string  `json:"metricName,omitempty"`
+	MetricExpression  string  `json:"metricExpression"`
+	EvaluationType    string  `json:"evaluationType"`
+	Filter            string  `json:"filter,omitempty"`
+	Target            float64 `json:"target"`
+	Warning           float64 `json:"warning"`
+	Timeframe         string  `json:"timeframe"`
+}
+
+type sloDefinitionsListResponse struct {
+	SLOs        []SLODefinition `json:"slo"`
+	NextPageKey string          `json:"nextPageKey,omitempty"`
+}
+
+// SLODefinitionsClient is a client for managing the definitions of Dynatrace SLOs.
+type SLODefinitionsClient struct {
+	client ClientInterface
+}
+
+// NewSLODefinitionsClient creates a new SLODefinitionsClient.
+func NewSLODefinitionsClient(client ClientInterface) *SLODefinitionsClient {
+	return &SLODefinitionsClient{
+		client: client,
+	}
+}
+
+// GetIDsByName returns the IDs of all SLOs by their names.
+func (sc *SLODefinitionsClient) GetIDsByName(ctx context.Context) (map[string]string, error) {
+	queryParameters := newQueryParameters()
+	queryParameters.add("pageSize", "500")
+	queryParameters.add("evaluate", "false")
+
+	ids := make(map[string]string)
+	for {
+		response, err := sc.client.Get(ctx, SLOPath+"?"+queryParameters.encode())
+		if err != nil {
+			return nil, fmt.Errorf("could not retrieve SLOs: %v", err)
+		}
+
+		list := &sloDefinitionsListResponse{}
+		err = json.Unmarshal(response, list)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal SLOs: %v", err)
+		}
+
+		for _, slo := range list.SLOs {
+			ids[slo.Name] = slo.ID
+		}
+
+		if list.NextPageKey == "" {
+			return ids, nil
+		}
+
+		// the next page key already includes all other query parameters
+		queryParameters = newQueryParameters()
+		queryParameters.add("nextPageKey", list.NextPageKey)
+	}
+}
+
+// GetByID gets the definition of the SLO with the specified ID.
+func (sc *SLODefinitionsClient) GetByID(ctx context.Context, id string) (*SLODefinition, error) {
+	response, err := sc.client.Get(ctx, SLOPath+"/"+id)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve SLO: %v", err)
+	}
+
+	slo := &SLODefinition{}
+	err = json.Unmarshal(response, slo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal SLO: %v", err)
+	}
+
+	return slo, nil
+}
+
+// Create creates an SLO.
+func (sc *SLODefinitionsClient) Create(ctx context.Context, slo *SLODefinition) error {
+	payload, err := json.Marshal(slo)
+	if err != nil {
+		return fmt.Errorf("failed to marshal SLO: %v", err)
+	}
+
+	_, err = sc.client.Post(ctx, SLOPath, payload)
+	if err != nil {
+		return fmt.Errorf("failed to create SLO: %v", err)
+	}
+
+	return nil
+}
+
+// DeleteByID deletes the SLO with the specified ID.
+func (sc *SLODefinitionsClient) DeleteByID(ctx context.Context, id string) error {
+	_, err := sc.client.Delete(ctx, SLOPath+"/"+id)
+	if err != nil {
+		return fmt.Errorf("failed to delete SLO: %v", err)
+	}
+
+	return nil
+}
+
+// Update updates the SLO with the specified ID.
+func (sc *SLODefinitionsClient) Update(ctx context.Context, id string, slo *SLODefinition) error {
+	payload, err := json.Marshal(slo)
+	if err != nil {
+		return fmt.Errorf("failed to marshal SLO: %v", err)
+	}
+
+	_, err = sc.client.Put(ctx, SLOPath+"/"+id, payload)
+	if err != nil {
+		return fmt.Errorf("failed to update SLO: %v", err)
+	}
+
+	return nil
+}
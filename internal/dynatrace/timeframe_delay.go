@@ -6,7 +6,11 @@ import (
 	"fmt"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+
 	"github.com/keptn-contrib/dynatrace-service/internal/common"
+	"github.com/keptn-contrib/dynatrace-service/internal/metrics"
+	"github.com/keptn-contrib/dynatrace-service/internal/tracing"
 )
 
 // TimeframeDelay encapsulates the calculation and execution of a delay relative to a timeframe.
@@ -32,6 +36,14 @@ func (d TimeframeDelay) Wait(ctx context.Context) error {
 		return err
 	}
 
+	_, span := tracing.StartSpan(ctx, "wait for timeframe delay", attribute.String("dynatrace.timeframe_delay.wait_duration", waitDuration.String()))
+	defer span.End()
+
+	start := time.Now()
+	defer func() {
+		metrics.TimeframeDelayWaitSeconds.Observe(time.Since(start).Seconds())
+	}()
+
 	select {
 	case <-ctx.Done():
 		return errors.New("delay sleep interrupted")
@@ -0,0 +1,179 @@
+package dynatrace
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/credentials"
+)
+
+// TestNewClient_MutualTLS tests that a client created from a secret containing a CA certificate and a client certificate can access a tenant using a private PKI and requiring client authentication.
+func TestNewClient_MutualTLS(t *testing.T) {
+	ca := newTestCertificateAuthority(t)
+	serverCert := ca.issue(t, "server", x509.ExtKeyUsageServerAuth)
+	clientCert := ca.issue(t, "client", x509.ExtKeyUsageClientAuth)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if assert.Len(t, r.TLS.PeerCertificates, 1) {
+			assert.Equal(t, "client", r.TLS.PeerCertificates[0].Subject.CommonName)
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	server.TLS = &tls.Config{
+		Certificates: []tls.Certificate{serverCert.tlsCertificate(t)},
+		ClientCAs:    ca.pool(),
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	tests := []struct {
+		name       string
+		secretData map[string]string
+		wantErr    bool
+	}{
+		{
+			name: "with CA and client certificate",
+			secretData: map[string]string{
+				"DT_CA_CERT":     string(ca.certificatePEM),
+				"DT_CLIENT_CERT": string(clientCert.certificatePEM),
+				"DT_CLIENT_KEY":  string(clientCert.privateKeyPEM),
+			},
+			wantErr: false,
+		},
+		{
+			name: "without client certificate",
+			secretData: map[string]string{
+				"DT_CA_CERT": string(ca.certificatePEM),
+			},
+			wantErr: true,
+		},
+		{
+			name: "without CA certificate",
+			secretData: map[string]string{
+				"DT_CLIENT_CERT": string(clientCert.certificatePEM),
+				"DT_CLIENT_KEY":  string(clientCert.privateKeyPEM),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.secretData["DT_TENANT"] = server.URL
+			tt.secretData["DT_API_TOKEN"] = testDynatraceAPIToken
+			tt.secretData["DT_API_MAX_RETRIES"] = "0"
+
+			secret := &v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "dynatrace", Namespace: "keptn"},
+				Data:       make(map[string][]byte),
+			}
+			for key, value := range tt.secretData {
+				secret.Data[key] = []byte(value)
+			}
+			credentialsProvider := credentials.NewDynatraceK8sSecretReader(credentials.NewK8sSecretReader(fake.NewSimpleClientset(secret)))
+			dynatraceCredentials, err := credentialsProvider.GetDynatraceCredentials(context.Background(), "dynatrace")
+			require.NoError(t, err)
+
+			_, err = NewClient(dynatraceCredentials).Get(context.Background(), "/api/v2/metrics")
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+type testCertificate struct {
+	certificate    *x509.Certificate
+	privateKey     *ecdsa.PrivateKey
+	certificatePEM []byte
+	privateKeyPEM  []byte
+}
+
+func (c *testCertificate) tlsCertificate(t *testing.T) tls.Certificate {
+	certificate, err := tls.X509KeyPair(c.certificatePEM, c.privateKeyPEM)
+	require.NoError(t, err)
+	return certificate
+}
+
+type testCertificateAuthority struct {
+	testCertificate
+}
+
+func newTestCertificateAuthority(t *testing.T) *testCertificateAuthority {
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	return &testCertificateAuthority{testCertificate: *createTestCertificate(t, template, nil)}
+}
+
+func (ca *testCertificateAuthority) issue(t *testing.T, commonName string, extKeyUsage x509.ExtKeyUsage) *testCertificate {
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{extKeyUsage},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	return createTestCertificate(t, template, &ca.testCertificate)
+}
+
+func (ca *testCertificateAuthority) pool() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.certificate)
+	return pool
+}
+
+// createTestCertificate creates a certificate signed by the issuer or a self-signed certificate if the issuer is nil.
+func createTestCertificate(t *testing.T, template *x509.Certificate, issuer *testCertificate) *testCertificate {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	parent, signer := template, privateKey
+	if issuer != nil {
+		parent, signer = issuer.certificate, issuer.privateKey
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &privateKey.PublicKey, signer)
+	require.NoError(t, err)
+
+	certificate, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	privateKeyDER, err := x509.MarshalECPrivateKey(privateKey)
+	require.NoError(t, err)
+
+	return &testCertificate{
+		certificate:    certificate,
+		privateKey:     privateKey,
+		certificatePEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		privateKeyPEM:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: privateKeyDER}),
+	}
+}
@@ -100,11 +100,175 @@ func IsMetricEventsGenerationEnabled() bool {
 	return readEnvAsBool("GENERATE_METRIC_EVENTS", false)
 }
 
+// IsSLOsGenerationEnabled returns whether SLOs should be generated when configuring the monitoring
+func IsSLOsGenerationEnabled() bool {
+	return readEnvAsBool("GENERATE_SLOS", false)
+}
+
 // IsHttpSSLVerificationEnabled returns whether the SSL verification is enabled or disabled
 func IsHttpSSLVerificationEnabled() bool {
 	return readEnvAsBool("HTTP_SSL_VERIFY", true)
 }
 
+// GetAPIMaxRetries returns the maximum number of times a failed Dynatrace API request should be retried.
+// If not set, 3 retries are assumed.
+func GetAPIMaxRetries() int {
+	return readEnvAsInt("DYNATRACE_API_MAX_RETRIES", 3)
+}
+
+// GetAPIRetryInitialBackoff returns the upper bound of the delay before the first retry of a failed Dynatrace API request.
+// If not set, 500 milliseconds are assumed.
+func GetAPIRetryInitialBackoff() time.Duration {
+	return time.Duration(readEnvAsInt("DYNATRACE_API_RETRY_INITIAL_BACKOFF_MILLISECONDS", 500)) * time.Millisecond
+}
+
+// GetAPIRetryMaxBackoff returns the maximum delay between two attempts of a Dynatrace API request.
+// If not set, 30 seconds are assumed.
+func GetAPIRetryMaxBackoff() time.Duration {
+	return time.Duration(readEnvAsInt("DYNATRACE_API_RETRY_MAX_BACKOFF_SECONDS", 30)) * time.Second
+}
+
+// IsAPIRetryOfNonIdempotentRequestsEnabled returns whether non-idempotent Dynatrace API requests (e.g. POST) should be retried after transport or server errors.
+func IsAPIRetryOfNonIdempotentRequestsEnabled() bool {
+	return readEnvAsBool("DYNATRACE_API_RETRY_NON_IDEMPOTENT_REQUESTS", false)
+}
+
+// GetAPIRequestsPerMinute returns the maximum number of requests per minute that may be sent to a single Dynatrace tenant by all clients of the service.
+// A value of 0 disables the rate limit. If not set, 600 requests per minute are assumed.
+func GetAPIRequestsPerMinute() int {
+	return readEnvAsInt("DYNATRACE_API_REQUESTS_PER_MINUTE", 600)
+}
+
+// GetAPIRateLimitBurst returns the maximum number of requests that may be sent to a single Dynatrace tenant in a burst.
+// If not set, a burst of 10 requests is assumed.
+func GetAPIRateLimitBurst() int {
+	return readEnvAsInt("DYNATRACE_API_RATE_LIMIT_BURST", 10)
+}
+
+// GetAPICacheMaxEntries returns the maximum number of Dynatrace API responses held in the cache.
+// If not set, 1000 entries are assumed.
+func GetAPICacheMaxEntries() int {
+	return readEnvAsInt("DYNATRACE_API_CACHE_MAX_ENTRIES", 1000)
+}
+
+// GetAPICacheMetricDefinitionsTTL returns how long metric definitions are cached. A value of 0 disables caching.
+// If not set, 5 minutes are assumed.
+func GetAPICacheMetricDefinitionsTTL() time.Duration {
+	return time.Duration(readEnvAsInt("DYNATRACE_API_CACHE_METRIC_DEFINITIONS_TTL_SECONDS", 300)) * time.Second
+}
+
+// GetAPICacheUnitConversionsTTL returns how long unit conversions are cached. A value of 0 disables caching.
+// If not set, 1 hour is assumed.
+func GetAPICacheUnitConversionsTTL() time.Duration {
+	return time.Duration(readEnvAsInt("DYNATRACE_API_CACHE_UNIT_CONVERSIONS_TTL_SECONDS", 3600)) * time.Second
+}
+
+// GetAPICacheDashboardsTTL returns how long the list of dashboards is cached. A value of 0 disables caching.
+// If not set, 1 minute is assumed.
+func GetAPICacheDashboardsTTL() time.Duration {
+	return time.Duration(readEnvAsInt("DYNATRACE_API_CACHE_DASHBOARDS_TTL_SECONDS", 60)) * time.Second
+}
+
+// GetAPICacheManagementZonesTTL returns how long the list of management zones is cached. A value of 0 disables caching.
+// If not set, 1 minute is assumed.
+func GetAPICacheManagementZonesTTL() time.Duration {
+	return time.Duration(readEnvAsInt("DYNATRACE_API_CACHE_MANAGEMENT_ZONES_TTL_SECONDS", 60)) * time.Second
+}
+
+// IsTracingEnabled returns whether spans should be exported via OTLP.
+func IsTracingEnabled() bool {
+	return readEnvAsBool("TRACING_ENABLED", false)
+}
+
+// GetMetricsPort returns the port of the metrics endpoint.
+// If not set, port 9090 is assumed.
+func GetMetricsPort() int {
+	return readEnvAsInt("METRICS_PORT", 9090)
+}
+
+// GetOutboxAdminPort returns the port of the admin endpoint listing the pending outbox entries, which is only reachable from within the pod.
+// If not set, port 8090 is assumed.
+func GetOutboxAdminPort() int {
+	return readEnvAsInt("OUTBOX_ADMIN_PORT", 8090)
+}
+
+// GetEventWorkers returns the maximum number of events that are processed concurrently.
+// If not set, 50 workers are assumed.
+func GetEventWorkers() int {
+	return readEnvAsInt("EVENT_WORKERS", 50)
+}
+
+// GetEventQueueSize returns the maximum number of events waiting for a free worker. Once the queue is full, receiving further events is blocked.
+// If not set, a queue size of 1000 is assumed.
+func GetEventQueueSize() int {
+	return readEnvAsInt("EVENT_QUEUE_SIZE", 1000)
+}
+
+// GetEventMaxWorkersPerProject returns the maximum number of events of a single Keptn project that are processed concurrently.
+// A value of 0 disables the limit. If not set, 0 is assumed.
+func GetEventMaxWorkersPerProject() int {
+	return readEnvAsInt("EVENT_MAX_WORKERS_PER_PROJECT", 0)
+}
+
+// GetEventDeduplicationStore returns the type of store used to detect duplicate deliveries of events: "memory", "configmap", "file" or "none".
+// If not set, "memory" is assumed.
+func GetEventDeduplicationStore() string {
+	return readEnvAsString("EVENT_DEDUPLICATION_STORE", "memory")
+}
+
+// GetEventDeduplicationMaxEntries returns the maximum number of received events remembered by the "memory" and "file" stores to detect duplicate deliveries.
+// If not set, 10000 entries are assumed.
+func GetEventDeduplicationMaxEntries() int {
+	return readEnvAsInt("EVENT_DEDUPLICATION_MAX_ENTRIES", 10000)
+}
+
+// GetEventDeduplicationConfigMapMaxEntries returns the maximum number of received events remembered by the "configmap" store to detect duplicate deliveries.
+// The keys of all events are written to the ConfigMap on each update, so the default is lower than for the other stores.
+// If not set, 2000 entries are assumed.
+func GetEventDeduplicationConfigMapMaxEntries() int {
+	return readEnvAsInt("EVENT_DEDUPLICATION_CONFIGMAP_MAX_ENTRIES", 2000)
+}
+
+// GetEventDeduplicationConfigMap returns the name of the ConfigMap used by the "configmap" event deduplication store.
+// If not set, "dynatrace-service-received-events" is assumed.
+func GetEventDeduplicationConfigMap() string {
+	return readEnvAsString("EVENT_DEDUPLICATION_CONFIGMAP", "dynatrace-service-received-events")
+}
+
+// GetEventDeduplicationFile returns the path of the file used by the "file" event deduplication store.
+func GetEventDeduplicationFile() string {
+	return os.Getenv("EVENT_DEDUPLICATION_FILE")
+}
+
+// GetOutboxStore returns the type of store used to persist events that could not be sent to Dynatrace: "configmap", "file" or "none".
+// If not set, "none" is assumed, i.e. such events are discarded.
+func GetOutboxStore() string {
+	return readEnvAsString("OUTBOX_STORE", "none")
+}
+
+// GetOutboxDirectory returns the directory used by the "file" outbox store.
+func GetOutboxDirectory() string {
+	return os.Getenv("OUTBOX_DIRECTORY")
+}
+
+// GetOutboxRetryInterval returns the interval at which events in the outbox are retried.
+// If not set, 30 seconds are assumed.
+func GetOutboxRetryInterval() time.Duration {
+	return time.Duration(readEnvAsInt("OUTBOX_RETRY_INTERVAL_SECONDS", 30)) * time.Second
+}
+
+// GetOutboxMaxBackoff returns the maximum delay between two attempts to send an event in the outbox.
+// If not set, 1 hour is assumed.
+func GetOutboxMaxBackoff() time.Duration {
+	return time.Duration(readEnvAsInt("OUTBOX_MAX_BACKOFF_SECONDS", 3600)) * time.Second
+}
+
+// GetOutboxMaxAge returns the maximum age of an event in the outbox, after which it is discarded.
+// If not set, 24 hours are assumed.
+func GetOutboxMaxAge() time.Duration {
+	return time.Duration(readEnvAsInt("OUTBOX_MAX_AGE_SECONDS", 86400)) * time.Second
+}
+
 // IsServiceSyncEnabled returns wether the service synchronization is enabled or disabled
 func IsServiceSyncEnabled() bool {
 	return readEnvAsBool("SYNCHRONIZE_DYNATRACE_SERVICES", false)
@@ -116,6 +280,46 @@ func GetServiceSyncInterval() int {
 	return readEnvAsInt("SYNCHRONIZE_DYNATRACE_SERVICES_INTERVAL_SECONDS", 60)
 }
 
+// IsMonitoringReconciliationEnabled returns whether the Dynatrace entities generated when configuring the monitoring should be periodically checked for drift.
+func IsMonitoringReconciliationEnabled() bool {
+	return readEnvAsBool("RECONCILE_MONITORING", false)
+}
+
+// GetMonitoringReconciliationInterval returns the number of seconds between two reconciliation runs. If not set, 3600 seconds is assumed.
+func GetMonitoringReconciliationInterval() int {
+	return readEnvAsInt("RECONCILE_MONITORING_INTERVAL_SECONDS", 3600)
+}
+
+// GetMonitoringReconciliationPolicy returns how drift of Dynatrace entities is handled, i.e. "report" or "correct". If not set, "report" is assumed.
+func GetMonitoringReconciliationPolicy() string {
+	return readEnvAsString("RECONCILE_MONITORING_POLICY", "report")
+}
+
+// GetProjectDeletionCleanupPolicy returns how the Dynatrace entities of a deleted Keptn project are handled, i.e. "none", "report" or "delete". If not set, "none" is assumed.
+func GetProjectDeletionCleanupPolicy() string {
+	return readEnvAsString("CLEANUP_ON_PROJECT_DELETION", "none")
+}
+
+// GetProjectDeletionCleanupCredentialsSecretName returns the name of the secret containing the credentials of the Dynatrace tenant cleaned up if the configuration of a deleted project is not available.
+// If not set, the cleanup of such projects is skipped.
+func GetProjectDeletionCleanupCredentialsSecretName() string {
+	return os.Getenv("CLEANUP_ON_PROJECT_DELETION_DT_CREDS")
+}
+
+func readEnvAsString(env string, defaultValue string) string {
+	envValue := os.Getenv(env)
+	if envValue == "" {
+		log.WithFields(
+			log.Fields{
+				"name":    env,
+				"default": defaultValue,
+			}).Info("Environment variable not set or empty. Using default value.")
+		return defaultValue
+	}
+
+	return envValue
+}
+
 func readEnvAsBool(env string, defaultValue bool) bool {
 	envValue := os.Getenv(env)
 	if envValue == "" {
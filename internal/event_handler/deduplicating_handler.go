@@ -0,0 +1,57 @@
+package event_handler
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/deduplication"
+)
+
+// DuplicateEventHandler handles an event that has already been received before by doing nothing.
+type DuplicateEventHandler struct{}
+
+// HandleEvent acknowledges the duplicate event without any side effects.
+func (DuplicateEventHandler) HandleEvent(workCtx context.Context, replyCtx context.Context) error {
+	return nil
+}
+
+// deduplicatingHandler is a DynatraceEventHandler decorator that records the event in the deduplication store once it has been handled successfully.
+// Events that fail to be handled are not recorded, so that a redelivery is processed again.
+type deduplicatingHandler struct {
+	store   deduplication.Store
+	key     string
+	handler DynatraceEventHandler
+}
+
+func newDeduplicatingHandler(store deduplication.Store, key string, handler DynatraceEventHandler) *deduplicatingHandler {
+	return &deduplicatingHandler{
+		store:   store,
+		key:     key,
+		handler: handler,
+	}
+}
+
+// HandleEvent handles the event using the decorated handler.
+func (h *deduplicatingHandler) HandleEvent(workCtx context.Context, replyCtx context.Context) error {
+	err := h.handler.HandleEvent(workCtx, replyCtx)
+	if err != nil {
+		return err
+	}
+
+	if addErr := h.store.Add(replyCtx, h.key); addErr != nil {
+		log.WithError(addErr).WithField("key", h.key).Error("Could not record handled event in deduplication store")
+	}
+	return nil
+}
+
+// isDuplicateEvent returns whether the event key has already been recorded in the store.
+// If the store is not available, the event is not considered a duplicate.
+func isDuplicateEvent(ctx context.Context, store deduplication.Store, key string) bool {
+	contained, err := store.Contains(ctx, key)
+	if err != nil {
+		log.WithError(err).WithField("key", key).Warn("Could not check for duplicate event, processing it anyway")
+		return false
+	}
+	return contained
+}
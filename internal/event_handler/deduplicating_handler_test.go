@@ -0,0 +1,26 @@
+package event_handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/deduplication"
+)
+
+// TestDeduplicatingHandler_HandleEvent tests that an event is only recorded in the deduplication store once it has been handled successfully.
+func TestDeduplicatingHandler_HandleEvent(t *testing.T) {
+	const key = "sh.keptn.event.test.triggered/my-event-id"
+	store := deduplication.NewInMemoryStore(10)
+
+	wantErr := errors.New("failed")
+	err := newDeduplicatingHandler(store, key, eventHandlerMock{err: wantErr}).HandleEvent(context.Background(), context.Background())
+	assert.ErrorIs(t, err, wantErr)
+	assert.False(t, isDuplicateEvent(context.Background(), store, key), "a failed event should not be recorded")
+
+	err = newDeduplicatingHandler(store, key, eventHandlerMock{}).HandleEvent(context.Background(), context.Background())
+	assert.NoError(t, err)
+	assert.True(t, isDuplicateEvent(context.Background(), store, key), "a handled event should be recorded")
+}
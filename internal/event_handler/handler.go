@@ -14,9 +14,13 @@ import (
 	"github.com/keptn-contrib/dynatrace-service/internal/adapter"
 	"github.com/keptn-contrib/dynatrace-service/internal/config"
 	"github.com/keptn-contrib/dynatrace-service/internal/credentials"
+	"github.com/keptn-contrib/dynatrace-service/internal/deduplication"
 	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+	"github.com/keptn-contrib/dynatrace-service/internal/env"
 	"github.com/keptn-contrib/dynatrace-service/internal/keptn"
+	"github.com/keptn-contrib/dynatrace-service/internal/metrics"
 	"github.com/keptn-contrib/dynatrace-service/internal/monitoring"
+	"github.com/keptn-contrib/dynatrace-service/internal/outbox"
 	"github.com/keptn-contrib/dynatrace-service/internal/problem"
 	"github.com/keptn-contrib/dynatrace-service/internal/sli"
 )
@@ -29,17 +33,31 @@ type DynatraceEventHandler interface {
 }
 
 // NewEventHandler creates a new DynatraceEventHandler for the specified event.
-func NewEventHandler(ctx context.Context, clientFactory keptn.ClientFactoryInterface, eventSenderClient keptn.EventSenderClientInterface, event cloudevents.Event) (DynatraceEventHandler, error) {
-	eventHandler, err := getEventHandler(ctx, eventSenderClient, event, clientFactory)
+// Events that have already been recorded in the deduplication store are handled by a DuplicateEventHandler.
+// If an outbox store is specified, events that could not be sent to Dynatrace due to a temporary failure are added to it to be retried later.
+// The problem window store tracks the deduplication windows and cooldowns of problems received from Dynatrace.
+// The returned handler records metrics about handling the event and traces both its creation and handling in a single span, which is ended once the event has been handled.
+func NewEventHandler(ctx context.Context, clientFactory keptn.ClientFactoryInterface, eventSenderClient keptn.EventSenderClientInterface, event cloudevents.Event, deduplicationStore deduplication.Store, outboxStore outbox.Store, problemWindowStore deduplication.WindowStore) (DynatraceEventHandler, error) {
+	metrics.EventsReceivedTotal.WithLabelValues(event.Type()).Inc()
+
+	ctx, span := startEventSpan(ctx, event)
+	eventKey := deduplication.GetEventKey(event)
+	if isDuplicateEvent(ctx, deduplicationStore, eventKey) {
+		log.WithField("eventType", event.Type()).WithField("eventID", event.ID()).Info("Ignoring duplicate event")
+		return newTracingHandler(span, newMetricsRecordingHandler(event.Type(), DuplicateEventHandler{})), nil
+	}
+
+	eventHandler, err := getEventHandler(ctx, eventSenderClient, event, clientFactory, outboxStore, problemWindowStore)
 	if err != nil {
 		log.WithError(err).Error("Cannot handle event")
-		return NewErrorHandler(fmt.Errorf("cannot handle event: %w", err), event, eventSenderClient, clientFactory.CreateUniformClient()), nil
+		span.RecordError(err)
+		eventHandler = NewErrorHandler(fmt.Errorf("cannot handle event: %w", err), event, eventSenderClient, clientFactory.CreateUniformClient())
 	}
 
-	return eventHandler, nil
+	return newTracingHandler(span, newDeduplicatingHandler(deduplicationStore, eventKey, newMetricsRecordingHandler(event.Type(), eventHandler))), nil
 }
 
-func getEventHandler(ctx context.Context, eventSenderClient keptn.EventSenderClientInterface, event cloudevents.Event, clientFactory keptn.ClientFactoryInterface) (DynatraceEventHandler, error) {
+func getEventHandler(ctx context.Context, eventSenderClient keptn.EventSenderClientInterface, event cloudevents.Event, clientFactory keptn.ClientFactoryInterface, outboxStore outbox.Store, problemWindowStore deduplication.WindowStore) (DynatraceEventHandler, error) {
 	log.WithField("eventType", event.Type()).Debug("Received event")
 
 	keptnEvent, err := getEventAdapter(event)
@@ -78,7 +96,17 @@ func getEventHandler(ctx context.Context, eventSenderClient keptn.EventSenderCli
 	dynatraceConfigGetter := config.NewDynatraceConfigGetter(keptn.NewConfigClient(clientFactory.CreateResourceClient()))
 	dynatraceConfig, err := dynatraceConfigGetter.GetDynatraceConfig(ctx, keptnEvent)
 	if err != nil {
-		return nil, fmt.Errorf("could not get configuration: %w", err)
+		// the configuration of a deleted project is usually no longer available
+		if _, isProjectDeleteFinished := keptnEvent.(*monitoring.ProjectDeleteFinishedAdapter); !isProjectDeleteFinished {
+			return nil, fmt.Errorf("could not get configuration: %w", err)
+		}
+
+		dynatraceConfig = getDynatraceConfigForDeletedProject()
+		if dynatraceConfig == nil {
+			log.WithError(err).WithField("project", keptnEvent.GetProject()).Warn("Skipping cleanup of deleted project as its configuration is not available and CLEANUP_ON_PROJECT_DELETION_DT_CREDS is not set")
+			return NoOpHandler{}, nil
+		}
+		log.WithError(err).WithField("dtCreds", dynatraceConfig.DtCreds).Warn("Could not get configuration of deleted project, using the credentials configured for the cleanup")
 	}
 
 	dynatraceCredentialsProvider, err := credentials.NewDefaultDynatraceK8sSecretReader()
@@ -91,7 +119,15 @@ func getEventHandler(ctx context.Context, eventSenderClient keptn.EventSenderCli
 		return nil, fmt.Errorf("could not get Dynatrace credentials: %w", err)
 	}
 
-	dtClient := dynatrace.NewClient(dynatraceCredentials)
+	dtClient := dynatrace.NewCachingClient(dynatrace.NewClient(dynatraceCredentials))
+
+	eventsClient, err := dynatrace.NewEventsClientForAPIVersion(dtClient, dynatraceConfig.EventsAPI)
+	if err != nil {
+		return nil, fmt.Errorf("could not create Dynatrace events client: %w", err)
+	}
+	if outboxStore != nil {
+		eventsClient = outbox.NewEventsClient(eventsClient, outboxStore, dynatraceConfig.DtCreds, dynatraceConfig.EventsAPI)
+	}
 
 	keptnCredentialsProvider, err := credentials.NewDefaultKeptnCredentialsReader()
 	if err != nil {
@@ -100,27 +136,47 @@ func getEventHandler(ctx context.Context, eventSenderClient keptn.EventSenderCli
 
 	switch aType := keptnEvent.(type) {
 	case *monitoring.ConfigureMonitoringAdapter:
-		return monitoring.NewConfigureMonitoringEventHandler(keptnEvent.(*monitoring.ConfigureMonitoringAdapter), dtClient, eventSenderClient, keptn.NewConfigClient(clientFactory.CreateResourceClient()), keptn.NewConfigClient(clientFactory.CreateResourceClient()), clientFactory.CreateServiceClient(), keptnCredentialsProvider, keptn.NewDefaultCredentialsChecker()), nil
+		configClients, err := dynatrace.NewConfigClientsForAPI(ctx, dtClient, dynatraceConfig.ConfigAPI)
+		if err != nil {
+			return nil, fmt.Errorf("could not create Dynatrace configuration clients: %w", err)
+		}
+		return monitoring.NewConfigureMonitoringEventHandler(keptnEvent.(*monitoring.ConfigureMonitoringAdapter), dtClient, configClients, eventSenderClient, keptn.NewConfigClient(clientFactory.CreateResourceClient()), keptn.NewConfigClient(clientFactory.CreateResourceClient()), keptn.NewConfigClient(clientFactory.CreateResourceClient()), clientFactory.CreateServiceClient(), keptnCredentialsProvider, keptn.NewDefaultCredentialsChecker(), dynatraceConfig.Monitoring), nil
+	case *monitoring.ProjectDeleteFinishedAdapter:
+		configClients, err := dynatrace.NewConfigClientsForAPI(ctx, dtClient, dynatraceConfig.ConfigAPI)
+		if err != nil {
+			return nil, fmt.Errorf("could not create Dynatrace configuration clients: %w", err)
+		}
+		return monitoring.NewProjectDeleteFinishedEventHandler(keptnEvent.(*monitoring.ProjectDeleteFinishedAdapter), dtClient, configClients, monitoring.CleanupPolicy(env.GetProjectDeletionCleanupPolicy())), nil
 	case *problem.ProblemAdapter:
-		return problem.NewProblemEventHandler(keptnEvent.(*problem.ProblemAdapter), eventSenderClient), nil
+		return problem.NewProblemEventHandler(keptnEvent.(*problem.ProblemAdapter), eventSenderClient, dynatrace.NewEntitiesClient(dtClient), dynatrace.NewProblemsClient(dtClient), problemWindowStore, dynatraceConfig.ProblemRouting, dynatraceConfig.ProblemFilter), nil
 	case *action.ActionTriggeredAdapter:
-		return action.NewActionTriggeredEventHandler(keptnEvent.(*action.ActionTriggeredAdapter), dtClient, clientFactory.CreateEventClient(), keptn.NewBridgeURLCreator(keptnCredentialsProvider), dynatraceConfig.AttachRules), nil
+		return action.NewActionTriggeredEventHandler(keptnEvent.(*action.ActionTriggeredAdapter), dtClient, eventsClient, clientFactory.CreateEventClient(), keptn.NewBridgeURLCreator(keptnCredentialsProvider), dynatraceConfig.AttachRules, dynatraceConfig.GetEventTemplate(event.Type())), nil
 	case *action.ActionStartedAdapter:
 		return action.NewActionStartedEventHandler(keptnEvent.(*action.ActionStartedAdapter), dtClient, clientFactory.CreateEventClient(), keptn.NewBridgeURLCreator(keptnCredentialsProvider)), nil
 	case *action.ActionFinishedAdapter:
-		return action.NewActionFinishedEventHandler(keptnEvent.(*action.ActionFinishedAdapter), dtClient, clientFactory.CreateEventClient(), keptn.NewBridgeURLCreator(keptnCredentialsProvider), dynatraceConfig.AttachRules), nil
+		return action.NewActionFinishedEventHandler(keptnEvent.(*action.ActionFinishedAdapter), dtClient, eventsClient, clientFactory.CreateEventClient(), keptn.NewBridgeURLCreator(keptnCredentialsProvider), dynatraceConfig.AttachRules, dynatraceConfig.GetEventTemplate(event.Type())), nil
 	case *sli.GetSLITriggeredAdapter:
 		return sli.NewGetSLITriggeredHandler(keptnEvent.(*sli.GetSLITriggeredAdapter), dtClient, eventSenderClient, keptn.NewConfigClient(clientFactory.CreateResourceClient()), dynatraceConfig.DtCreds, dynatraceConfig.Dashboard), nil
 	case *action.DeploymentFinishedAdapter:
-		return action.NewDeploymentFinishedEventHandler(keptnEvent.(*action.DeploymentFinishedAdapter), dtClient, clientFactory.CreateEventClient(), keptn.NewBridgeURLCreator(keptnCredentialsProvider), dynatraceConfig.AttachRules), nil
+		return action.NewDeploymentFinishedEventHandler(keptnEvent.(*action.DeploymentFinishedAdapter), dtClient, eventsClient, clientFactory.CreateEventClient(), keptn.NewBridgeURLCreator(keptnCredentialsProvider), dynatraceConfig.AttachRules, dynatraceConfig.GetEventTemplate(event.Type())), nil
 	case *action.TestTriggeredAdapter:
-		return action.NewTestTriggeredEventHandler(keptnEvent.(*action.TestTriggeredAdapter), dtClient, clientFactory.CreateEventClient(), keptn.NewBridgeURLCreator(keptnCredentialsProvider), dynatraceConfig.AttachRules), nil
+		return action.NewTestTriggeredEventHandler(keptnEvent.(*action.TestTriggeredAdapter), dtClient, eventsClient, clientFactory.CreateEventClient(), keptn.NewBridgeURLCreator(keptnCredentialsProvider), dynatraceConfig.AttachRules, dynatraceConfig.GetEventTemplate(event.Type())), nil
 	case *action.TestFinishedAdapter:
-		return action.NewTestFinishedEventHandler(keptnEvent.(*action.TestFinishedAdapter), dtClient, clientFactory.CreateEventClient(), keptn.NewBridgeURLCreator(keptnCredentialsProvider), dynatraceConfig.AttachRules), nil
+		return action.NewTestFinishedEventHandler(keptnEvent.(*action.TestFinishedAdapter), dtClient, eventsClient, clientFactory.CreateEventClient(), keptn.NewBridgeURLCreator(keptnCredentialsProvider), dynatraceConfig.AttachRules, dynatraceConfig.GetEventTemplate(event.Type())), nil
 	case *action.EvaluationFinishedAdapter:
-		return action.NewEvaluationFinishedEventHandler(keptnEvent.(*action.EvaluationFinishedAdapter), dtClient, clientFactory.CreateEventClient(), keptn.NewBridgeURLCreator(keptnCredentialsProvider), dynatraceConfig.AttachRules), nil
+		return action.NewEvaluationFinishedEventHandler(keptnEvent.(*action.EvaluationFinishedAdapter), dtClient, eventsClient, clientFactory.CreateEventClient(), keptn.NewBridgeURLCreator(keptnCredentialsProvider), dynatraceConfig.AttachRules, dynatraceConfig.GetEventTemplate(event.Type())), nil
 	case *action.ReleaseTriggeredAdapter:
-		return action.NewReleaseTriggeredEventHandler(keptnEvent.(*action.ReleaseTriggeredAdapter), dtClient, clientFactory.CreateEventClient(), keptn.NewBridgeURLCreator(keptnCredentialsProvider), dynatraceConfig.AttachRules), nil
+		return action.NewReleaseTriggeredEventHandler(keptnEvent.(*action.ReleaseTriggeredAdapter), dtClient, eventsClient, clientFactory.CreateEventClient(), keptn.NewBridgeURLCreator(keptnCredentialsProvider), dynatraceConfig.AttachRules, dynatraceConfig.GetEventTemplate(event.Type())), nil
+	case *action.ApprovalTriggeredAdapter:
+		return action.NewApprovalTriggeredEventHandler(keptnEvent.(*action.ApprovalTriggeredAdapter), dtClient, eventsClient, clientFactory.CreateEventClient(), keptn.NewBridgeURLCreator(keptnCredentialsProvider), dynatraceConfig.AttachRules, dynatraceConfig.GetEventTemplate(event.Type())), nil
+	case *action.ApprovalFinishedAdapter:
+		return action.NewApprovalFinishedEventHandler(keptnEvent.(*action.ApprovalFinishedAdapter), dtClient, eventsClient, clientFactory.CreateEventClient(), keptn.NewBridgeURLCreator(keptnCredentialsProvider), dynatraceConfig.AttachRules, dynatraceConfig.GetEventTemplate(event.Type())), nil
+	case *action.RollbackTriggeredAdapter:
+		return action.NewRollbackTriggeredEventHandler(keptnEvent.(*action.RollbackTriggeredAdapter), dtClient, eventsClient, clientFactory.CreateEventClient(), keptn.NewBridgeURLCreator(keptnCredentialsProvider), dynatraceConfig.AttachRules, dynatraceConfig.GetEventTemplate(event.Type())), nil
+	case *action.RollbackFinishedAdapter:
+		return action.NewRollbackFinishedEventHandler(keptnEvent.(*action.RollbackFinishedAdapter), dtClient, eventsClient, clientFactory.CreateEventClient(), keptn.NewBridgeURLCreator(keptnCredentialsProvider), dynatraceConfig.AttachRules, dynatraceConfig.GetEventTemplate(event.Type())), nil
+	case *action.SequenceFinishedAdapter:
+		return action.NewSequenceFinishedEventHandler(keptnEvent.(*action.SequenceFinishedAdapter), dtClient, eventsClient, clientFactory.CreateEventClient(), keptn.NewBridgeURLCreator(keptnCredentialsProvider), dynatraceConfig.AttachRules, dynatraceConfig.GetEventTemplate(event.Type())), nil
 	default:
 		return NewErrorHandler(fmt.Errorf("this should not have happened, we are missing an implementation for: %T", aType), event, eventSenderClient, clientFactory.CreateUniformClient()), nil
 	}
@@ -157,8 +213,38 @@ func getEventAdapter(e cloudevents.Event) (adapter.EventContentAdapter, error) {
 		return action.NewEvaluationFinishedAdapterFromEvent(e)
 	case keptnv2.GetTriggeredEventType(keptnv2.ReleaseTaskName):
 		return action.NewReleaseTriggeredAdapterFromEvent(e)
+	case keptnv2.GetTriggeredEventType(keptnv2.ApprovalTaskName):
+		return action.NewApprovalTriggeredAdapterFromEvent(e)
+	case keptnv2.GetFinishedEventType(keptnv2.ApprovalTaskName):
+		return action.NewApprovalFinishedAdapterFromEvent(e)
+	case keptnv2.GetTriggeredEventType(keptnv2.RollbackTaskName):
+		return action.NewRollbackTriggeredAdapterFromEvent(e)
+	case keptnv2.GetFinishedEventType(keptnv2.RollbackTaskName):
+		return action.NewRollbackFinishedAdapterFromEvent(e)
+	case keptnv2.GetFinishedEventType(keptnv2.ProjectDeleteTaskName):
+		if monitoring.CleanupPolicy(env.GetProjectDeletionCleanupPolicy()) == monitoring.CleanupPolicyNone {
+			log.WithField("eventType", e.Type()).Debug("Ignoring event as cleanup on project deletion is disabled")
+			return nil, nil
+		}
+		return monitoring.NewProjectDeleteFinishedAdapterFromEvent(e)
 	default:
+		if keptnv2.IsSequenceEventType(e.Type()) && keptnv2.IsFinishedEventType(e.Type()) {
+			return action.NewSequenceFinishedAdapterFromEvent(e)
+		}
+
 		log.WithField("eventType", e.Type()).Debug("Ignoring event")
 		return nil, nil
 	}
 }
+
+// getDynatraceConfigForDeletedProject returns the configuration used to clean up a deleted project whose configuration is no longer available, or nil if no credentials are configured for the cleanup.
+func getDynatraceConfigForDeletedProject() *config.DynatraceConfig {
+	credentialsSecretName := env.GetProjectDeletionCleanupCredentialsSecretName()
+	if credentialsSecretName == "" {
+		return nil
+	}
+
+	dynatraceConfig := config.NewDynatraceConfigWithDefaults()
+	dynatraceConfig.DtCreds = credentialsSecretName
+	return dynatraceConfig
+}
@@ -6,8 +6,11 @@ import (
 	"testing"
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/keptn-contrib/dynatrace-service/internal/action"
 	"github.com/keptn-contrib/dynatrace-service/internal/adapter"
+	"github.com/keptn-contrib/dynatrace-service/internal/deduplication"
 	"github.com/keptn-contrib/dynatrace-service/internal/keptn"
+	"github.com/keptn-contrib/dynatrace-service/internal/monitoring"
 	"github.com/keptn-contrib/dynatrace-service/internal/sli"
 	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
 	"github.com/stretchr/testify/assert"
@@ -46,6 +49,70 @@ func Test_getEventAdapterForGetSLITriggeredNotForDynatrace(t *testing.T) {
 	assert.Nil(t, adapter)
 }
 
+// Test_getEventAdapterForSequenceEvents tests that getEventAdapter returns an action.SequenceFinishedAdapter for sequence finished events and ignores other sequence events.
+func Test_getEventAdapterForSequenceEvents(t *testing.T) {
+	sequenceFinishedEvent, err := createTestCloudEvent("sh.keptn.event.hardening.delivery.finished", keptnv2.EventData{Project: "my-project", Stage: "hardening", Service: "test", Result: keptnv2.ResultPass})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	eventAdapter, err := getEventAdapter(sequenceFinishedEvent)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	sequenceFinishedAdapter, ok := eventAdapter.(*action.SequenceFinishedAdapter)
+	if !assert.True(t, ok) {
+		return
+	}
+	assert.Equal(t, "delivery", sequenceFinishedAdapter.GetSequence())
+	assert.Equal(t, "sh.keptn.event.hardening.delivery.finished", sequenceFinishedAdapter.GetEvent())
+
+	sequenceTriggeredEvent, err := createTestCloudEvent("sh.keptn.event.hardening.delivery.triggered", keptnv2.EventData{Project: "my-project", Stage: "hardening", Service: "test"})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	eventAdapter, err = getEventAdapter(sequenceTriggeredEvent)
+	assert.NoError(t, err)
+	assert.Nil(t, eventAdapter)
+}
+
+// Test_getEventAdapterForProjectDeleteFinished tests that getEventAdapter returns a monitoring.ProjectDeleteFinishedAdapter rather than an action.SequenceFinishedAdapter for project delete finished events if cleanup on project deletion is enabled.
+func Test_getEventAdapterForProjectDeleteFinished(t *testing.T) {
+	projectDeleteFinishedEvent, err := createTestCloudEvent("sh.keptn.event.project.delete.finished", keptnv2.EventData{Project: "my-project", Status: keptnv2.StatusSucceeded, Result: keptnv2.ResultPass})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	eventAdapter, err := getEventAdapter(projectDeleteFinishedEvent)
+	assert.NoError(t, err)
+	assert.Nil(t, eventAdapter)
+
+	t.Setenv("CLEANUP_ON_PROJECT_DELETION", "report")
+	eventAdapter, err = getEventAdapter(projectDeleteFinishedEvent)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	projectDeleteFinishedAdapter, ok := eventAdapter.(*monitoring.ProjectDeleteFinishedAdapter)
+	if !assert.True(t, ok) {
+		return
+	}
+	assert.Equal(t, "my-project", projectDeleteFinishedAdapter.GetProject())
+}
+
+// Test_getDynatraceConfigForDeletedProject tests that the configuration used to clean up a deleted project only targets the explicitly configured tenant.
+func Test_getDynatraceConfigForDeletedProject(t *testing.T) {
+	assert.Nil(t, getDynatraceConfigForDeletedProject())
+
+	t.Setenv("CLEANUP_ON_PROJECT_DELETION_DT_CREDS", "dynatrace-prod")
+	dynatraceConfig := getDynatraceConfigForDeletedProject()
+	if assert.NotNil(t, dynatraceConfig) {
+		assert.Equal(t, "dynatrace-prod", dynatraceConfig.DtCreds)
+	}
+}
+
 // TestEventHandlerIgnoresGetSLITriggeredNotForDynatrace tests that EventHandler ignores "sh.keptn.event.get-sli.triggered" events with an SLIProvider other than "dynatrace".
 func TestEventHandlerIgnoresGetSLITriggeredNotForDynatrace(t *testing.T) {
 	getSLITriggeredEvent, err := createTestGetSLITriggeredCloudEvent("other")
@@ -61,7 +128,7 @@ func TestEventHandlerIgnoresGetSLITriggeredNotForDynatrace(t *testing.T) {
 		t: t,
 	}
 
-	handler, err := NewEventHandler(context.Background(), clientFactory, eventSenderClient, getSLITriggeredEvent)
+	handler, err := NewEventHandler(context.Background(), clientFactory, eventSenderClient, getSLITriggeredEvent, deduplication.NewNoOpStore(), nil, nil)
 	if !assert.NoError(t, err) {
 		return
 	}
@@ -70,6 +137,54 @@ func TestEventHandlerIgnoresGetSLITriggeredNotForDynatrace(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+// TestEventHandlerIgnoresDuplicateEvents tests that EventHandler handles an event that has already been received by a DuplicateEventHandler.
+func TestEventHandlerIgnoresDuplicateEvents(t *testing.T) {
+	getSLITriggeredEvent, err := createTestGetSLITriggeredCloudEvent("other")
+	if !assert.NoError(t, err) {
+		return
+	}
+	getSLITriggeredEvent.SetID("my-event-id")
+
+	clientFactory := &clientFactoryMock{
+		t: t,
+	}
+
+	eventSenderClient := &eventSenderClientMock{
+		t: t,
+	}
+
+	store := deduplication.NewInMemoryStore(10)
+	var handledBy []DynatraceEventHandler
+	for i := 0; i < 2; i++ {
+		handler, err := NewEventHandler(context.Background(), clientFactory, eventSenderClient, getSLITriggeredEvent, store, nil, nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		assert.NoError(t, handler.HandleEvent(context.Background(), context.Background()))
+		handledBy = append(handledBy, getInnermostHandler(handler))
+	}
+
+	assert.IsType(t, NoOpHandler{}, handledBy[0])
+	assert.IsType(t, DuplicateEventHandler{}, handledBy[1])
+}
+
+// getInnermostHandler gets the handler wrapped by any decorators.
+func getInnermostHandler(handler DynatraceEventHandler) DynatraceEventHandler {
+	for {
+		switch h := handler.(type) {
+		case *tracingHandler:
+			handler = h.handler
+		case *deduplicatingHandler:
+			handler = h.handler
+		case *metricsRecordingHandler:
+			handler = h.handler
+		default:
+			return handler
+		}
+	}
+}
+
 func createTestGetSLITriggeredCloudEvent(sliProvider string) (cloudevents.Event, error) {
 	return createTestCloudEvent("sh.keptn.event.get-sli.triggered", keptnv2.GetSLITriggeredEventData{
 		EventData: keptnv2.EventData{
@@ -131,6 +246,11 @@ func (m *clientFactoryMock) CreateServiceClient() keptn.ServiceClientInterface {
 	return nil
 }
 
+func (m *clientFactoryMock) CreateProjectClient() keptn.ProjectClientInterface {
+	m.t.Fatalf("CreateProjectClient() should not be needed in this mock!")
+	return nil
+}
+
 func (m *clientFactoryMock) CreateUniformClient() keptn.UniformClientInterface {
 	m.t.Fatalf("CreateUniformClient() should not be needed in this mock!")
 	return nil
@@ -0,0 +1,56 @@
+package event_handler
+
+import (
+	"context"
+	"time"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/metrics"
+)
+
+const (
+	eventOutcomeSucceeded = "succeeded"
+	eventOutcomeFailed    = "failed"
+	eventOutcomeErrored   = "errored"
+	eventOutcomeIgnored   = "ignored"
+	eventOutcomeDuplicate = "duplicate"
+)
+
+// metricsRecordingHandler is a DynatraceEventHandler decorator that records the outcome and duration of handling an event.
+type metricsRecordingHandler struct {
+	eventType string
+	handler   DynatraceEventHandler
+}
+
+func newMetricsRecordingHandler(eventType string, handler DynatraceEventHandler) *metricsRecordingHandler {
+	return &metricsRecordingHandler{
+		eventType: eventType,
+		handler:   handler,
+	}
+}
+
+// HandleEvent handles the event using the decorated handler and records metrics.
+func (h *metricsRecordingHandler) HandleEvent(workCtx context.Context, replyCtx context.Context) error {
+	start := time.Now()
+	err := h.handler.HandleEvent(workCtx, replyCtx)
+
+	metrics.EventHandlingDurationSeconds.WithLabelValues(h.eventType).Observe(time.Since(start).Seconds())
+	metrics.EventsHandledTotal.WithLabelValues(h.eventType, h.getOutcome(err)).Inc()
+	return err
+}
+
+// getOutcome gets the outcome of handling the event: ignored events are handled by a NoOpHandler, duplicate events by a DuplicateEventHandler and events that could not be processed at all by an ErrorHandler.
+func (h *metricsRecordingHandler) getOutcome(err error) string {
+	switch h.handler.(type) {
+	case NoOpHandler:
+		return eventOutcomeIgnored
+	case DuplicateEventHandler:
+		return eventOutcomeDuplicate
+	case *ErrorHandler:
+		return eventOutcomeErrored
+	}
+
+	if err != nil {
+		return eventOutcomeFailed
+	}
+	return eventOutcomeSucceeded
+}
@@ -0,0 +1,67 @@
+package event_handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+// eventHandlerMock is an implementation of DynatraceEventHandler that returns the specified error.
+type eventHandlerMock struct {
+	err error
+}
+
+func (m eventHandlerMock) HandleEvent(workCtx context.Context, replyCtx context.Context) error {
+	return m.err
+}
+
+func TestMetricsRecordingHandler_getOutcome(t *testing.T) {
+	tests := []struct {
+		name        string
+		handler     DynatraceEventHandler
+		err         error
+		wantOutcome string
+	}{
+		{
+			name:        "ignored event",
+			handler:     NoOpHandler{},
+			wantOutcome: eventOutcomeIgnored,
+		},
+		{
+			name:        "duplicate event",
+			handler:     DuplicateEventHandler{},
+			wantOutcome: eventOutcomeDuplicate,
+		},
+		{
+			name:        "event that could not be processed",
+			handler:     NewErrorHandler(errors.New("error"), cloudevents.NewEvent(), nil, nil),
+			wantOutcome: eventOutcomeErrored,
+		},
+		{
+			name:        "failed event",
+			handler:     eventHandlerMock{err: errors.New("failed")},
+			err:         errors.New("failed"),
+			wantOutcome: eventOutcomeFailed,
+		},
+		{
+			name:        "succeeded event",
+			handler:     eventHandlerMock{},
+			wantOutcome: eventOutcomeSucceeded,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newMetricsRecordingHandler("sh.keptn.event.test.triggered", tt.handler)
+			assert.Equal(t, tt.wantOutcome, h.getOutcome(tt.err))
+		})
+	}
+}
+
+func TestMetricsRecordingHandler_HandleEvent(t *testing.T) {
+	wantErr := errors.New("failed")
+	h := newMetricsRecordingHandler("sh.keptn.event.test.triggered", eventHandlerMock{err: wantErr})
+	assert.ErrorIs(t, h.HandleEvent(context.Background(), context.Background()), wantErr)
+}
@@ -0,0 +1,29 @@
+package event_handler
+
+import (
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	keptnevents "github.com/keptn/go-utils/pkg/lib"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/worker"
+)
+
+// GetEventPriority returns the priority with which the event should be processed.
+// Events Keptn is waiting for are prioritized over events that are only forwarded to Dynatrace.
+func GetEventPriority(event cloudevents.Event) worker.Priority {
+	switch event.Type() {
+	case keptnv2.GetTriggeredEventType(keptnv2.GetSLITaskName), keptnevents.ConfigureMonitoringEventType:
+		return worker.PriorityHigh
+	default:
+		return worker.PriorityLow
+	}
+}
+
+// GetEventProject returns the Keptn project of the event or an empty string if it is not available.
+func GetEventProject(event cloudevents.Event) string {
+	eventData := &keptnv2.EventData{}
+	if err := event.DataAs(eventData); err != nil {
+		return ""
+	}
+	return eventData.Project
+}
@@ -0,0 +1,58 @@
+package event_handler
+
+import (
+	"testing"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/worker"
+)
+
+func TestGetEventPriority(t *testing.T) {
+	tests := []struct {
+		eventType    string
+		wantPriority worker.Priority
+	}{
+		{
+			eventType:    "sh.keptn.event.get-sli.triggered",
+			wantPriority: worker.PriorityHigh,
+		},
+		{
+			eventType:    "sh.keptn.event.monitoring.configure",
+			wantPriority: worker.PriorityHigh,
+		},
+		{
+			eventType:    "sh.keptn.event.deployment.finished",
+			wantPriority: worker.PriorityLow,
+		},
+		{
+			eventType:    "sh.keptn.events.problem",
+			wantPriority: worker.PriorityLow,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.eventType, func(t *testing.T) {
+			event, err := createTestCloudEvent(tt.eventType, keptnv2.EventData{Project: "my-project"})
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			assert.Equal(t, tt.wantPriority, GetEventPriority(event))
+		})
+	}
+}
+
+func TestGetEventProject(t *testing.T) {
+	event, err := createTestGetSLITriggeredCloudEvent("dynatrace")
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "my-project", GetEventProject(event))
+
+	event, err = createTestCloudEvent("sh.keptn.event.deployment.finished", "not an object")
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "", GetEventProject(event))
+}
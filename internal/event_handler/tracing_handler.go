@@ -0,0 +1,48 @@
+package event_handler
+
+import (
+	"context"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/tracing"
+)
+
+// tracingHandler is a DynatraceEventHandler decorator that ends the span covering the creation and handling of an event.
+type tracingHandler struct {
+	span    trace.Span
+	handler DynatraceEventHandler
+}
+
+// startEventSpan starts the span covering the creation and handling of the event. All further spans created for the event are its descendants.
+func startEventSpan(ctx context.Context, event cloudevents.Event) (context.Context, trace.Span) {
+	return tracing.StartSpan(ctx, event.Type(),
+		attribute.String("keptn.event.id", event.ID()),
+		attribute.String("keptn.event.type", event.Type()),
+		tracing.KeptnContextAttributeKey.String(getKeptnContext(event)))
+}
+
+// getKeptnContext gets the shkeptncontext extension of the event or an empty string if it is not available.
+func getKeptnContext(event cloudevents.Event) string {
+	keptnContext, ok := event.Extensions()["shkeptncontext"].(string)
+	if !ok {
+		return ""
+	}
+	return keptnContext
+}
+
+func newTracingHandler(span trace.Span, handler DynatraceEventHandler) *tracingHandler {
+	return &tracingHandler{
+		span:    span,
+		handler: handler,
+	}
+}
+
+// HandleEvent handles the event using the decorated handler as part of the span and then ends the span.
+func (h *tracingHandler) HandleEvent(workCtx context.Context, replyCtx context.Context) error {
+	err := h.handler.HandleEvent(trace.ContextWithSpan(workCtx, h.span), trace.ContextWithSpan(replyCtx, h.span))
+	tracing.EndSpan(h.span, err)
+	return err
+}
@@ -0,0 +1,62 @@
+package event_handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/tracing"
+)
+
+// childSpanHandlerMock is an implementation of DynatraceEventHandler that creates a child span and returns the specified error.
+type childSpanHandlerMock struct {
+	err error
+}
+
+func (m childSpanHandlerMock) HandleEvent(workCtx context.Context, replyCtx context.Context) error {
+	_, span := tracing.StartSpan(workCtx, "child")
+	span.End()
+	return m.err
+}
+
+// TestTracingHandler tests that the event span carries the Keptn context, is the parent of spans created while handling the event and records any error.
+func TestTracingHandler(t *testing.T) {
+	spanRecorder := tracetest.NewSpanRecorder()
+	previousTracerProvider := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder)))
+	defer otel.SetTracerProvider(previousTracerProvider)
+
+	event := cloudevents.NewEvent()
+	event.SetID("my-event-id")
+	event.SetType("sh.keptn.event.get-sli.triggered")
+	event.SetExtension("shkeptncontext", "my-keptn-context")
+
+	ctx, span := startEventSpan(context.Background(), event)
+	handler := newTracingHandler(span, childSpanHandlerMock{err: errors.New("failed")})
+
+	err := handler.HandleEvent(ctx, ctx)
+	assert.EqualError(t, err, "failed")
+
+	endedSpans := spanRecorder.Ended()
+	if !assert.Len(t, endedSpans, 2) {
+		return
+	}
+
+	childSpan := endedSpans[0]
+	eventSpan := endedSpans[1]
+	assert.Equal(t, "child", childSpan.Name())
+	assert.Equal(t, eventSpan.SpanContext().SpanID(), childSpan.Parent().SpanID())
+
+	assert.Equal(t, "sh.keptn.event.get-sli.triggered", eventSpan.Name())
+	assert.Contains(t, eventSpan.Attributes(), attribute.String("keptn.event.id", "my-event-id"))
+	assert.Contains(t, eventSpan.Attributes(), tracing.KeptnContextAttributeKey.String("my-keptn-context"))
+	assert.Equal(t, codes.Error, eventSpan.Status().Code)
+}
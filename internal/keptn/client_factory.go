@@ -10,6 +10,7 @@ import (
 // ClientFactoryInterface provides a factories for clients.
 type ClientFactoryInterface interface {
 	CreateEventClient() EventClientInterface
+	CreateProjectClient() ProjectClientInterface
 	CreateResourceClient() ResourceClientInterface
 	CreateServiceClient() ServiceClientInterface
 	CreateUniformClient() UniformClientInterface
@@ -35,6 +36,11 @@ func (c *ClientFactory) CreateEventClient() EventClientInterface {
 	return NewEventClient(c.apiSet.Events())
 }
 
+// CreateProjectClient creates a ProjectClientInterface.
+func (c *ClientFactory) CreateProjectClient() ProjectClientInterface {
+	return NewProjectClient(c.apiSet.Projects())
+}
+
 // CreateResourceClient creates a ResourceClientInterface.
 func (c *ClientFactory) CreateResourceClient() ResourceClientInterface {
 	return NewResourceClient(c.apiSet.Resources())
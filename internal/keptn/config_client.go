@@ -51,10 +51,17 @@ type DynatraceConfigReaderInterface interface {
 	GetDynatraceConfig(ctx context.Context, project string, stage string, service string) (string, error)
 }
 
+// DashboardTemplateReaderInterface provides functionality for getting a project's dashboard template.
+type DashboardTemplateReaderInterface interface {
+	// GetDashboardTemplate returns the dashboard template of a project.
+	GetDashboardTemplate(ctx context.Context, project string) (string, error)
+}
+
 const shipyardFilename = "shipyard.yaml"
 const sloFilename = "slo.yaml"
 const sliFilename = "dynatrace/sli.yaml"
 const configFilename = "dynatrace/dynatrace.conf.yaml"
+const dashboardTemplateFilename = "dynatrace/dashboard.template.json"
 
 // ConfigClient is the default implementation for ResourceClientInterface using a ConfigResourceClientInterface.
 type ConfigClient struct {
@@ -125,6 +132,11 @@ func (rc *ConfigClient) GetShipyard(ctx context.Context, project string) (*keptn
 	return &shipyard, nil
 }
 
+// GetDashboardTemplate returns the dashboard template of a project.
+func (rc *ConfigClient) GetDashboardTemplate(ctx context.Context, project string) (string, error) {
+	return rc.client.GetProjectResource(ctx, project, dashboardTemplateFilename)
+}
+
 type sliMap map[string]string
 
 func (m sliMap) insertOrUpdateMany(x map[string]string) {
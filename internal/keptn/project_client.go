@@ -0,0 +1,41 @@
+package keptn
+
+import (
+	"context"
+	"fmt"
+
+	v2 "github.com/keptn/go-utils/pkg/api/utils/v2"
+)
+
+// ProjectClientInterface provides access to Keptn projects.
+type ProjectClientInterface interface {
+	// GetProjectNames gets the names of all projects or returns an error.
+	GetProjectNames(ctx context.Context) ([]string, error)
+}
+
+// ProjectClient is an implementation of ProjectClientInterface using v2.ProjectsInterface.
+type ProjectClient struct {
+	projectsClient v2.ProjectsInterface
+}
+
+// NewProjectClient creates a new ProjectClient using the specified client.
+func NewProjectClient(projectsClient v2.ProjectsInterface) *ProjectClient {
+	return &ProjectClient{
+		projectsClient: projectsClient,
+	}
+}
+
+// GetProjectNames gets the names of all projects or returns an error.
+func (c *ProjectClient) GetProjectNames(ctx context.Context) ([]string, error) {
+	projects, err := c.projectsClient.GetAllProjects(ctx, v2.ProjectsGetAllProjectsOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch Keptn projects: %w", err)
+	}
+
+	projectNames := make([]string, len(projects))
+	for i, project := range projects {
+		projectNames[i] = project.ProjectName
+	}
+
+	return projectNames, nil
+}
@@ -9,6 +9,9 @@ import (
 	keptnmodels "github.com/keptn/go-utils/pkg/api/models"
 	v2 "github.com/keptn/go-utils/pkg/api/utils/v2"
 	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/tracing"
 )
 
 const projectFieldName = "project"
@@ -115,6 +118,13 @@ func NewResourceClient(client v2.ResourcesInterface) *ResourceClient {
 
 // GetResource tries to find the first instance of a given resource on service, stage or project level.
 func (rc *ResourceClient) GetResource(ctx context.Context, project string, stage string, service string, resourceURI string) (string, error) {
+	ctx, span := tracing.StartSpan(ctx, "get Keptn resource",
+		attribute.String("keptn.resource.uri", resourceURI),
+		attribute.String("keptn.project", project),
+		attribute.String("keptn.stage", stage),
+		attribute.String("keptn.service", service))
+	defer span.End()
+
 	var rnfErrorType *ResourceNotFoundError
 	if project != "" && stage != "" && service != "" {
 		keptnResourceContent, err := rc.GetServiceResource(ctx, project, stage, service, resourceURI)
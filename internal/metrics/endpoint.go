@@ -0,0 +1,22 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	log "github.com/sirupsen/logrus"
+)
+
+// MetricsPath is the path of the endpoint exposing the metrics in Prometheus format.
+const MetricsPath = "/metrics"
+
+// RunEndpoint serves the metrics on the specified port. It blocks until the server fails.
+func RunEndpoint(port string) {
+	mux := http.NewServeMux()
+	mux.Handle(MetricsPath, promhttp.Handler())
+
+	err := http.ListenAndServe(":"+port, mux)
+	if err != nil {
+		log.WithError(err).Error("Metrics endpoint stopped")
+	}
+}
@@ -0,0 +1,158 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "dynatrace_service"
+
+// DynatraceAPIRateLimitWaitSeconds tracks the time requests to a Dynatrace tenant spent waiting for the client-side rate limiter.
+var DynatraceAPIRateLimitWaitSeconds = promauto.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "dynatrace_api_rate_limit_wait_seconds",
+		Help:      "Time Dynatrace API requests spent waiting for the client-side rate limiter.",
+		Buckets:   []float64{0.01, 0.05, 0.1, 0.5, 1, 5, 10, 30, 60},
+	},
+	[]string{"tenant"},
+)
+
+// DynatraceAPICacheRequestsTotal counts cache hits and misses of cacheable Dynatrace API requests.
+var DynatraceAPICacheRequestsTotal = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "dynatrace_api_cache_requests_total",
+		Help:      "Number of cacheable Dynatrace API requests by cache category and result (hit or miss).",
+	},
+	[]string{"category", "result"},
+)
+
+// EventsReceivedTotal counts the events received per Keptn event type.
+var EventsReceivedTotal = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "events_received_total",
+		Help:      "Number of events received by Keptn event type.",
+	},
+	[]string{"type"},
+)
+
+// EventsHandledTotal counts the events handled per Keptn event type and outcome.
+var EventsHandledTotal = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "events_handled_total",
+		Help:      "Number of events handled by Keptn event type and outcome.",
+	},
+	[]string{"type", "outcome"},
+)
+
+// EventHandlingDurationSeconds tracks the time taken to handle events per Keptn event type.
+var EventHandlingDurationSeconds = promauto.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "event_handling_duration_seconds",
+		Help:      "Time taken to handle events by Keptn event type.",
+		Buckets:   []float64{0.1, 0.5, 1, 5, 10, 30, 60, 120, 300},
+	},
+	[]string{"type"},
+)
+
+// InFlightWorkers tracks the number of worker goroutines currently processing.
+var InFlightWorkers = promauto.NewGauge(
+	prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "in_flight_workers",
+		Help:      "Number of worker goroutines currently processing events or synchronizing services.",
+	},
+)
+
+// QueuedEvents tracks the number of events waiting for a free worker.
+var QueuedEvents = promauto.NewGauge(
+	prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "queued_events",
+		Help:      "Number of events waiting for a free worker.",
+	},
+)
+
+// DynatraceAPIRequestsTotal counts the requests sent to the Dynatrace API per method, endpoint and status code.
+var DynatraceAPIRequestsTotal = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "dynatrace_api_requests_total",
+		Help:      "Number of Dynatrace API requests by method, endpoint and status code. Requests without a response have the status \"error\".",
+	},
+	[]string{"method", "endpoint", "status"},
+)
+
+// DynatraceAPIRequestDurationSeconds tracks the duration of requests sent to the Dynatrace API per method and endpoint.
+var DynatraceAPIRequestDurationSeconds = promauto.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "dynatrace_api_request_duration_seconds",
+		Help:      "Duration of Dynatrace API requests by method and endpoint.",
+		Buckets:   prometheus.DefBuckets,
+	},
+	[]string{"method", "endpoint"},
+)
+
+// SLIResultsTotal counts the retrieved SLI results per indicator result type.
+var SLIResultsTotal = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "sli_results_total",
+		Help:      "Number of retrieved SLI results by result (success, warning or fail).",
+	},
+	[]string{"result"},
+)
+
+// TimeframeDelayWaitSeconds tracks the time spent waiting for data of a timeframe to become available.
+var TimeframeDelayWaitSeconds = promauto.NewHistogram(
+	prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "timeframe_delay_wait_seconds",
+		Help:      "Time spent waiting before querying a timeframe so that the data is available.",
+		Buckets:   []float64{0, 1, 5, 15, 30, 60, 120, 240},
+	},
+)
+
+// OutboxEntriesTotal counts the events added to and removed from the outbox per result.
+var OutboxEntriesTotal = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "outbox_entries_total",
+		Help:      "Number of outbox entries by result (added, sent, expired or rejected).",
+	},
+	[]string{"result"},
+)
+
+// OutboxPendingEntries tracks the number of events in the outbox waiting to be sent to Dynatrace.
+var OutboxPendingEntries = promauto.NewGauge(
+	prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "outbox_pending_entries",
+		Help:      "Number of events in the outbox waiting to be sent to Dynatrace.",
+	},
+)
+
+// MonitoringDriftedEntities tracks the number of Dynatrace entities that differ from their desired configuration per Keptn project and entity type.
+var MonitoringDriftedEntities = promauto.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "monitoring_drifted_entities",
+		Help:      "Number of Dynatrace entities generated by configure-monitoring that differ from their desired configuration by Keptn project and entity type.",
+	},
+	[]string{"project", "type"},
+)
+
+// MonitoringDriftCorrectionsTotal counts the corrections of drifted Dynatrace entities per entity type and result.
+var MonitoringDriftCorrectionsTotal = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "monitoring_drift_corrections_total",
+		Help:      "Number of corrections of drifted Dynatrace entities by entity type and result (success or failure).",
+	},
+	[]string{"type", "result"},
+)
@@ -8,11 +8,13 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
+var keptnTaggingRuleNames = []string{"keptn_service", "keptn_stage", "keptn_project", "keptn_deployment"}
+
 type autoTagCreation struct {
-	client dynatrace.ClientInterface
+	client dynatrace.AutoTagsClientInterface
 }
 
-func newAutoTagCreation(client dynatrace.ClientInterface) *autoTagCreation {
+func newAutoTagCreation(client dynatrace.AutoTagsClientInterface) *autoTagCreation {
 	return &autoTagCreation{
 		client: client,
 	}
@@ -22,8 +24,7 @@ func newAutoTagCreation(client dynatrace.ClientInterface) *autoTagCreation {
 func (at *autoTagCreation) create(ctx context.Context) []configResult {
 	log.Info("Setting up auto-tagging rules in Dynatrace Tenant")
 
-	autoTagsClient := dynatrace.NewAutoTagClient(at.client)
-	existingDTRuleNames, err := autoTagsClient.GetAllTagNames(ctx)
+	existingDTRuleNames, err := at.client.GetAllTagNames(ctx)
 	if err != nil {
 		// Error occurred but continue
 		// TODO 2021-08-18: should this error just be ignored?
@@ -31,15 +32,31 @@ func (at *autoTagCreation) create(ctx context.Context) []configResult {
 	}
 
 	var taggingRulesResults []configResult
-	for _, ruleName := range []string{"keptn_service", "keptn_stage", "keptn_project", "keptn_deployment"} {
+	for _, ruleName := range keptnTaggingRuleNames {
 		taggingRulesResults = append(
 			taggingRulesResults,
-			createAutoTaggingRuleForRuleName(ctx, autoTagsClient, existingDTRuleNames, ruleName))
+			createAutoTaggingRuleForRuleName(ctx, at.client, existingDTRuleNames, ruleName))
 	}
 	return taggingRulesResults
 }
 
-func createAutoTaggingRuleForRuleName(ctx context.Context, client *dynatrace.AutoTagsClient, existingTagNames *dynatrace.TagNames, ruleName string) configResult {
+// diff returns the tagging rules that would be created.
+func (at *autoTagCreation) diff(ctx context.Context) *entityDiff {
+	existingDTRuleNames, err := at.client.GetAllTagNames(ctx)
+	if err != nil {
+		return &entityDiff{Error: err}
+	}
+
+	diff := &entityDiff{}
+	for _, ruleName := range keptnTaggingRuleNames {
+		if !existingDTRuleNames.Contains(ruleName) {
+			diff.Changes = append(diff.Changes, configChange{Name: ruleName, Action: changeActionCreate})
+		}
+	}
+	return diff
+}
+
+func createAutoTaggingRuleForRuleName(ctx context.Context, client dynatrace.AutoTagsClientInterface, existingTagNames *dynatrace.TagNames, ruleName string) configResult {
 	if !existingTagNames.Contains(ruleName) {
 		rule := createAutoTaggingRuleDTO(ruleName)
 
@@ -0,0 +1,272 @@
+package monitoring
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+)
+
+// CleanupPolicy defines how the Dynatrace entities of a deleted Keptn project are handled.
+type CleanupPolicy string
+
+const (
+	// CleanupPolicyNone leaves the Dynatrace entities of a deleted Keptn project untouched.
+	CleanupPolicyNone CleanupPolicy = "none"
+
+	// CleanupPolicyReport only reports the Dynatrace entities of a deleted Keptn project that would be deleted.
+	CleanupPolicyReport CleanupPolicy = "report"
+
+	// CleanupPolicyDelete deletes the Dynatrace entities of a deleted Keptn project.
+	CleanupPolicyDelete CleanupPolicy = "delete"
+)
+
+// CleanupReport contains the Dynatrace entities that were, or in case of a dry run would be, deleted by a cleanup.
+type CleanupReport struct {
+	project              string
+	stage                string
+	dryRun               bool
+	managementZones      *entityDiff
+	dashboard            *entityDiff
+	metricEvents         *entityDiff
+	slos                 *entityDiff
+	problemNotifications *entityDiff
+}
+
+// HasErrors returns whether any of the entities could not be retrieved or deleted.
+func (r *CleanupReport) HasErrors() bool {
+	for _, diff := range []*entityDiff{r.managementZones, r.dashboard, r.metricEvents, r.slos, r.problemNotifications} {
+		if diff != nil && diff.Error != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns a summary of the cleanup.
+func (r *CleanupReport) String() string {
+	scope := "project " + r.project
+	if r.stage != "" {
+		scope = "stage " + r.stage + " of " + scope
+	}
+
+	msg := "Dynatrace cleanup done for " + scope + ". The following changes have been performed:\n\n"
+	if r.dryRun {
+		msg = "Dynatrace cleanup dry run done for " + scope + ". No changes have been made.\nThe following changes would be performed:\n\n"
+	}
+
+	msg = msg + getEntityDiffMessage("Management Zones", r.managementZones)
+	msg = msg + getEntityDiffMessage("Problem Notification", r.problemNotifications)
+	msg = msg + getEntityDiffMessage("Metric Events", r.metricEvents)
+	msg = msg + getEntityDiffMessage("SLOs", r.slos)
+	msg = msg + getEntityDiffMessage("Dashboard", r.dashboard)
+	return msg
+}
+
+// Cleanup finds the Dynatrace entities generated when configuring the monitoring for the specified project, or only the specified stage if it is not empty, and deletes them unless dryRun is set.
+// Entities are identified by the naming conventions used when creating them. Tagging rules and the Keptn alerting profile are shared by all projects and are therefore never deleted.
+func Cleanup(ctx context.Context, dtClient dynatrace.ClientInterface, configClients *dynatrace.ConfigClients, project string, stage string, dryRun bool) *CleanupReport {
+	c := &cleanup{dtClient: dtClient, configClients: configClients, project: project, stage: stage, dryRun: dryRun}
+
+	report := &CleanupReport{
+		project:         project,
+		stage:           stage,
+		dryRun:          dryRun,
+		metricEvents:    c.cleanupMetricEvents(ctx),
+		slos:            c.cleanupSLOs(ctx),
+		managementZones: c.cleanupManagementZones(ctx),
+	}
+
+	// dashboards and problem notifications are not stage-specific
+	if stage == "" {
+		report.dashboard = c.cleanupDashboard(ctx)
+		report.problemNotifications = c.cleanupProblemNotifications(ctx)
+	}
+
+	return report
+}
+
+type cleanup struct {
+	dtClient      dynatrace.ClientInterface
+	configClients *dynatrace.ConfigClients
+	project       string
+	stage         string
+	dryRun        bool
+}
+
+// cleanupManagementZones deletes the management zone of the project and the management zones of its stages, or only the management zone of the stage.
+func (c *cleanup) cleanupManagementZones(ctx context.Context) *entityDiff {
+	client := c.configClients.ManagementZones
+	managementZones, err := client.GetAll(ctx)
+	if err != nil {
+		return &entityDiff{Error: err}
+	}
+
+	matchesManagementZone := func(name string) bool {
+		if c.stage != "" {
+			return name == GetManagementZoneNameForProjectAndStage(c.project, c.stage)
+		}
+		return name == GetManagementZoneNameForProject(c.project) || strings.HasPrefix(name, GetManagementZoneNameForProject(c.project)+" ")
+	}
+
+	diff := &entityDiff{}
+	for _, name := range managementZones.GetNames() {
+		if !matchesManagementZone(name) {
+			continue
+		}
+
+		managementZone, _ := managementZones.GetByName(name)
+		if err := c.delete(name, func() error { return client.Delete(ctx, managementZone.ID) }); err != nil {
+			diff.Error = err
+			break
+		}
+		diff.Changes = append(diff.Changes, configChange{Name: name, Action: changeActionDelete})
+	}
+	return diff
+}
+
+// cleanupDashboard deletes the dashboards of the project.
+func (c *cleanup) cleanupDashboard(ctx context.Context) *entityDiff {
+	client := dynatrace.NewDashboardsClient(c.dtClient)
+	dashboards, err := client.GetAll(ctx)
+	if err != nil {
+		return &entityDiff{Error: err}
+	}
+
+	diff := &entityDiff{}
+	for _, dashboardItem := range dashboards.Dashboards {
+		if dashboardItem.Name != getDashboardName(c.project) {
+			continue
+		}
+
+		id := dashboardItem.ID
+		name := dashboardItem.Name + " (" + id + ")"
+		if err := c.delete(name, func() error { return client.Delete(ctx, id) }); err != nil {
+			diff.Error = fmt.Errorf("could not delete dashboard %s: %w", id, err)
+			break
+		}
+		diff.Changes = append(diff.Changes, configChange{Name: name, Action: changeActionDelete})
+	}
+	return diff
+}
+
+// cleanupMetricEvents deletes the metric events of all services of the project or stage.
+func (c *cleanup) cleanupMetricEvents(ctx context.Context) *entityDiff {
+	client := dynatrace.NewMetricEventsClient(c.dtClient)
+	metricEventIDs, err := client.GetIDsByName(ctx)
+	if err != nil {
+		return &entityDiff{Error: err}
+	}
+
+	// metric events are named "<metric> (Keptn.<project>.<stage>.<service>)"
+	return c.deleteServiceEntities(metricEventIDs, func(id string) error { return client.DeleteByID(ctx, id) })
+}
+
+// cleanupSLOs deletes the SLOs of all services of the project or stage.
+func (c *cleanup) cleanupSLOs(ctx context.Context) *entityDiff {
+	client := dynatrace.NewSLODefinitionsClient(c.dtClient)
+	sloIDs, err := client.GetIDsByName(ctx)
+	if err != nil {
+		return &entityDiff{Error: err}
+	}
+
+	// SLOs are named "<sli> (Keptn.<project>.<stage>.<service>)"
+	return c.deleteServiceEntities(sloIDs, func(id string) error { return client.DeleteByID(ctx, id) })
+}
+
+// deleteServiceEntities deletes the entities named "<name> (Keptn.<project>.<stage>.<service>)" of the project or stage, in the order of their names.
+func (c *cleanup) deleteServiceEntities(idsByName map[string]string, deleteByID func(id string) error) *entityDiff {
+	nameInfix := " (Keptn." + c.project + "."
+	if c.stage != "" {
+		nameInfix = nameInfix + c.stage + "."
+	}
+
+	names := make([]string, 0, len(idsByName))
+	for name := range idsByName {
+		if strings.Contains(name, nameInfix) && strings.HasSuffix(name, ")") {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	diff := &entityDiff{}
+	for _, name := range names {
+		id := idsByName[name]
+		if err := c.delete(name, func() error { return deleteByID(id) }); err != nil {
+			diff.Error = err
+			break
+		}
+		diff.Changes = append(diff.Changes, configChange{Name: name, Action: changeActionDelete})
+	}
+	return diff
+}
+
+// cleanupProblemNotifications deletes the Keptn problem notifications forwarding problems to the project.
+func (c *cleanup) cleanupProblemNotifications(ctx context.Context) *entityDiff {
+	client := c.configClients.Notifications
+	ids, err := client.GetKeptnProblemNotificationIDs(ctx)
+	if err != nil {
+		return &entityDiff{Error: err}
+	}
+
+	diff := &entityDiff{}
+	for _, id := range ids {
+		notification, err := client.GetByID(ctx, id)
+		if err != nil {
+			diff.Error = err
+			break
+		}
+
+		project, err := notification.GetKeptnProject()
+		if err != nil {
+			log.WithError(err).WithField("name", notification.Name).Warn("Could not determine Keptn project of problem notification, skipping it")
+			continue
+		}
+
+		if project != c.project {
+			continue
+		}
+
+		name := notification.Name + " (" + id + ")"
+		if err := c.delete(name, func() error { return client.DeleteByID(ctx, id) }); err != nil {
+			diff.Error = err
+			break
+		}
+		diff.Changes = append(diff.Changes, configChange{Name: name, Action: changeActionDelete})
+	}
+	return diff
+}
+
+// delete calls deleteFunc unless this is a dry run.
+func (c *cleanup) delete(name string, deleteFunc func() error) error {
+	if c.dryRun {
+		return nil
+	}
+
+	err := deleteFunc()
+	if err != nil {
+		return err
+	}
+
+	log.WithFields(log.Fields{"project": c.project, "stage": c.stage, "name": name}).Info("Deleted Dynatrace entity")
+	return nil
+}
+
+// CleanupWithCredentials runs Cleanup for the Dynatrace tenant whose credentials are stored in the specified secret using the specified configuration API.
+func CleanupWithCredentials(ctx context.Context, credentialsSecretName string, configAPI string, project string, stage string, dryRun bool) (*CleanupReport, error) {
+	dtClient, err := createDynatraceClientFromSecret(ctx, credentialsSecretName)
+	if err != nil {
+		return nil, err
+	}
+
+	configClients, err := dynatrace.NewConfigClientsForAPI(ctx, dtClient, configAPI)
+	if err != nil {
+		return nil, err
+	}
+
+	return Cleanup(ctx, dtClient, configClients, project, stage, dryRun), nil
+}
@@ -0,0 +1,144 @@
+package monitoring
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+	"github.com/keptn-contrib/dynatrace-service/internal/test"
+)
+
+// deleteRecordingHandler records the URLs of DELETE requests and passes all other requests to the underlying handler
+type deleteRecordingHandler struct {
+	http.Handler
+	deletedURLs []string
+}
+
+func (h *deleteRecordingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		h.Handler.ServeHTTP(w, r)
+		return
+	}
+
+	h.deletedURLs = append(h.deletedURLs, r.URL.Path)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func createCleanupHandler(t *testing.T) *deleteRecordingHandler {
+	handler := test.NewFileBasedURLHandler(t)
+	handler.AddExact("/api/config/v1/managementZones", "./testdata/cleanup/management_zones.json")
+	handler.AddExact("/api/config/v1/dashboards", "./testdata/cleanup/dashboards.json")
+	handler.AddExact(testMetricEventsSettingsObjectsURL, "./testdata/cleanup/metric_events.json")
+	handler.AddExact("/api/v2/slo?evaluate=false&pageSize=500", "./testdata/cleanup/slos.json")
+	handler.AddExact("/api/config/v1/notifications", "./testdata/cleanup/notifications.json")
+	handler.AddExact("/api/config/v1/notifications/n1", "./testdata/cleanup/notification_n1.json")
+	handler.AddExact("/api/config/v1/notifications/n3", "./testdata/cleanup/notification_n3.json")
+	handler.AddExact("/api/config/v1/notifications/n4", "./testdata/cleanup/notification_n4.json")
+	return &deleteRecordingHandler{Handler: handler}
+}
+
+// TestCleanupProject tests that all entities of the project, but not those of other projects, are deleted.
+func TestCleanupProject(t *testing.T) {
+	handler := createCleanupHandler(t)
+	dtClient, teardown := createDynatraceClient(t, handler)
+	defer teardown()
+
+	report := Cleanup(context.Background(), dtClient, dynatrace.NewConfigClients(dtClient), "sockshop", "", false)
+	assert.False(t, report.HasErrors())
+
+	assert.Equal(t, &entityDiff{
+		Changes: []configChange{
+			{Name: "Keptn: sockshop", Action: changeActionDelete},
+			{Name: "Keptn: sockshop dev", Action: changeActionDelete},
+			{Name: "Keptn: sockshop production", Action: changeActionDelete},
+		},
+	}, report.managementZones)
+	assert.Equal(t, &entityDiff{
+		Changes: []configChange{
+			{Name: "response_time_p95 (Keptn.sockshop.dev.carts)", Action: changeActionDelete},
+			{Name: "response_time_p95 (Keptn.sockshop.production.carts)", Action: changeActionDelete},
+		},
+	}, report.metricEvents)
+	assert.Equal(t, &entityDiff{
+		Changes: []configChange{
+			{Name: "error_rate (Keptn.sockshop.dev.carts)", Action: changeActionDelete},
+			{Name: "error_rate (Keptn.sockshop.production.carts)", Action: changeActionDelete},
+		},
+	}, report.slos)
+	assert.Equal(t, &entityDiff{
+		Changes: []configChange{{Name: "sockshop@keptn: Digital Delivery & Operations Dashboard (d1)", Action: changeActionDelete}},
+	}, report.dashboard)
+	assert.Equal(t, &entityDiff{
+		Changes: []configChange{
+			{Name: "Keptn Problem Notification (n1)", Action: changeActionDelete},
+			{Name: "Keptn Problem Notification production (n3)", Action: changeActionDelete},
+		},
+	}, report.problemNotifications)
+
+	assert.Equal(t, []string{
+		"/api/v2/settings/objects/m1",
+		"/api/v2/settings/objects/m2",
+		"/api/v2/slo/s1",
+		"/api/v2/slo/s2",
+		"/api/config/v1/managementZones/1",
+		"/api/config/v1/managementZones/2",
+		"/api/config/v1/managementZones/3",
+		"/api/config/v1/dashboards/d1",
+		"/api/config/v1/notifications/n1",
+		"/api/config/v1/notifications/n3",
+	}, handler.deletedURLs)
+}
+
+// TestCleanupStage tests that only the stage-specific entities of the stage are deleted.
+func TestCleanupStage(t *testing.T) {
+	handler := createCleanupHandler(t)
+	dtClient, teardown := createDynatraceClient(t, handler)
+	defer teardown()
+
+	report := Cleanup(context.Background(), dtClient, dynatrace.NewConfigClients(dtClient), "sockshop", "dev", false)
+	assert.False(t, report.HasErrors())
+	assert.Nil(t, report.dashboard)
+	assert.Nil(t, report.problemNotifications)
+
+	assert.Equal(t, []string{
+		"/api/v2/settings/objects/m1",
+		"/api/v2/slo/s1",
+		"/api/config/v1/managementZones/2",
+	}, handler.deletedURLs)
+}
+
+// TestCleanupDryRun tests that a dry run reports the entities that would be deleted without deleting them.
+func TestCleanupDryRun(t *testing.T) {
+	handler := createCleanupHandler(t)
+	dtClient, teardown := createDynatraceClient(t, handler)
+	defer teardown()
+
+	report := Cleanup(context.Background(), dtClient, dynatrace.NewConfigClients(dtClient), "sockshop", "", true)
+	assert.False(t, report.HasErrors())
+	assert.Empty(t, handler.deletedURLs)
+
+	summary := report.String()
+	assert.Contains(t, summary, "Dynatrace cleanup dry run done for project sockshop. No changes have been made.")
+	assert.Contains(t, summary, "  - delete: Keptn: sockshop dev\n")
+	assert.Contains(t, summary, "  - delete: response_time_p95 (Keptn.sockshop.production.carts)\n")
+	assert.NotContains(t, summary, "sockshop-legacy")
+}
+
+// TestCleanupReportsErrors tests that entities that cannot be retrieved are reported as errors.
+func TestCleanupReportsErrors(t *testing.T) {
+	handler := test.NewFileBasedURLHandler(t)
+	handler.AddExact("/api/config/v1/managementZones", "./testdata/cleanup/management_zones.json")
+	handler.AddExactError(testMetricEventsSettingsObjectsURL, http.StatusInternalServerError, "./testdata/cleanup/metric_events.json")
+	handler.AddExact("/api/v2/slo?evaluate=false&pageSize=500", "./testdata/cleanup/slos.json")
+
+	dtClient, teardown := createDynatraceClient(t, &deleteRecordingHandler{Handler: handler})
+	defer teardown()
+
+	report := Cleanup(context.Background(), dtClient, dynatrace.NewConfigClients(dtClient), "sockshop", "dev", true)
+	assert.True(t, report.HasErrors())
+	assert.Error(t, report.metricEvents.Error)
+	assert.Contains(t, report.String(), "---Metric Events:--- \n  - Error: ")
+}
@@ -5,6 +5,7 @@ import (
 
 	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
 
+	"github.com/keptn-contrib/dynatrace-service/internal/config"
 	"github.com/keptn-contrib/dynatrace-service/internal/credentials"
 	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
 	"github.com/keptn-contrib/dynatrace-service/internal/env"
@@ -14,10 +15,11 @@ import (
 // configuredEntities contains information about the entities configures in Dynatrace
 type configuredEntities struct {
 	TaggingRules         []configResult
-	ProblemNotifications *configResult
+	ProblemNotifications []configResult
 	ManagementZones      []configResult
 	Dashboard            *configResult
 	MetricEvents         []configResult
+	SLOs                 []configResult
 }
 
 type configResult struct {
@@ -28,19 +30,25 @@ type configResult struct {
 
 type configuration struct {
 	dtClient                 dynatrace.ClientInterface
+	configClients            *dynatrace.ConfigClients
 	eventSenderClient        keptn.EventSenderClientInterface
 	serviceClient            keptn.ServiceClientInterface
 	keptnCredentialsProvider credentials.KeptnCredentialsProvider
 	sliAndSLOReader          keptn.SLIAndSLOReaderInterface
+	dashboardTemplateReader  keptn.DashboardTemplateReaderInterface
+	monitoringConfig         *config.MonitoringConfig
 }
 
-func newConfiguration(dynatraceClient dynatrace.ClientInterface, eventSenderClient keptn.EventSenderClientInterface, serviceClient keptn.ServiceClientInterface, keptnCredentialsProvider credentials.KeptnCredentialsProvider, sliAndSLOReader keptn.SLIAndSLOReaderInterface) *configuration {
+func newConfiguration(dynatraceClient dynatrace.ClientInterface, configClients *dynatrace.ConfigClients, eventSenderClient keptn.EventSenderClientInterface, serviceClient keptn.ServiceClientInterface, keptnCredentialsProvider credentials.KeptnCredentialsProvider, sliAndSLOReader keptn.SLIAndSLOReaderInterface, dashboardTemplateReader keptn.DashboardTemplateReaderInterface, monitoringConfig *config.MonitoringConfig) *configuration {
 	return &configuration{
 		dtClient:                 dynatraceClient,
+		configClients:            configClients,
 		eventSenderClient:        eventSenderClient,
 		serviceClient:            serviceClient,
 		keptnCredentialsProvider: keptnCredentialsProvider,
 		sliAndSLOReader:          sliAndSLOReader,
+		dashboardTemplateReader:  dashboardTemplateReader,
+		monitoringConfig:         monitoringConfig,
 	}
 }
 
@@ -49,30 +57,43 @@ func (mc *configuration) configureMonitoring(ctx context.Context, project string
 
 	configuredEntities := &configuredEntities{}
 
-	if env.IsTaggingRulesGenerationEnabled() {
-		configuredEntities.TaggingRules = newAutoTagCreation(mc.dtClient).create(ctx)
+	if mc.monitoringConfig.GetTaggingRules().IsEnabled(env.IsTaggingRulesGenerationEnabled()) {
+		configuredEntities.TaggingRules = newAutoTagCreation(mc.configClients.AutoTags).create(ctx)
 	}
 
-	if env.IsProblemNotificationsGenerationEnabled() {
-		configuredEntities.ProblemNotifications = newProblemNotificationCreation(mc.dtClient, mc.keptnCredentialsProvider).create(ctx, project)
+	problemNotificationsConfig := mc.monitoringConfig.GetProblemNotifications()
+	if problemNotificationsConfig.IsEnabled(env.IsProblemNotificationsGenerationEnabled()) {
+		configuredEntities.ProblemNotifications = newProblemNotificationCreation(mc.configClients.AlertingProfiles, mc.configClients.Notifications, mc.keptnCredentialsProvider, problemNotificationsConfig).create(ctx, project)
 	}
 
-	if env.IsManagementZonesGenerationEnabled() {
-		configuredEntities.ManagementZones = newManagementZoneCreation(mc.dtClient).create(ctx, project, shipyard)
+	managementZonesConfig := mc.monitoringConfig.GetManagementZones()
+	if managementZonesConfig.IsEnabled(env.IsManagementZonesGenerationEnabled()) {
+		configuredEntities.ManagementZones = newManagementZoneCreation(mc.configClients.ManagementZones).create(ctx, project, filterShipyardStages(shipyard, managementZonesConfig))
 	}
 
-	if env.IsDashboardsGenerationEnabled() {
-		configuredEntities.Dashboard = newDashboardCreation(mc.dtClient).create(ctx, project, shipyard)
+	dashboardsConfig := mc.monitoringConfig.GetDashboards()
+	if dashboardsConfig.IsEnabled(env.IsDashboardsGenerationEnabled()) {
+		configuredEntities.Dashboard = newDashboardCreation(mc.dtClient, mc.dashboardTemplateReader, mc.serviceClient).create(ctx, project, filterShipyardStages(shipyard, dashboardsConfig))
 	}
 
-	if env.IsMetricEventsGenerationEnabled() {
+	metricEventsConfig := mc.monitoringConfig.GetMetricEvents()
+	if metricEventsConfig.IsEnabled(env.IsMetricEventsGenerationEnabled()) {
 		var metricEvents []configResult
-		for _, stage := range shipyard.Spec.Stages {
+		for _, stage := range filterShipyardStages(shipyard, metricEventsConfig).Spec.Stages {
 			metricEvents = append(metricEvents, mc.createMetricEventsForStage(ctx, project, stage)...)
 		}
 		configuredEntities.MetricEvents = metricEvents
 	}
 
+	slosConfig := mc.monitoringConfig.GetSLOs()
+	if slosConfig.IsEnabled(env.IsSLOsGenerationEnabled()) {
+		var slos []configResult
+		for _, stage := range filterShipyardStages(shipyard, slosConfig).Spec.Stages {
+			slos = append(slos, mc.createSLOsForStage(ctx, project, stage)...)
+		}
+		configuredEntities.SLOs = slos
+	}
+
 	return configuredEntities, nil
 }
 
@@ -98,6 +119,35 @@ func (mc *configuration) createMetricEventsForStage(ctx context.Context, project
 	return metricEvents
 }
 
+func (mc *configuration) createSLOsForStage(ctx context.Context, project string, stage keptnv2.Stage) []configResult {
+	serviceNames, err := mc.serviceClient.GetServiceNames(ctx, project, stage.Name)
+	if err != nil {
+		return []configResult{{
+			Success: false,
+			Message: err.Error(),
+		}}
+	}
+
+	var slos []configResult
+	for _, serviceName := range serviceNames {
+		slos = append(slos, newSLOCreation(mc.dtClient, mc.sliAndSLOReader).create(ctx, project, stage.Name, serviceName)...)
+	}
+	return slos
+}
+
+// filterShipyardStages returns a copy of the shipyard only containing the stages included by the entity configuration
+func filterShipyardStages(shipyard keptnv2.Shipyard, entityConfig *config.MonitoringEntityConfig) keptnv2.Shipyard {
+	var stages []keptnv2.Stage
+	for _, stage := range shipyard.Spec.Stages {
+		if entityConfig.IncludesStage(stage.Name) {
+			stages = append(stages, stage)
+		}
+	}
+
+	shipyard.Spec.Stages = stages
+	return shipyard
+}
+
 func isStageMissingRemediationSequence(stage keptnv2.Stage) bool {
 	for _, taskSequence := range stage.Sequences {
 		if taskSequence.Name == "remediation" {
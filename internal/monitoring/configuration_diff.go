@@ -0,0 +1,182 @@
+package monitoring
+
+import (
+	"context"
+	"fmt"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/env"
+)
+
+// changeAction is the kind of change configuring the monitoring would perform on a Dynatrace entity
+type changeAction string
+
+const (
+	changeActionCreate changeAction = "create"
+	changeActionUpdate changeAction = "update"
+	changeActionDelete changeAction = "delete"
+
+	// changeActionUnsupported marks an entity that would not be created as its configuration is not supported, explained by the message of the change
+	changeActionUnsupported changeAction = "unsupported"
+)
+
+// configChange describes a single change to a Dynatrace entity. Message optionally explains the change.
+type configChange struct {
+	Name    string
+	Action  changeAction
+	Message string
+}
+
+// entityDiff contains the changes for a type of Dynatrace entity. Error is set if the current state could not be retrieved completely.
+type entityDiff struct {
+	Changes []configChange
+	Error   error
+}
+
+// configurationDiff contains the changes configuring the monitoring would perform. Entity types that are not enabled are nil.
+type configurationDiff struct {
+	TaggingRules         *entityDiff
+	ProblemNotifications *entityDiff
+	ManagementZones      *entityDiff
+	Dashboard            *entityDiff
+	MetricEvents         *entityDiff
+	SLOs                 *entityDiff
+}
+
+// diffMonitoring determines the changes configuring the monitoring for a Keptn project would perform without making any changes
+func (mc *configuration) diffMonitoring(ctx context.Context, project string, shipyard keptnv2.Shipyard) *configurationDiff {
+	diff := &configurationDiff{}
+
+	if mc.monitoringConfig.GetTaggingRules().IsEnabled(env.IsTaggingRulesGenerationEnabled()) {
+		diff.TaggingRules = newAutoTagCreation(mc.configClients.AutoTags).diff(ctx)
+	}
+
+	problemNotificationsConfig := mc.monitoringConfig.GetProblemNotifications()
+	if problemNotificationsConfig.IsEnabled(env.IsProblemNotificationsGenerationEnabled()) {
+		diff.ProblemNotifications = newProblemNotificationCreation(mc.configClients.AlertingProfiles, mc.configClients.Notifications, mc.keptnCredentialsProvider, problemNotificationsConfig).diff(ctx, project)
+	}
+
+	// management zones created in the same run are available for the metric events
+	plannedManagementZones := map[string]bool{}
+	managementZonesConfig := mc.monitoringConfig.GetManagementZones()
+	if managementZonesConfig.IsEnabled(env.IsManagementZonesGenerationEnabled()) {
+		diff.ManagementZones = newManagementZoneCreation(mc.configClients.ManagementZones).diff(ctx, project, filterShipyardStages(shipyard, managementZonesConfig))
+		for _, change := range diff.ManagementZones.Changes {
+			plannedManagementZones[change.Name] = true
+		}
+	}
+
+	dashboardsConfig := mc.monitoringConfig.GetDashboards()
+	if dashboardsConfig.IsEnabled(env.IsDashboardsGenerationEnabled()) {
+		diff.Dashboard = newDashboardCreation(mc.dtClient, mc.dashboardTemplateReader, mc.serviceClient).diff(ctx, project, filterShipyardStages(shipyard, dashboardsConfig))
+	}
+
+	metricEventsConfig := mc.monitoringConfig.GetMetricEvents()
+	if metricEventsConfig.IsEnabled(env.IsMetricEventsGenerationEnabled()) {
+		diff.MetricEvents = &entityDiff{}
+		for _, stage := range filterShipyardStages(shipyard, metricEventsConfig).Spec.Stages {
+			changes, err := mc.diffMetricEventsForStage(ctx, project, stage, plannedManagementZones)
+			diff.MetricEvents.Changes = append(diff.MetricEvents.Changes, changes...)
+			if err != nil {
+				diff.MetricEvents.Error = err
+				break
+			}
+		}
+	}
+
+	slosConfig := mc.monitoringConfig.GetSLOs()
+	if slosConfig.IsEnabled(env.IsSLOsGenerationEnabled()) {
+		diff.SLOs = &entityDiff{}
+		for _, stage := range filterShipyardStages(shipyard, slosConfig).Spec.Stages {
+			changes, err := mc.diffSLOsForStage(ctx, project, stage)
+			diff.SLOs.Changes = append(diff.SLOs.Changes, changes...)
+			if err != nil {
+				diff.SLOs.Error = err
+				break
+			}
+		}
+	}
+
+	return diff
+}
+
+func (mc *configuration) diffMetricEventsForStage(ctx context.Context, project string, stage keptnv2.Stage, plannedManagementZones map[string]bool) ([]configChange, error) {
+	if isStageMissingRemediationSequence(stage) {
+		return nil, nil
+	}
+
+	serviceNames, err := mc.serviceClient.GetServiceNames(ctx, project, stage.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []configChange
+	for _, serviceName := range serviceNames {
+		serviceChanges, err := newMetricEventCreation(mc.dtClient, mc.eventSenderClient, mc.sliAndSLOReader).diff(ctx, project, stage.Name, serviceName, plannedManagementZones)
+		changes = append(changes, serviceChanges...)
+		if err != nil {
+			return changes, err
+		}
+	}
+	return changes, nil
+}
+
+func (mc *configuration) diffSLOsForStage(ctx context.Context, project string, stage keptnv2.Stage) ([]configChange, error) {
+	serviceNames, err := mc.serviceClient.GetServiceNames(ctx, project, stage.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []configChange
+	for _, serviceName := range serviceNames {
+		serviceChanges, err := newSLOCreation(mc.dtClient, mc.sliAndSLOReader).diff(ctx, project, stage.Name, serviceName)
+		changes = append(changes, serviceChanges...)
+		if err != nil {
+			return changes, err
+		}
+	}
+	return changes, nil
+}
+
+func getConfigureMonitoringDryRunMessage(keptnCredentialsCheckResult keptnCredentialsCheckResult, diff *configurationDiff) string {
+	if diff == nil {
+		return ""
+	}
+	msg := "Dynatrace monitoring dry run done. No changes have been made.\nThe following changes would be performed:\n\n"
+
+	msg = msg + getEntityDiffMessage("Management Zones", diff.ManagementZones)
+	msg = msg + getEntityDiffMessage("Automatic Tagging Rules", diff.TaggingRules)
+	msg = msg + getEntityDiffMessage("Problem Notification", diff.ProblemNotifications)
+	msg = msg + getEntityDiffMessage("Metric Events", diff.MetricEvents)
+	msg = msg + getEntityDiffMessage("SLOs", diff.SLOs)
+	msg = msg + getEntityDiffMessage("Dashboard", diff.Dashboard)
+
+	msg = msg + "---Keptn API Connection Check:--- \n"
+	msg = msg + "  - Keptn API URL: " + keptnCredentialsCheckResult.APIURL + "\n"
+	msg = msg + fmt.Sprintf("  - Connection Successful: %v. %s\n", keptnCredentialsCheckResult.Success, keptnCredentialsCheckResult.Message)
+	msg = msg + "\n"
+
+	return msg
+}
+
+func getEntityDiffMessage(title string, diff *entityDiff) string {
+	if diff == nil {
+		return ""
+	}
+
+	msg := "---" + title + ":--- \n"
+	for _, change := range diff.Changes {
+		msg = msg + "  - " + string(change.Action) + ": " + change.Name
+		if change.Message != "" {
+			msg = msg + " (" + change.Message + ")"
+		}
+		msg = msg + "\n"
+	}
+	if diff.Error != nil {
+		msg = msg + "  - Error: " + diff.Error.Error() + "\n"
+	} else if len(diff.Changes) == 0 {
+		msg = msg + "  - No changes\n"
+	}
+	return msg + "\n\n"
+}
@@ -0,0 +1,97 @@
+package monitoring
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/config"
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+	"github.com/keptn-contrib/dynatrace-service/internal/test"
+)
+
+// TestDiffMonitoring tests that the changes are derived from the current state of the tenant only using GET requests.
+func TestDiffMonitoring(t *testing.T) {
+	handler := test.NewFileBasedURLHandler(t)
+	handler.AddExact("/api/config/v1/autoTags", "./testdata/diff/auto_tags.json")
+	handler.AddExact("/api/config/v1/alertingProfiles", "./testdata/diff/alerting_profiles.json")
+	handler.AddExact("/api/config/v1/notifications", "./testdata/diff/notifications.json")
+	handler.AddExact("/api/config/v1/managementZones", "./testdata/diff/management_zones.json")
+	handler.AddExact("/api/config/v1/dashboards", "./testdata/diff/dashboards.json")
+
+	dtClient, teardown := createDynatraceClient(t, handler)
+	defer teardown()
+
+	enabled := true
+	disabled := false
+	monitoringConfig := &config.MonitoringConfig{
+		TaggingRules:         &config.MonitoringEntityConfig{Enabled: &enabled},
+		ProblemNotifications: &config.ProblemNotificationsConfig{Enabled: &enabled},
+		ManagementZones:      &config.MonitoringEntityConfig{Enabled: &enabled},
+		Dashboards:           &config.MonitoringEntityConfig{Enabled: &enabled},
+		MetricEvents:         &config.MonitoringEntityConfig{Enabled: &disabled},
+	}
+
+	shipyard := keptnv2.Shipyard{
+		Spec: keptnv2.ShipyardSpec{
+			Stages: []keptnv2.Stage{{Name: "dev"}, {Name: "production"}},
+		},
+	}
+
+	diff := newConfiguration(dtClient, dynatrace.NewConfigClients(dtClient), nil, nil, nil, nil, nil, monitoringConfig).diffMonitoring(context.Background(), "sockshop", shipyard)
+
+	assert.Equal(t, &configurationDiff{
+		TaggingRules: &entityDiff{
+			Changes: []configChange{
+				{Name: "keptn_project", Action: changeActionCreate},
+				{Name: "keptn_deployment", Action: changeActionCreate},
+			},
+		},
+		ProblemNotifications: &entityDiff{
+			Changes: []configChange{
+				{Name: "Alerting profile Keptn", Action: changeActionCreate},
+				{Name: "Keptn Problem Notification", Action: changeActionUpdate},
+			},
+		},
+		ManagementZones: &entityDiff{
+			Changes: []configChange{
+				{Name: "Keptn: sockshop production", Action: changeActionCreate},
+			},
+		},
+		Dashboard: &entityDiff{
+			Changes: []configChange{
+				{Name: "sockshop@keptn: Digital Delivery & Operations Dashboard (d1)", Action: changeActionDelete},
+				{Name: "sockshop@keptn: Digital Delivery & Operations Dashboard", Action: changeActionCreate},
+			},
+		},
+	}, diff)
+}
+
+func TestGetConfigureMonitoringDryRunMessage(t *testing.T) {
+	diff := &configurationDiff{
+		ManagementZones: &entityDiff{
+			Changes: []configChange{
+				{Name: "Keptn: sockshop production", Action: changeActionCreate},
+			},
+		},
+		Dashboard: &entityDiff{},
+		MetricEvents: &entityDiff{
+			Changes: []configChange{
+				{Name: "response_time_p95 (Keptn.sockshop.production.carts)", Action: changeActionUpdate},
+			},
+			Error: errors.New("could not retrieve metric events"),
+		},
+	}
+
+	message := getConfigureMonitoringDryRunMessage(keptnCredentialsCheckResult{APIURL: "http://keptn", Success: true}, diff)
+
+	assert.Equal(t, "Dynatrace monitoring dry run done. No changes have been made.\nThe following changes would be performed:\n\n"+
+		"---Management Zones:--- \n  - create: Keptn: sockshop production\n\n\n"+
+		"---Metric Events:--- \n  - update: response_time_p95 (Keptn.sockshop.production.carts)\n  - Error: could not retrieve metric events\n\n\n"+
+		"---Dashboard:--- \n  - No changes\n\n\n"+
+		"---Keptn API Connection Check:--- \n  - Keptn API URL: http://keptn\n  - Connection Successful: true. \n\n",
+		message)
+}
@@ -0,0 +1,53 @@
+package monitoring
+
+import (
+	"testing"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/config"
+)
+
+func TestFilterShipyardStages(t *testing.T) {
+	shipyard := keptnv2.Shipyard{
+		Spec: keptnv2.ShipyardSpec{
+			Stages: []keptnv2.Stage{{Name: "dev"}, {Name: "staging"}, {Name: "production"}},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		entityConfig   *config.MonitoringEntityConfig
+		expectedStages []string
+	}{
+		{
+			name:           "no config includes all stages",
+			entityConfig:   nil,
+			expectedStages: []string{"dev", "staging", "production"},
+		},
+		{
+			name:           "no stages includes all stages",
+			entityConfig:   &config.MonitoringEntityConfig{},
+			expectedStages: []string{"dev", "staging", "production"},
+		},
+		{
+			name:           "only configured stages are included",
+			entityConfig:   &config.MonitoringEntityConfig{Stages: []string{"production", "unknown"}},
+			expectedStages: []string{"production"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filteredShipyard := filterShipyardStages(shipyard, tt.entityConfig)
+
+			var stages []string
+			for _, stage := range filteredShipyard.Spec.Stages {
+				stages = append(stages, stage.Name)
+			}
+			assert.Equal(t, tt.expectedStages, stages)
+			assert.Len(t, shipyard.Spec.Stages, 3)
+		})
+	}
+}
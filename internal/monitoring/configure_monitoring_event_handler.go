@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/keptn-contrib/dynatrace-service/internal/adapter"
+	"github.com/keptn-contrib/dynatrace-service/internal/config"
 	"github.com/keptn-contrib/dynatrace-service/internal/credentials"
 	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
 	"github.com/keptn-contrib/dynatrace-service/internal/keptn"
@@ -12,6 +13,9 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
+// dryRunLabel is the label of a configure monitoring event requesting a dry run
+const dryRunLabel = "dryRun"
+
 type keptnCredentialsCheckResult struct {
 	APIURL  string
 	Success bool
@@ -21,25 +25,31 @@ type keptnCredentialsCheckResult struct {
 type ConfigureMonitoringEventHandler struct {
 	event                    ConfigureMonitoringAdapterInterface
 	dtClient                 dynatrace.ClientInterface
+	configClients            *dynatrace.ConfigClients
 	eventSenderClient        keptn.EventSenderClientInterface
 	shipyardReader           keptn.ShipyardReaderInterface
 	sliAndSLOReader          keptn.SLIAndSLOReaderInterface
+	dashboardTemplateReader  keptn.DashboardTemplateReaderInterface
 	serviceClient            keptn.ServiceClientInterface
 	keptnCredentialsProvider credentials.KeptnCredentialsProvider
 	credentialsChecker       keptn.CredentialsCheckerInterface
+	monitoringConfig         *config.MonitoringConfig
 }
 
 // NewConfigureMonitoringEventHandler returns a new ConfigureMonitoringEventHandler
-func NewConfigureMonitoringEventHandler(event ConfigureMonitoringAdapterInterface, dtClient dynatrace.ClientInterface, eventSenderClient keptn.EventSenderClientInterface, shipyardReader keptn.ShipyardReaderInterface, sliAndSLOReader keptn.SLIAndSLOReaderInterface, serviceClient keptn.ServiceClientInterface, keptnCredentialsProvider credentials.KeptnCredentialsProvider, credentialsChecker keptn.CredentialsCheckerInterface) ConfigureMonitoringEventHandler {
+func NewConfigureMonitoringEventHandler(event ConfigureMonitoringAdapterInterface, dtClient dynatrace.ClientInterface, configClients *dynatrace.ConfigClients, eventSenderClient keptn.EventSenderClientInterface, shipyardReader keptn.ShipyardReaderInterface, sliAndSLOReader keptn.SLIAndSLOReaderInterface, dashboardTemplateReader keptn.DashboardTemplateReaderInterface, serviceClient keptn.ServiceClientInterface, keptnCredentialsProvider credentials.KeptnCredentialsProvider, credentialsChecker keptn.CredentialsCheckerInterface, monitoringConfig *config.MonitoringConfig) ConfigureMonitoringEventHandler {
 	return ConfigureMonitoringEventHandler{
 		event:                    event,
 		dtClient:                 dtClient,
+		configClients:            configClients,
 		eventSenderClient:        eventSenderClient,
 		shipyardReader:           shipyardReader,
 		sliAndSLOReader:          sliAndSLOReader,
+		dashboardTemplateReader:  dashboardTemplateReader,
 		serviceClient:            serviceClient,
 		keptnCredentialsProvider: keptnCredentialsProvider,
 		credentialsChecker:       credentialsChecker,
+		monitoringConfig:         monitoringConfig,
 	}
 }
 
@@ -66,7 +76,13 @@ func (eh *ConfigureMonitoringEventHandler) configureMonitoring(ctx context.Conte
 		return eh.handleError(err)
 	}
 
-	cfg := newConfiguration(eh.dtClient, eh.eventSenderClient, eh.serviceClient, eh.keptnCredentialsProvider, eh.sliAndSLOReader)
+	cfg := newConfiguration(eh.dtClient, eh.configClients, eh.eventSenderClient, eh.serviceClient, eh.keptnCredentialsProvider, eh.sliAndSLOReader, eh.dashboardTemplateReader, eh.monitoringConfig)
+
+	if eh.isDryRun() {
+		diff := cfg.diffMonitoring(ctx, eh.event.GetProject(), *shipyard)
+		log.Info("Dynatrace Monitoring dry run done")
+		return eh.handleSuccess(getConfigureMonitoringDryRunMessage(keptnCredentialsCheckResult, diff))
+	}
 
 	configuredEntities, err := cfg.configureMonitoring(ctx, eh.event.GetProject(), *shipyard)
 	if err != nil {
@@ -77,6 +93,11 @@ func (eh *ConfigureMonitoringEventHandler) configureMonitoring(ctx context.Conte
 	return eh.handleSuccess(getConfigureMonitoringResultMessage(keptnCredentialsCheckResult, configuredEntities))
 }
 
+// isDryRun returns whether a dry run was requested by the event label or the monitoring configuration.
+func (eh *ConfigureMonitoringEventHandler) isDryRun() bool {
+	return eh.event.GetLabels()[dryRunLabel] == "true" || eh.monitoringConfig.IsDryRun()
+}
+
 func (eh *ConfigureMonitoringEventHandler) checkKeptnCredentials(ctx context.Context) keptnCredentialsCheckResult {
 	keptnCredentials, err := eh.keptnCredentialsProvider.GetKeptnCredentials(ctx)
 	if err != nil {
@@ -133,9 +154,15 @@ func getConfigureMonitoringResultMessage(keptnCredentialsCheckResult keptnCreden
 		msg = msg + "\n\n"
 	}
 
-	if entities.ProblemNotifications != nil {
-		msg = msg + "---Problem Notification:--- \n"
-		msg = msg + "  - " + entities.ProblemNotifications.Message
+	if len(entities.ProblemNotifications) > 0 {
+		msg = msg + "---Problem Notifications:--- \n"
+		for _, notification := range entities.ProblemNotifications {
+			if notification.Success {
+				msg = msg + "  - " + notification.Name + ": " + notification.Message + "\n"
+			} else {
+				msg = msg + "  - " + notification.Name + ": Error: " + notification.Message + "\n"
+			}
+		}
 		msg = msg + "\n\n"
 	}
 
@@ -151,6 +178,18 @@ func getConfigureMonitoringResultMessage(keptnCredentialsCheckResult keptnCreden
 		msg = msg + "\n\n"
 	}
 
+	if len(entities.SLOs) > 0 {
+		msg = msg + "---SLOs:--- \n"
+		for _, slo := range entities.SLOs {
+			if slo.Success {
+				msg = msg + "  - " + slo.Name + ": Created successfully \n"
+			} else {
+				msg = msg + "  - " + slo.Name + ": Error: " + slo.Message + "\n"
+			}
+		}
+		msg = msg + "\n\n"
+	}
+
 	if entities.Dashboard != nil {
 		msg = msg + "---Dashboard:--- \n"
 		msg = msg + "  - " + entities.Dashboard.Message
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+	"github.com/keptn-contrib/dynatrace-service/internal/keptn"
 	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
 	log "github.com/sirupsen/logrus"
 )
@@ -17,20 +18,35 @@ const timeSeriesChartType = "TIMESERIES"
 const dashboardStageWidth int = 456
 
 type dashboardCreation struct {
-	client dynatrace.ClientInterface
+	client         dynatrace.ClientInterface
+	templateReader keptn.DashboardTemplateReaderInterface
+	serviceClient  keptn.ServiceClientInterface
 }
 
-func newDashboardCreation(client dynatrace.ClientInterface) *dashboardCreation {
+// newDashboardCreation creates a new dashboardCreation. If templateReader is nil, the built-in dashboard layout is always used.
+func newDashboardCreation(client dynatrace.ClientInterface, templateReader keptn.DashboardTemplateReaderInterface, serviceClient keptn.ServiceClientInterface) *dashboardCreation {
 	return &dashboardCreation{
-		client: client,
+		client:         client,
+		templateReader: templateReader,
+		serviceClient:  serviceClient,
 	}
 }
 
 // create creates a new dashboard for the provided project.
 func (dc *dashboardCreation) create(ctx context.Context, project string, shipyard keptnv2.Shipyard) *configResult {
+	// render the dashboard first, so that an invalid template does not remove the existing dashboard
+	dashboardPayload, err := dc.getDashboardPayload(ctx, project, shipyard)
+	if err != nil {
+		log.WithError(err).Error("Could not create dashboard")
+		return &configResult{
+			Success: false,
+			Message: "Could not create dashboard: " + err.Error(),
+		}
+	}
+
 	// first, check if dashboard for this project already exists and delete that
 	dashboardClient := dynatrace.NewDashboardsClient(dc.client)
-	err := deleteExistingDashboard(ctx, project, dashboardClient)
+	err = deleteExistingDashboard(ctx, project, dashboardClient)
 	if err != nil {
 		log.WithError(err).Error("Could not delete existing dashboard")
 		return &configResult{
@@ -40,8 +56,7 @@ func (dc *dashboardCreation) create(ctx context.Context, project string, shipyar
 	}
 
 	log.WithField("project", project).Info("Creating Dashboard for project")
-	dashboard := createDynatraceDashboard(project, shipyard)
-	err = dashboardClient.Create(ctx, dashboard)
+	err = dashboardClient.CreateFromJSON(ctx, dashboardPayload)
 	if err != nil {
 		log.WithError(err).Error("Failed to create Dynatrace dashboards")
 		return &configResult{
@@ -56,6 +71,28 @@ func (dc *dashboardCreation) create(ctx context.Context, project string, shipyar
 	}
 }
 
+// diff returns the changes to the dashboard for the provided project that would be performed. As existing dashboards are replaced, they are deleted and created again.
+func (dc *dashboardCreation) diff(ctx context.Context, project string, shipyard keptnv2.Shipyard) *entityDiff {
+	_, err := dc.getDashboardPayload(ctx, project, shipyard)
+	if err != nil {
+		return &entityDiff{Error: err}
+	}
+
+	response, err := dynatrace.NewDashboardsClient(dc.client).GetAll(ctx)
+	if err != nil {
+		return &entityDiff{Error: err}
+	}
+
+	diff := &entityDiff{}
+	for _, dashboardItem := range response.Dashboards {
+		if dashboardItem.Name == getDashboardName(project) {
+			diff.Changes = append(diff.Changes, configChange{Name: dashboardItem.Name + " (" + dashboardItem.ID + ")", Action: changeActionDelete})
+		}
+	}
+	diff.Changes = append(diff.Changes, configChange{Name: getDashboardName(project), Action: changeActionCreate})
+	return diff
+}
+
 // deleteExistingDashboard deletes an existing dashboard for the provided project
 func deleteExistingDashboard(ctx context.Context, project string, dashboardClient *dynatrace.DashboardsClient) error {
 	response, err := dashboardClient.GetAll(ctx)
@@ -0,0 +1,163 @@
+package monitoring
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"text/template"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/common"
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+	"github.com/keptn-contrib/dynatrace-service/internal/keptn"
+)
+
+// dashboardTemplateData is the data available in a dashboard template
+type dashboardTemplateData struct {
+	Project        string
+	ManagementZone dashboardTemplateManagementZone
+	Stages         []dashboardTemplateStage
+	Services       []string
+}
+
+// dashboardTemplateStage describes a stage of the project in a dashboard template
+type dashboardTemplateStage struct {
+	Name           string
+	ManagementZone dashboardTemplateManagementZone
+	Services       []string
+}
+
+// dashboardTemplateManagementZone describes a management zone in a dashboard template. The ID is empty if the management zone does not exist.
+type dashboardTemplateManagementZone struct {
+	ID   string
+	Name string
+}
+
+// getDashboardPayload returns the JSON representation of the dashboard for the provided project.
+// If a dashboard template is stored for the project, it is rendered, otherwise the built-in layout is used.
+func (dc *dashboardCreation) getDashboardPayload(ctx context.Context, project string, shipyard keptnv2.Shipyard) ([]byte, error) {
+	dashboardTemplate, err := dc.getDashboardTemplate(ctx, project)
+	if err != nil {
+		return nil, err
+	}
+
+	if dashboardTemplate == "" {
+		dashboardPayload, err := json.Marshal(createDynatraceDashboard(project, shipyard))
+		if err != nil {
+			return nil, common.NewMarshalJSONError("Dynatrace dashboard", err)
+		}
+		return dashboardPayload, nil
+	}
+
+	data, err := dc.getDashboardTemplateData(ctx, project, shipyard)
+	if err != nil {
+		return nil, err
+	}
+
+	return renderDashboardTemplate(dashboardTemplate, getDashboardName(project), data)
+}
+
+// getDashboardTemplate returns the dashboard template stored for the project or an empty string if there is none.
+func (dc *dashboardCreation) getDashboardTemplate(ctx context.Context, project string) (string, error) {
+	if dc.templateReader == nil {
+		return "", nil
+	}
+
+	dashboardTemplate, err := dc.templateReader.GetDashboardTemplate(ctx, project)
+	if err != nil {
+		var rnfErr *keptn.ResourceNotFoundError
+		var reErr *keptn.ResourceEmptyError
+		if errors.As(err, &rnfErr) || errors.As(err, &reErr) {
+			return "", nil
+		}
+		return "", fmt.Errorf("could not get dashboard template: %w", err)
+	}
+
+	return dashboardTemplate, nil
+}
+
+// getDashboardTemplateData returns the data available in the dashboard template for the provided project.
+func (dc *dashboardCreation) getDashboardTemplateData(ctx context.Context, project string, shipyard keptnv2.Shipyard) (*dashboardTemplateData, error) {
+	// dashboards reference management zones by the IDs of the configuration API v1, regardless of the API used to create them
+	managementZones, err := dynatrace.NewManagementZonesClient(dc.client).GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	data := &dashboardTemplateData{
+		Project:        project,
+		ManagementZone: getDashboardTemplateManagementZone(managementZones, GetManagementZoneNameForProject(project)),
+	}
+
+	allServiceNames := make(map[string]struct{})
+	for _, stage := range shipyard.Spec.Stages {
+		serviceNames, err := dc.serviceClient.GetServiceNames(ctx, project, stage.Name)
+		if err != nil {
+			return nil, fmt.Errorf("could not get services of stage %s: %w", stage.Name, err)
+		}
+
+		for _, serviceName := range serviceNames {
+			allServiceNames[serviceName] = struct{}{}
+		}
+
+		data.Stages = append(data.Stages, dashboardTemplateStage{
+			Name:           stage.Name,
+			ManagementZone: getDashboardTemplateManagementZone(managementZones, GetManagementZoneNameForProjectAndStage(project, stage.Name)),
+			Services:       serviceNames,
+		})
+	}
+
+	for serviceName := range allServiceNames {
+		data.Services = append(data.Services, serviceName)
+	}
+	sort.Strings(data.Services)
+
+	return data, nil
+}
+
+func getDashboardTemplateManagementZone(managementZones *dynatrace.ManagementZones, name string) dashboardTemplateManagementZone {
+	managementZone, _ := managementZones.GetByName(name)
+	return dashboardTemplateManagementZone{ID: managementZone.ID, Name: name}
+}
+
+// renderDashboardTemplate renders the dashboard template and returns the JSON representation of the dashboard.
+// The name of the dashboard is always set to the provided name, so that the dashboard can be found again when it is replaced or cleaned up.
+func renderDashboardTemplate(dashboardTemplate string, name string, data *dashboardTemplateData) ([]byte, error) {
+	tmpl, err := template.New("dashboard").Option("missingkey=error").Parse(dashboardTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse dashboard template: %w", err)
+	}
+
+	var rendered bytes.Buffer
+	err = tmpl.Execute(&rendered, data)
+	if err != nil {
+		return nil, fmt.Errorf("could not render dashboard template: %w", err)
+	}
+
+	// unmarshal to a generic map, so that properties not modelled by dynatrace.Dashboard are retained
+	var dashboard map[string]interface{}
+	err = json.Unmarshal(rendered.Bytes(), &dashboard)
+	if err != nil {
+		return nil, fmt.Errorf("rendered dashboard template is not valid JSON: %w", err)
+	}
+
+	dashboardMetadata, ok := dashboard["dashboardMetadata"].(map[string]interface{})
+	if !ok {
+		return nil, errors.New("rendered dashboard template has no dashboardMetadata")
+	}
+	dashboardMetadata["name"] = name
+
+	// the ID of an exported dashboard must not be reused
+	delete(dashboard, "id")
+
+	dashboardPayload, err := json.Marshal(dashboard)
+	if err != nil {
+		return nil, common.NewMarshalJSONError("Dynatrace dashboard", err)
+	}
+
+	return dashboardPayload, nil
+}
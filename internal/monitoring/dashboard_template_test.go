@@ -0,0 +1,195 @@
+package monitoring
+
+import (
+	"context"
+	"io/ioutil"
+	"testing"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/keptn"
+	"github.com/keptn-contrib/dynatrace-service/internal/test"
+)
+
+type dashboardTemplateReaderMock struct {
+	dashboardTemplate string
+	err               error
+}
+
+func (m *dashboardTemplateReaderMock) GetDashboardTemplate(_ context.Context, _ string) (string, error) {
+	return m.dashboardTemplate, m.err
+}
+
+type serviceClientMock struct {
+	services map[string][]string
+}
+
+func (m *serviceClientMock) GetServiceNames(_ context.Context, _ string, stage string) ([]string, error) {
+	return m.services[stage], nil
+}
+
+func (m *serviceClientMock) CreateServiceInProject(_ context.Context, _ string, _ string) error {
+	return nil
+}
+
+func createDashboardTemplateShipyard() keptnv2.Shipyard {
+	return keptnv2.Shipyard{
+		Spec: keptnv2.ShipyardSpec{
+			Stages: []keptnv2.Stage{{Name: "dev"}, {Name: "production"}},
+		},
+	}
+}
+
+func createDashboardTemplateServiceClient() *serviceClientMock {
+	return &serviceClientMock{
+		services: map[string][]string{
+			"dev":        {"orders", "carts"},
+			"production": {"carts"},
+		},
+	}
+}
+
+func createDashboardHandler(t *testing.T) (*deleteRecordingHandler, *test.FileBasedURLHandlerWithSink) {
+	handler := test.NewFileBasedURLHandlerWithSink(t)
+	handler.AddExact("/api/config/v1/managementZones", "./testdata/cleanup/management_zones.json")
+	handler.AddExact("/api/config/v1/dashboards", "./testdata/cleanup/dashboards.json")
+	return &deleteRecordingHandler{Handler: handler}, handler
+}
+
+// TestDashboardCreation_CreateFromTemplate tests that the dashboard template is rendered with the stages, services and management zones of the project and replaces the existing dashboard.
+func TestDashboardCreation_CreateFromTemplate(t *testing.T) {
+	handler, sinkHandler := createDashboardHandler(t)
+	dtClient, teardown := createDynatraceClient(t, handler)
+	defer teardown()
+
+	dashboardTemplate, err := ioutil.ReadFile("./testdata/dashboard/dashboard.template.json")
+	require.NoError(t, err)
+
+	templateReader := &dashboardTemplateReaderMock{dashboardTemplate: string(dashboardTemplate)}
+
+	result := newDashboardCreation(dtClient, templateReader, createDashboardTemplateServiceClient()).create(context.Background(), "sockshop", createDashboardTemplateShipyard())
+	require.True(t, result.Success, result.Message)
+	assert.Equal(t, []string{"/api/config/v1/dashboards/d1"}, handler.deletedURLs)
+
+	var dashboard map[string]interface{}
+	sinkHandler.GetStoredPayloadForURL("/api/config/v1/dashboards", &dashboard)
+	assert.NotContains(t, dashboard, "id")
+
+	dashboardMetadata := dashboard["dashboardMetadata"].(map[string]interface{})
+	assert.Equal(t, "sockshop@keptn: Digital Delivery & Operations Dashboard", dashboardMetadata["name"])
+	assert.Equal(t, map[string]interface{}{"id": "1", "name": "Keptn: sockshop"}, dashboardMetadata["dashboardFilter"].(map[string]interface{})["managementZone"])
+
+	tiles := dashboard["tiles"].([]interface{})
+	require.Len(t, tiles, 2)
+
+	productionTile := tiles[1].(map[string]interface{})
+	assert.Equal(t, "production: 1 services", productionTile["name"])
+	assert.Equal(t, map[string]interface{}{"id": "3", "name": "Keptn: sockshop production"}, productionTile["tileFilter"].(map[string]interface{})["managementZone"])
+
+	// properties unknown to the dynatrace-service are retained
+	assert.Equal(t, false, productionTile["isAutoRefreshDisabled"])
+}
+
+// TestDashboardCreation_FallsBackToBuiltInLayout tests that the built-in dashboard layout is used if no dashboard template is stored for the project.
+func TestDashboardCreation_FallsBackToBuiltInLayout(t *testing.T) {
+	handler, sinkHandler := createDashboardHandler(t)
+	dtClient, teardown := createDynatraceClient(t, handler)
+	defer teardown()
+
+	templateReader := &dashboardTemplateReaderMock{err: &keptn.ResourceNotFoundError{}}
+
+	result := newDashboardCreation(dtClient, templateReader, createDashboardTemplateServiceClient()).create(context.Background(), "sockshop", createDashboardTemplateShipyard())
+	require.True(t, result.Success, result.Message)
+
+	var dashboard map[string]interface{}
+	sinkHandler.GetStoredPayloadForURL("/api/config/v1/dashboards", &dashboard)
+	assert.Len(t, dashboard["tiles"], len(createDynatraceDashboard("sockshop", createDashboardTemplateShipyard()).Tiles))
+}
+
+// TestDashboardCreation_InvalidTemplateKeepsExistingDashboard tests that the existing dashboard is not deleted if the dashboard template cannot be rendered.
+func TestDashboardCreation_InvalidTemplateKeepsExistingDashboard(t *testing.T) {
+	handler, _ := createDashboardHandler(t)
+	dtClient, teardown := createDynatraceClient(t, handler)
+	defer teardown()
+
+	templateReader := &dashboardTemplateReaderMock{dashboardTemplate: `{"dashboardMetadata": {}, "tiles": [{{ .Unknown }}]}`}
+
+	result := newDashboardCreation(dtClient, templateReader, createDashboardTemplateServiceClient()).create(context.Background(), "sockshop", createDashboardTemplateShipyard())
+	assert.False(t, result.Success)
+	assert.Empty(t, handler.deletedURLs)
+}
+
+func TestRenderDashboardTemplate(t *testing.T) {
+	data := &dashboardTemplateData{
+		Project:  "sockshop",
+		Stages:   []dashboardTemplateStage{{Name: "dev"}, {Name: "production"}},
+		Services: []string{"carts", "orders"},
+	}
+
+	tests := []struct {
+		name              string
+		dashboardTemplate string
+		expectError       bool
+		expectedDashboard string
+	}{
+		{
+			name:              "stages and services",
+			dashboardTemplate: `{"dashboardMetadata": {"name": "{{ .Project }}", "tags": [{{ range $i, $s := .Stages }}{{ if $i }},{{ end }}"{{ $s.Name }}"{{ end }}]}, "tiles": [{{ range $i, $s := .Services }}{{ if $i }},{{ end }}{"name": "{{ $s }}"}{{ end }}]}`,
+			expectedDashboard: `{"dashboardMetadata": {"name": "dashboard", "tags": ["dev", "production"]}, "tiles": [{"name": "carts"}, {"name": "orders"}]}`,
+		},
+		{
+			name:              "invalid template",
+			dashboardTemplate: `{"dashboardMetadata": {"name": "{{ .Project }"}}`,
+			expectError:       true,
+		},
+		{
+			name:              "unknown placeholder",
+			dashboardTemplate: `{"dashboardMetadata": {"name": "{{ .Project.Name }}"}}`,
+			expectError:       true,
+		},
+		{
+			name:              "invalid JSON",
+			dashboardTemplate: `{"dashboardMetadata": {"name": {{ .Project }}}}`,
+			expectError:       true,
+		},
+		{
+			name:              "no dashboard metadata",
+			dashboardTemplate: `{"tiles": []}`,
+			expectError:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dashboardPayload, err := renderDashboardTemplate(tt.dashboardTemplate, "dashboard", data)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.expectedDashboard, string(dashboardPayload))
+		})
+	}
+}
+
+// TestDashboardTemplateData tests that the services of all stages are provided in sorted order.
+func TestDashboardTemplateData(t *testing.T) {
+	handler, _ := createDashboardHandler(t)
+	dtClient, teardown := createDynatraceClient(t, handler)
+	defer teardown()
+
+	data, err := newDashboardCreation(dtClient, nil, createDashboardTemplateServiceClient()).getDashboardTemplateData(context.Background(), "sockshop", createDashboardTemplateShipyard())
+	require.NoError(t, err)
+
+	assert.Equal(t, &dashboardTemplateData{
+		Project:        "sockshop",
+		ManagementZone: dashboardTemplateManagementZone{ID: "1", Name: "Keptn: sockshop"},
+		Stages: []dashboardTemplateStage{
+			{Name: "dev", ManagementZone: dashboardTemplateManagementZone{ID: "2", Name: "Keptn: sockshop dev"}, Services: []string{"orders", "carts"}},
+			{Name: "production", ManagementZone: dashboardTemplateManagementZone{ID: "3", Name: "Keptn: sockshop production"}, Services: []string{"carts"}},
+		},
+		Services: []string{"carts", "orders"},
+	}, data)
+}
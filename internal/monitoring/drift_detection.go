@@ -0,0 +1,452 @@
+package monitoring
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/config"
+	"github.com/keptn-contrib/dynatrace-service/internal/credentials"
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+	"github.com/keptn-contrib/dynatrace-service/internal/env"
+	"github.com/keptn-contrib/dynatrace-service/internal/keptn"
+)
+
+const (
+	entityTypeManagementZone      = "management_zone"
+	entityTypeTaggingRule         = "tagging_rule"
+	entityTypeAlertingProfile     = "alerting_profile"
+	entityTypeProblemNotification = "problem_notification"
+	entityTypeDashboard           = "dashboard"
+)
+
+// driftEntityTypes are the types of Dynatrace entities checked for drift
+var driftEntityTypes = []string{entityTypeManagementZone, entityTypeTaggingRule, entityTypeAlertingProfile, entityTypeProblemNotification, entityTypeDashboard}
+
+// driftKind describes how a Dynatrace entity differs from its desired configuration
+type driftKind string
+
+const (
+	driftKindMissing  driftKind = "missing"
+	driftKindModified driftKind = "modified"
+)
+
+// drift describes a Dynatrace entity that differs from its desired configuration
+type drift struct {
+	EntityType string
+	Name       string
+	Kind       driftKind
+
+	// correct restores the desired configuration of the entity
+	correct func(ctx context.Context) error
+}
+
+// driftDetector compares the Dynatrace entities generated when configuring the monitoring for a Keptn project with the current state of the tenant
+type driftDetector struct {
+	dtClient                 dynatrace.ClientInterface
+	configClients            *dynatrace.ConfigClients
+	dashboardTemplateReader  keptn.DashboardTemplateReaderInterface
+	serviceClient            keptn.ServiceClientInterface
+	keptnCredentialsProvider credentials.KeptnCredentialsProvider
+	monitoringConfig         *config.MonitoringConfig
+}
+
+func newDriftDetector(dtClient dynatrace.ClientInterface, configClients *dynatrace.ConfigClients, dashboardTemplateReader keptn.DashboardTemplateReaderInterface, serviceClient keptn.ServiceClientInterface, keptnCredentialsProvider credentials.KeptnCredentialsProvider, monitoringConfig *config.MonitoringConfig) *driftDetector {
+	return &driftDetector{
+		dtClient:                 dtClient,
+		configClients:            configClients,
+		dashboardTemplateReader:  dashboardTemplateReader,
+		serviceClient:            serviceClient,
+		keptnCredentialsProvider: keptnCredentialsProvider,
+		monitoringConfig:         monitoringConfig,
+	}
+}
+
+// detect returns the drift of all entity types enabled for the project. Entity types whose state cannot be retrieved are skipped.
+func (d *driftDetector) detect(ctx context.Context, project string, shipyard keptnv2.Shipyard) []drift {
+	var drifts []drift
+	appendDrifts := func(entityType string, entityDrifts []drift, err error) {
+		if err != nil {
+			log.WithError(err).WithFields(log.Fields{"project": project, "entityType": entityType}).Error("Could not detect drift")
+		}
+		drifts = append(drifts, entityDrifts...)
+	}
+
+	managementZonesConfig := d.monitoringConfig.GetManagementZones()
+	if managementZonesConfig.IsEnabled(env.IsManagementZonesGenerationEnabled()) {
+		entityDrifts, err := d.detectManagementZoneDrift(ctx, project, filterShipyardStages(shipyard, managementZonesConfig))
+		appendDrifts(entityTypeManagementZone, entityDrifts, err)
+	}
+
+	if d.monitoringConfig.GetTaggingRules().IsEnabled(env.IsTaggingRulesGenerationEnabled()) {
+		entityDrifts, err := d.detectTaggingRuleDrift(ctx)
+		appendDrifts(entityTypeTaggingRule, entityDrifts, err)
+	}
+
+	if d.monitoringConfig.GetProblemNotifications().IsEnabled(env.IsProblemNotificationsGenerationEnabled()) {
+		entityDrifts, err := d.detectProblemNotificationDrift(ctx, project)
+		appendDrifts(entityTypeProblemNotification, entityDrifts, err)
+	}
+
+	dashboardsConfig := d.monitoringConfig.GetDashboards()
+	if dashboardsConfig.IsEnabled(env.IsDashboardsGenerationEnabled()) {
+		entityDrifts, err := d.detectDashboardDrift(ctx, project, filterShipyardStages(shipyard, dashboardsConfig))
+		appendDrifts(entityTypeDashboard, entityDrifts, err)
+	}
+
+	return drifts
+}
+
+func (d *driftDetector) detectManagementZoneDrift(ctx context.Context, project string, shipyard keptnv2.Shipyard) ([]drift, error) {
+	client := d.configClients.ManagementZones
+	managementZones, err := client.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	desiredManagementZones := []*dynatrace.ManagementZone{createManagementZoneForProject(project)}
+	for _, stage := range shipyard.Spec.Stages {
+		desiredManagementZones = append(desiredManagementZones, createManagementZoneForStage(project, stage.Name))
+	}
+
+	var drifts []drift
+	for _, desired := range desiredManagementZones {
+		desired := desired
+		existing, found := managementZones.GetByName(desired.Name)
+		if !found {
+			drifts = append(drifts, drift{
+				EntityType: entityTypeManagementZone,
+				Name:       desired.Name,
+				Kind:       driftKindMissing,
+				correct: func(ctx context.Context) error {
+					return client.Create(ctx, desired)
+				},
+			})
+			continue
+		}
+
+		actual, err := client.GetByID(ctx, existing.ID)
+		if err != nil {
+			return drifts, err
+		}
+
+		if !isJSONSubset(desired, actual) {
+			drifts = append(drifts, drift{
+				EntityType: entityTypeManagementZone,
+				Name:       desired.Name,
+				Kind:       driftKindModified,
+				correct: func(ctx context.Context) error {
+					return client.Update(ctx, existing.ID, desired)
+				},
+			})
+		}
+	}
+	return drifts, nil
+}
+
+func (d *driftDetector) detectTaggingRuleDrift(ctx context.Context) ([]drift, error) {
+	client := d.configClients.AutoTags
+
+	var drifts []drift
+	for _, ruleName := range keptnTaggingRuleNames {
+		desired := createAutoTaggingRuleDTO(ruleName)
+		id, err := client.GetTagIDByName(ctx, ruleName)
+		if err != nil {
+			return drifts, err
+		}
+
+		if id == "" {
+			drifts = append(drifts, drift{
+				EntityType: entityTypeTaggingRule,
+				Name:       ruleName,
+				Kind:       driftKindMissing,
+				correct: func(ctx context.Context) error {
+					return client.Create(ctx, desired)
+				},
+			})
+			continue
+		}
+
+		actual, err := client.GetByID(ctx, id)
+		if err != nil {
+			return drifts, err
+		}
+
+		if !isJSONSubset(desired, actual) {
+			drifts = append(drifts, drift{
+				EntityType: entityTypeTaggingRule,
+				Name:       ruleName,
+				Kind:       driftKindModified,
+				correct: func(ctx context.Context) error {
+					return client.Update(ctx, id, desired)
+				},
+			})
+		}
+	}
+	return drifts, nil
+}
+
+// detectProblemNotificationDrift returns the drift of the alerting profiles and problem notifications configured for the project.
+func (d *driftDetector) detectProblemNotificationDrift(ctx context.Context, project string) ([]drift, error) {
+	creation := newProblemNotificationCreation(d.configClients.AlertingProfiles, d.configClients.Notifications, d.keptnCredentialsProvider, d.monitoringConfig.GetProblemNotifications())
+
+	var drifts []drift
+	alertingProfileIDs := map[string]string{}
+	for _, desired := range getDesiredProblemNotifications(project, d.monitoringConfig.GetProblemNotifications()) {
+		desired := desired
+
+		alertingProfileID, checked := alertingProfileIDs[desired.alertingProfile.DisplayName]
+		if !checked {
+			var alertingProfileDrift *drift
+			var err error
+			alertingProfileID, alertingProfileDrift, err = d.detectAlertingProfileDrift(ctx, desired.alertingProfile)
+			if err != nil {
+				return drifts, err
+			}
+
+			alertingProfileIDs[desired.alertingProfile.DisplayName] = alertingProfileID
+			if alertingProfileDrift != nil {
+				drifts = append(drifts, *alertingProfileDrift)
+			}
+		}
+
+		kind, err := d.getProblemNotificationDriftKind(ctx, desired, alertingProfileID)
+		if err != nil {
+			return drifts, err
+		}
+
+		if kind != "" {
+			drifts = append(drifts, drift{
+				EntityType: entityTypeProblemNotification,
+				Name:       desired.name,
+				Kind:       kind,
+				correct: func(ctx context.Context) error {
+					keptnCredentials, err := d.keptnCredentialsProvider.GetKeptnCredentials(ctx)
+					if err != nil {
+						return fmt.Errorf("failed to retrieve Keptn API credentials: %w", err)
+					}
+
+					result := creation.createProblemNotification(ctx, desired, keptnCredentials)
+					return configResultToError(&result)
+				},
+			})
+		}
+	}
+	return drifts, nil
+}
+
+// detectAlertingProfileDrift returns the ID of the alerting profile with the name of the desired one, if any, and its drift.
+func (d *driftDetector) detectAlertingProfileDrift(ctx context.Context, desired *dynatrace.AlertingProfile) (string, *drift, error) {
+	alertingProfilesClient := d.configClients.AlertingProfiles
+	alertingProfileID, err := alertingProfilesClient.GetProfileID(ctx, desired.DisplayName)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if alertingProfileID == "" {
+		return "", &drift{
+			EntityType: entityTypeAlertingProfile,
+			Name:       desired.DisplayName,
+			Kind:       driftKindMissing,
+			correct: func(ctx context.Context) error {
+				_, err := alertingProfilesClient.Create(ctx, desired)
+				return err
+			},
+		}, nil
+	}
+
+	actual, err := alertingProfilesClient.GetByID(ctx, alertingProfileID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if isJSONSubset(desired, actual) {
+		return alertingProfileID, nil, nil
+	}
+
+	desired.ID = alertingProfileID
+	return alertingProfileID, &drift{
+		EntityType: entityTypeAlertingProfile,
+		Name:       desired.DisplayName,
+		Kind:       driftKindModified,
+		correct: func(ctx context.Context) error {
+			return alertingProfilesClient.Update(ctx, alertingProfileID, desired)
+		},
+	}, nil
+}
+
+func (d *driftDetector) getProblemNotificationDriftKind(ctx context.Context, desired desiredProblemNotification, alertingProfileID string) (driftKind, error) {
+	notificationsClient := d.configClients.Notifications
+	notificationIDs, err := notificationsClient.GetIDsByName(ctx, desired.name)
+	if err != nil {
+		return "", err
+	}
+
+	if len(notificationIDs) == 0 {
+		return driftKindMissing, nil
+	}
+
+	// several problem notifications with the same name are replaced by a single one
+	if len(notificationIDs) > 1 {
+		return driftKindModified, nil
+	}
+
+	keptnCredentials, err := d.keptnCredentialsProvider.GetKeptnCredentials(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to retrieve Keptn API credentials: %w", err)
+	}
+
+	desiredNotification := dynatrace.NewKeptnProblemNotification(desired.name, keptnCredentials, alertingProfileID, desired.project, desired.payload)
+	actual, err := notificationsClient.GetByID(ctx, notificationIDs[0])
+	if err != nil {
+		return "", err
+	}
+
+	// neither the payload, which the default notification shared by several projects forwards to the last configured project, nor the headers, whose secret values are not returned by Dynatrace, are compared
+	desiredNotification.Payload, desiredNotification.Headers = "", nil
+	actual.Payload, actual.Headers = "", nil
+	if !reflect.DeepEqual(desiredNotification, actual) {
+		return driftKindModified, nil
+	}
+	return "", nil
+}
+
+// detectDashboardDrift returns the drift of the project dashboard. Only the names, types and positions of the tiles are compared, as Dynatrace adds defaults to the tile configurations.
+func (d *driftDetector) detectDashboardDrift(ctx context.Context, project string, shipyard keptnv2.Shipyard) ([]drift, error) {
+	creation := newDashboardCreation(d.dtClient, d.dashboardTemplateReader, d.serviceClient)
+	desiredPayload, err := creation.getDashboardPayload(ctx, project, shipyard)
+	if err != nil {
+		return nil, err
+	}
+
+	desired := &dynatrace.Dashboard{}
+	err = json.Unmarshal(desiredPayload, desired)
+	if err != nil {
+		return nil, err
+	}
+
+	client := dynatrace.NewDashboardsClient(d.dtClient)
+	dashboards, err := client.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	correct := func(ctx context.Context) error {
+		return configResultToError(creation.create(ctx, project, shipyard))
+	}
+
+	var dashboardIDs []string
+	for _, dashboardItem := range dashboards.Dashboards {
+		if dashboardItem.Name == getDashboardName(project) {
+			dashboardIDs = append(dashboardIDs, dashboardItem.ID)
+		}
+	}
+
+	if len(dashboardIDs) == 0 {
+		return []drift{{EntityType: entityTypeDashboard, Name: getDashboardName(project), Kind: driftKindMissing, correct: correct}}, nil
+	}
+
+	if len(dashboardIDs) > 1 {
+		return []drift{{EntityType: entityTypeDashboard, Name: getDashboardName(project), Kind: driftKindModified, correct: correct}}, nil
+	}
+
+	actual, err := client.GetByID(ctx, dashboardIDs[0])
+	if err != nil {
+		return nil, err
+	}
+
+	if !reflect.DeepEqual(getTileLayout(desired), getTileLayout(actual)) {
+		return []drift{{EntityType: entityTypeDashboard, Name: getDashboardName(project), Kind: driftKindModified, correct: correct}}, nil
+	}
+	return nil, nil
+}
+
+type tileLayout struct {
+	Name     string
+	TileType string
+	Bounds   dynatrace.Bounds
+}
+
+func getTileLayout(dashboard *dynatrace.Dashboard) []tileLayout {
+	layout := make([]tileLayout, len(dashboard.Tiles))
+	for i, tile := range dashboard.Tiles {
+		layout[i] = tileLayout{Name: tile.Name, TileType: tile.TileType, Bounds: tile.Bounds}
+	}
+	return layout
+}
+
+func configResultToError(result *configResult) error {
+	if result == nil || result.Success {
+		return nil
+	}
+	return errors.New(result.Message)
+}
+
+// isJSONSubset returns whether all values set in the JSON representation of desired are equal in the JSON representation of actual.
+// Null values and empty strings in desired are considered unset, e.g. IDs and metadata assigned by Dynatrace.
+func isJSONSubset(desired interface{}, actual interface{}) bool {
+	desiredValue, err := toJSONValue(desired)
+	if err != nil {
+		return false
+	}
+
+	actualValue, err := toJSONValue(actual)
+	if err != nil {
+		return false
+	}
+
+	return isJSONValueSubset(desiredValue, actualValue)
+}
+
+func toJSONValue(v interface{}) (interface{}, error) {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var value interface{}
+	err = json.Unmarshal(payload, &value)
+	return value, err
+}
+
+func isJSONValueSubset(desired interface{}, actual interface{}) bool {
+	switch desiredValue := desired.(type) {
+	case nil:
+		return true
+
+	case string:
+		return desiredValue == "" || desiredValue == actual
+
+	case map[string]interface{}:
+		actualValue, ok := actual.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for key, value := range desiredValue {
+			if !isJSONValueSubset(value, actualValue[key]) {
+				return false
+			}
+		}
+		return true
+
+	case []interface{}:
+		actualValue, ok := actual.([]interface{})
+		if !ok || len(desiredValue) != len(actualValue) {
+			return false
+		}
+		for i := range desiredValue {
+			if !isJSONValueSubset(desiredValue[i], actualValue[i]) {
+				return false
+			}
+		}
+		return true
+
+	default:
+		return desired == actual
+	}
+}
@@ -10,10 +10,10 @@ import (
 )
 
 type managementZoneCreation struct {
-	client dynatrace.ClientInterface
+	client dynatrace.ManagementZonesClientInterface
 }
 
-func newManagementZoneCreation(client dynatrace.ClientInterface) *managementZoneCreation {
+func newManagementZoneCreation(client dynatrace.ManagementZonesClientInterface) *managementZoneCreation {
 	return &managementZoneCreation{
 		client: client,
 	}
@@ -22,8 +22,7 @@ func newManagementZoneCreation(client dynatrace.ClientInterface) *managementZone
 // create creates a new management zone for the project.
 func (mzc *managementZoneCreation) create(ctx context.Context, project string, shipyard keptnv2.Shipyard) []configResult {
 	// get existing management zones
-	managementZoneClient := dynatrace.NewManagementZonesClient(mzc.client)
-	managementZoneNames, err := managementZoneClient.GetAll(ctx)
+	managementZoneNames, err := mzc.client.GetAll(ctx)
 	if err != nil {
 		// continue
 		log.WithError(err).Error("Could not retrieve management zones")
@@ -32,7 +31,7 @@ func (mzc *managementZoneCreation) create(ctx context.Context, project string, s
 	var managementZonesResults []configResult
 	managementZoneResult := getOrCreateManagementZone(
 		ctx,
-		managementZoneClient,
+		mzc.client,
 		GetManagementZoneNameForProject(project),
 		func() *dynatrace.ManagementZone {
 			return createManagementZoneForProject(project)
@@ -43,7 +42,7 @@ func (mzc *managementZoneCreation) create(ctx context.Context, project string, s
 	for _, stage := range shipyard.Spec.Stages {
 		managementZone := getOrCreateManagementZone(
 			ctx,
-			managementZoneClient,
+			mzc.client,
 			GetManagementZoneNameForProjectAndStage(project, stage.Name),
 			func() *dynatrace.ManagementZone {
 				return createManagementZoneForStage(project, stage.Name)
@@ -55,9 +54,30 @@ func (mzc *managementZoneCreation) create(ctx context.Context, project string, s
 	return managementZonesResults
 }
 
+// diff returns the management zones for the project that would be created.
+func (mzc *managementZoneCreation) diff(ctx context.Context, project string, shipyard keptnv2.Shipyard) *entityDiff {
+	managementZoneNames, err := mzc.client.GetAll(ctx)
+	if err != nil {
+		return &entityDiff{Error: err}
+	}
+
+	requiredManagementZoneNames := []string{GetManagementZoneNameForProject(project)}
+	for _, stage := range shipyard.Spec.Stages {
+		requiredManagementZoneNames = append(requiredManagementZoneNames, GetManagementZoneNameForProjectAndStage(project, stage.Name))
+	}
+
+	diff := &entityDiff{}
+	for _, managementZoneName := range requiredManagementZoneNames {
+		if !managementZoneNames.Contains(managementZoneName) {
+			diff.Changes = append(diff.Changes, configChange{Name: managementZoneName, Action: changeActionCreate})
+		}
+	}
+	return diff
+}
+
 func getOrCreateManagementZone(
 	ctx context.Context,
-	managementZoneClient *dynatrace.ManagementZonesClient,
+	managementZoneClient dynatrace.ManagementZonesClientInterface,
 	managementZoneName string,
 	managementZoneFunc func() *dynatrace.ManagementZone,
 	managementZoneNames *dynatrace.ManagementZones) configResult {
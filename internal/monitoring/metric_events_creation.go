@@ -4,20 +4,48 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"reflect"
 	"regexp"
 	"strconv"
 	"strings"
 
+	keptnlib "github.com/keptn/go-utils/pkg/lib"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/common"
 	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
 	"github.com/keptn-contrib/dynatrace-service/internal/keptn"
 	"github.com/keptn-contrib/dynatrace-service/internal/sli/query"
-	keptnlib "github.com/keptn/go-utils/pkg/lib"
-
-	log "github.com/sirupsen/logrus"
 )
 
 const keptnService = "keptn_service"
-const keptnDeployment = "keptn_deployment"
+
+// metricEventDeployment is the deployment monitored by metric events, i.e. the value of the $DEPLOYMENT placeholder in the SLI queries
+const metricEventDeployment = "primary"
+
+const (
+	metricEventSamples           = 5 // taken from default value of custom metric events
+	metricEventViolatingSamples  = 3 // taken from default value of custom metric events
+	metricEventDealertingSamples = 5 // taken from default value of custom metric events
+
+	// metricEventPercentPerSignalFluctuation is the relative change in percent corresponding to one interquartile range the values may exceed their baseline before an event is raised,
+	// i.e. <=+10% results in a signal fluctuation of 1 and <=+50% in a signal fluctuation of 5
+	metricEventPercentPerSignalFluctuation = 10
+
+	// metricEventMaxSignalFluctuation is the maximum signal fluctuation of auto-adaptive thresholds supported by Dynatrace
+	metricEventMaxSignalFluctuation = 10
+)
+
+// metricTransformations are the transformations of the Metrics API v2, used to find the end of the metric key in a metric selector
+var metricTransformations = map[string]bool{
+	"asGauge": true, "auto": true, "avg": true, "count": true, "default": true, "delta": true, "filter": true, "fold": true,
+	"last": true, "lastReal": true, "limit": true, "max": true, "median": true, "merge": true, "min": true, "names": true,
+	"parents": true, "partition": true, "percentile": true, "rate": true, "rollup": true, "setUnit": true, "smooth": true,
+	"sort": true, "splitBy": true, "sum": true, "timeshift": true, "toUnit": true, "value": true,
+}
+
+var metricKeyRegex = regexp.MustCompile(`^[A-Za-z0-9_\-.:]+`)
+var entityTypeRegex = regexp.MustCompile(`type\("?([A-Za-z_]+)"?\)`)
 
 type criteriaObject struct {
 	Operator        string
@@ -27,6 +55,13 @@ type criteriaObject struct {
 	CheckIncrease   bool
 }
 
+// desiredMetricEvent is a metric event mapped from a single SLO criteria. If the criteria cannot be mapped, err contains the reason.
+type desiredMetricEvent struct {
+	name        string
+	metricEvent *dynatrace.MetricEvent
+	err         error
+}
+
 type metricEventCreation struct {
 	dtClient          dynatrace.ClientInterface
 	eventSenderClient keptn.EventSenderClientInterface
@@ -41,10 +76,10 @@ func newMetricEventCreation(dynatraceClient dynatrace.ClientInterface, eventSend
 	}
 }
 
-// create creates new metric events if SLOs are specified.
+// create creates or updates a metric event for each pass and warning criteria of the SLOs of the service. Criteria that cannot be mapped are reported as failed results.
 func (mec metricEventCreation) create(ctx context.Context, project string, stage string, service string) []configResult {
 	log.Info("Creating custom metric events for project SLIs")
-	slos, err := mec.sliAndSLOReader.GetSLOs(ctx, project, stage, service)
+	desiredMetricEvents, err := mec.getDesiredMetricEvents(ctx, project, stage, service)
 	if err != nil {
 		log.WithError(err).WithFields(
 			log.Fields{
@@ -53,141 +88,308 @@ func (mec metricEventCreation) create(ctx context.Context, project string, stage
 		return nil
 	}
 
-	// get custom metrics for project
-	slis, err := mec.sliAndSLOReader.GetSLIs(ctx, project, stage, service)
-	if err != nil {
-		log.WithError(err).WithField("project", project).Error("Failed to get SLIs for project")
-		return nil
-	}
-	projectCustomQueries := query.NewCustomQueries(slis)
-
 	managementZones, err := dynatrace.NewManagementZonesClient(mec.dtClient).GetAll(ctx)
-	var mzId int64 = -1
 	if err != nil {
 		log.WithError(err).WithFields(log.Fields{"project": project, "stage": stage}).Error("Could not retrieve management zones")
 		return nil
 	}
 
-	// TODO 2021-08-20: check the logic below - if parsing management zone id does not work, we will continue anyway?
-	if zone, wasFound := managementZones.GetByName(GetManagementZoneNameForProjectAndStage(project, stage)); wasFound {
-		mzId, err = strconv.ParseInt(zone.ID, 10, 64)
-		if err != nil {
-			log.WithError(err).WithFields(log.Fields{"project": project, "stage": stage}).Warn("Could not parse management zone ID")
-		}
-	} else {
-		log.WithError(err).WithFields(log.Fields{"project": project, "stage": stage}).Warn("Could not find management zone")
+	managementZone, found := managementZones.GetByName(GetManagementZoneNameForProjectAndStage(project, stage))
+	if !found {
+		log.WithFields(log.Fields{"project": project, "stage": stage}).Warn("Could not find management zone")
 		return nil
 	}
 
-	metricEventsClient := dynatrace.NewMetricEventsClient(mec.dtClient)
-	var metricsEventResults []configResult
-	// try to create metric events using best effort.
-	for _, objective := range slos.Objectives {
-		query, err := projectCustomQueries.GetQueryByNameOrDefault(objective.SLI)
-		if err != nil {
-			// Error occurred but continue
-			log.WithField("sli", objective.SLI).Error("Could not find query for SLI")
+	client := dynatrace.NewMetricEventsClient(mec.dtClient)
+	existingMetricEventIDs, err := client.GetIDsByName(ctx)
+	if err != nil {
+		log.WithError(err).WithFields(log.Fields{"project": project, "stage": stage}).Error("Could not retrieve metric events")
+		return []configResult{{Success: false, Message: err.Error()}}
+	}
 
-			// if .GetQueryByNameOrDefault(...) would fail, it would return an empty string. So we would do all the work
-			// in the two for loops below until we come to the point in createKeptnMetricEventDTO where it would be checked
-			// whether 'config' not equals to "" and return an error, log error and continue for each iteration.
-			// therefore, continue
+	var metricEventsResults []configResult
+	for _, desired := range desiredMetricEvents {
+		if desired.err != nil {
+			log.WithError(desired.err).WithField("name", desired.name).Warn("Could not map criteria to metric event")
+			metricEventsResults = append(metricEventsResults, configResult{Name: desired.name, Success: false, Message: "Unsupported criteria: " + desired.err.Error()})
 			continue
 		}
 
-		metricsEventResults = append(
-			metricsEventResults,
-			setupAllMetricEvents(ctx, metricEventsClient, project, stage, service, objective, query, mzId)...)
+		desired.metricEvent.QueryDefinition.ManagementZone = managementZone.ID
+		if id, exists := existingMetricEventIDs[desired.name]; exists {
+			err = client.Update(ctx, id, desired.metricEvent)
+		} else {
+			err = client.Create(ctx, desired.metricEvent)
+		}
+
+		if err != nil {
+			log.WithError(err).WithField("name", desired.name).Error("Could not create metric event")
+			metricEventsResults = append(metricEventsResults, configResult{Name: desired.name, Success: false, Message: err.Error()})
+			continue
+		}
 
+		log.WithField("name", desired.name).Info("Created metric event")
+		metricEventsResults = append(metricEventsResults, configResult{Name: desired.name, Success: true})
 	}
 
-	if len(metricsEventResults) > 0 {
+	if len(metricEventsResults) > 0 {
 		log.WithField("metricEventsURL", mec.dtClient.Credentials().GetTenant()+"/#settings/anomalydetection/metricevents").Info("Custom metric events have been generated")
 	}
 
-	return metricsEventResults
+	return metricEventsResults
 }
 
-func setupAllMetricEvents(ctx context.Context, client *dynatrace.MetricEventsClient, project string, stage string, service string, slo *keptnlib.SLO, query string, managementZoneID int64) []configResult {
-	var metricEventsResults []configResult
-	for _, criteria := range slo.Pass {
-		for _, crit := range criteria.Criteria {
+// diff returns the metric events for the service that would be created or updated, as well as the criteria that could not be mapped.
+// Management zones in plannedManagementZones are assumed to be created by the same run. As their IDs are not known yet, existing metric events would always be updated to use them.
+func (mec metricEventCreation) diff(ctx context.Context, project string, stage string, service string, plannedManagementZones map[string]bool) ([]configChange, error) {
+	desiredMetricEvents, err := mec.getDesiredMetricEvents(ctx, project, stage, service)
+	if err != nil {
+		// no SLOs, so no metric events would be created
+		return nil, nil
+	}
 
-			metricEventsResult, err := setupSingleMetricEvent(ctx, client, project, stage, service, slo.SLI, query, crit, managementZoneID)
-			if err != nil {
-				continue
-			}
+	managementZones, err := dynatrace.NewManagementZonesClient(mec.dtClient).GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-			metricEventsResults = append(metricEventsResults, *metricEventsResult)
-		}
+	managementZoneName := GetManagementZoneNameForProjectAndStage(project, stage)
+	managementZone, found := managementZones.GetByName(managementZoneName)
+	if !found && !plannedManagementZones[managementZoneName] {
+		// without a management zone no metric events would be created
+		return nil, nil
 	}
 
-	return metricEventsResults
+	client := dynatrace.NewMetricEventsClient(mec.dtClient)
+	existingMetricEventIDs, err := client.GetIDsByName(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []configChange
+	for _, desired := range desiredMetricEvents {
+		if desired.err != nil {
+			changes = append(changes, configChange{Name: desired.name, Action: changeActionUnsupported, Message: "Unsupported criteria: " + desired.err.Error()})
+			continue
+		}
+
+		id, exists := existingMetricEventIDs[desired.name]
+		if !exists {
+			changes = append(changes, configChange{Name: desired.name, Action: changeActionCreate})
+			continue
+		}
+
+		if !found {
+			changes = append(changes, configChange{Name: desired.name, Action: changeActionUpdate})
+			continue
+		}
+
+		existingMetricEvent, err := client.GetByID(ctx, id)
+		if err != nil {
+			return changes, err
+		}
+
+		desired.metricEvent.QueryDefinition.ManagementZone = managementZone.ID
+		if !reflect.DeepEqual(existingMetricEvent, desired.metricEvent) {
+			changes = append(changes, configChange{Name: desired.name, Action: changeActionUpdate})
+		}
+	}
+	return changes, nil
 }
 
-func setupSingleMetricEvent(ctx context.Context, client *dynatrace.MetricEventsClient, project string, stage string, service string, metric string, query string, crit string, managementZoneID int64) (*configResult, error) {
-	// criteria.Criteria
-	criteriaObject, err := parseCriteriaString(crit)
+// getDesiredMetricEvents returns a metric event for each pass and warning criteria of the SLOs of the service. The management zone of the metric events is not set.
+func (mec metricEventCreation) getDesiredMetricEvents(ctx context.Context, project string, stage string, service string) ([]desiredMetricEvent, error) {
+	slos, err := mec.sliAndSLOReader.GetSLOs(ctx, project, stage, service)
 	if err != nil {
-		// Error occurred but continue
-		log.WithError(err).WithField("criteria", crit).Error("Could not parse criteria")
-		return nil, fmt.Errorf("could not parse criteria: %s", crit)
+		return nil, err
 	}
 
-	if criteriaObject.IsComparison {
-		// comparison-based criteria cannot be mapped to alerts
-		return nil, fmt.Errorf("comparison-based criteria cannot be mapped to alerts")
+	slis, err := mec.sliAndSLOReader.GetSLIs(ctx, project, stage, service)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get SLIs for service %s in stage %s: %w", service, stage, err)
 	}
+	customQueries := query.NewCustomQueries(slis)
+	eventAdapter := serviceEventAdapter{projectEventAdapter: projectEventAdapter{project: project}, stage: stage, service: service, deployment: metricEventDeployment}
 
-	newMetricEvent, err := createKeptnMetricEventDTO(project, stage, service, metric, query, crit, criteriaObject.Value, managementZoneID)
-	if err != nil {
-		// Error occurred but continue
-		log.WithError(err).WithFields(
-			log.Fields{
-				"sli":      metric,
-				"criteria": crit,
-			}).Error("Could not create metric event definition for criteria")
-		return nil, fmt.Errorf("could not create metric event definition for criteria, sli: %s, criteria: %s", metric, crit)
+	var desiredMetricEvents []desiredMetricEvent
+	for _, objective := range slos.Objectives {
+		sliQuery, err := customQueries.GetQueryByNameOrDefault(objective.SLI)
+		if err != nil {
+			log.WithField("sli", objective.SLI).Error("Could not find query for SLI")
+			continue
+		}
+
+		metricSelector, err := getMetricEventMetricSelector(common.ReplaceQueryParameters(sliQuery, nil, eventAdapter))
+		for i, criteria := range getAllCriteria(objective.Pass) {
+			desiredMetricEvents = append(desiredMetricEvents, createDesiredMetricEvent(getMetricEventName(project, stage, service, objective.SLI, false, i), metricSelector, err, criteria, false))
+		}
+		for i, criteria := range getAllCriteria(objective.Warning) {
+			desiredMetricEvents = append(desiredMetricEvents, createDesiredMetricEvent(getMetricEventName(project, stage, service, objective.SLI, true, i), metricSelector, err, criteria, true))
+		}
+	}
+	return desiredMetricEvents, nil
+}
+
+func createDesiredMetricEvent(name string, metricSelector string, metricSelectorErr error, criteria string, warning bool) desiredMetricEvent {
+	if metricSelectorErr != nil {
+		return desiredMetricEvent{name: name, err: metricSelectorErr}
 	}
 
-	err = createOrUpdateMetricEvent(ctx, client, newMetricEvent)
+	metricEvent, err := createMetricEventForCriteria(name, metricSelector, criteria, warning)
 	if err != nil {
-		log.WithError(err).WithField("metricName", newMetricEvent.Name).Error("Could not create metric event")
-		return nil, fmt.Errorf("could not create metric event: %s", newMetricEvent.Name)
+		return desiredMetricEvent{name: name, err: fmt.Errorf("%s: %w", criteria, err)}
 	}
+	return desiredMetricEvent{name: name, metricEvent: metricEvent}
+}
 
-	log.WithFields(log.Fields{"name": newMetricEvent.Name, "criteria": crit}).Info("Created metric event")
-	return &configResult{
-		Name:    newMetricEvent.Name,
-		Success: true,
-	}, nil
+func getAllCriteria(criteriaGroups []*keptnlib.SLOCriteria) []string {
+	var criteria []string
+	for _, group := range criteriaGroups {
+		criteria = append(criteria, group.Criteria...)
+	}
+	return criteria
 }
 
-func createOrUpdateMetricEvent(ctx context.Context, client *dynatrace.MetricEventsClient, newMetricEvent *dynatrace.MetricEvent) error {
-	existingMetricEvent, err := client.GetMetricEventByName(ctx, newMetricEvent.Name)
+// getMetricEventName returns the name of the metric event for the criteria with the specified index of the pass or warning criteria of the SLI.
+func getMetricEventName(project string, stage string, service string, sli string, warning bool, index int) string {
+	name := sli
+	if warning {
+		name = name + " warning"
+	}
+	if index > 0 {
+		name = name + " #" + strconv.Itoa(index+1)
+	}
+	return name + " (Keptn." + project + "." + stage + "." + service + ")"
+}
+
+// createMetricEventForCriteria maps a single SLO criteria to a metric event.
+// Fixed thresholds are mapped to static thresholds, relative criteria in percent to auto-adaptive thresholds in the same direction with a signal fluctuation derived from the percentage.
+// Violations of pass criteria raise custom alerts, violations of warning criteria only raise info events.
+func createMetricEventForCriteria(name string, metricSelector string, criteria string, warning bool) (*dynatrace.MetricEvent, error) {
+	c, err := parseCriteriaString(criteria)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if existingMetricEvent != nil {
-		// adapt all properties that have initially been defaulted to some value from previous (potentially modified event)
-		existingMetricEvent.Threshold = newMetricEvent.Threshold
-		existingMetricEvent.TagFilters = nil
+	var alertCondition string
+	switch c.Operator {
+	case "<", "<=":
+		alertCondition = dynatrace.MetricEventAlertConditionAbove
+	case ">", ">=":
+		alertCondition = dynatrace.MetricEventAlertConditionBelow
+	default:
+		return nil, errors.New("only criteria with the operators <, <=, > and >= are supported")
+	}
+
+	modelProperties := dynatrace.MetricEventModelProperties{
+		AlertCondition:    alertCondition,
+		Samples:           metricEventSamples,
+		ViolatingSamples:  metricEventViolatingSamples,
+		DealertingSamples: metricEventDealertingSamples,
+	}
 
-		err := client.Update(ctx, existingMetricEvent)
+	description := "Keptn SLI violated: The {metricname} value of {severity} was {alert_condition} your custom threshold of {threshold}."
+	if c.IsComparison || c.CheckPercentage {
+		if c.IsComparison && c.CheckIncrease != (alertCondition == dynatrace.MetricEventAlertConditionAbove) {
+			return nil, errors.New("relative criteria must limit an increase using < or <= or a decrease using > or >=")
+		}
+
+		signalFluctuation, err := getSignalFluctuation(c)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
-		return nil
+		modelProperties.Type = dynatrace.MetricEventModelTypeAutoAdaptiveThreshold
+		modelProperties.SignalFluctuation = signalFluctuation
+		description = "Keptn SLI violated: The {metricname} value of {severity} was {alert_condition} the auto-adaptive threshold of {threshold}."
+	} else {
+		threshold := c.Value
+		modelProperties.Type = dynatrace.MetricEventModelTypeStaticThreshold
+		modelProperties.Threshold = &threshold
+	}
+
+	eventType := dynatrace.MetricEventTypeCustomAlert
+	if warning {
+		eventType = dynatrace.MetricEventTypeInfo
+	}
+
+	return &dynatrace.MetricEvent{
+		Enabled: false,
+		Summary: name,
+		QueryDefinition: dynatrace.MetricEventQueryDefinition{
+			Type:           dynatrace.MetricEventQueryTypeMetricSelector,
+			MetricSelector: metricSelector,
+		},
+		ModelProperties: modelProperties,
+		EventTemplate: dynatrace.MetricEventTemplate{
+			Title:       name,
+			Description: description,
+			EventType:   eventType,
+			DavisMerge:  true,
+		},
+	}, nil
+}
+
+// getSignalFluctuation returns the signal fluctuation of the auto-adaptive threshold for relative criteria in percent.
+// Criteria limiting an absolute change, e.g. <+50, cannot be mapped, as the change cannot be related to the interquartile range of the baseline.
+func getSignalFluctuation(c *criteriaObject) (float64, error) {
+	if !c.IsComparison || !c.CheckPercentage {
+		return 0, errors.New("only relative criteria in percent, e.g. <=+10%, can be mapped to auto-adaptive thresholds")
 	}
 
-	err = client.Create(ctx, newMetricEvent)
+	signalFluctuation := c.Value / metricEventPercentPerSignalFluctuation
+	if signalFluctuation <= 0 || signalFluctuation > metricEventMaxSignalFluctuation {
+		return 0, fmt.Errorf("relative criteria must be greater than 0%% and at most %d%%", metricEventPercentPerSignalFluctuation*metricEventMaxSignalFluctuation)
+	}
+	return signalFluctuation, nil
+}
+
+// getMetricEventMetricSelector returns the metric selector of a metrics SLI query, filtered by its entity selector, if any.
+func getMetricEventMetricSelector(sliQuery string) (string, error) {
+	metricsQuery, err := getMetricsQuery(sliQuery)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	return nil
+	if metricsQuery.GetEntitySelector() == "" {
+		return metricsQuery.GetMetricSelector(), nil
+	}
+
+	return addEntityFilterToMetricSelector(metricsQuery.GetMetricSelector(), metricsQuery.GetEntitySelector())
+}
+
+// addEntityFilterToMetricSelector adds a filter for the entities matching the entity selector directly after the metric key,
+// so that it is applied before any other transformation, e.g. merge, removes the entity dimension.
+func addEntityFilterToMetricSelector(metricSelector string, entitySelector string) (string, error) {
+	entityTypeMatch := entityTypeRegex.FindStringSubmatch(entitySelector)
+	if entityTypeMatch == nil {
+		return "", fmt.Errorf("could not determine entity type of entity selector: %s", entitySelector)
+	}
+	dimension := "dt.entity." + strings.ToLower(entityTypeMatch[1])
+
+	metricKey := getMetricKey(metricSelector)
+	if metricKey == "" {
+		return "", fmt.Errorf("could not determine metric key of metric selector: %s", metricSelector)
+	}
+
+	escapedEntitySelector := strings.NewReplacer(`~`, `~~`, `"`, `~"`).Replace(entitySelector)
+	filter := fmt.Sprintf(`:filter(in("%s",entitySelector("%s")))`, dimension, escapedEntitySelector)
+	return metricKey + filter + strings.TrimPrefix(metricSelector, metricKey), nil
+}
+
+// getMetricKey returns the metric key at the start of a metric selector or an empty string if the metric selector does not start with a metric key.
+func getMetricKey(metricSelector string) string {
+	segments := strings.Split(metricKeyRegex.FindString(metricSelector), ":")
+	if segments[0] == "" {
+		return ""
+	}
+
+	for i := 1; i < len(segments); i++ {
+		if metricTransformations[segments[i]] {
+			return strings.Join(segments[:i], ":")
+		}
+	}
+	return strings.Join(segments, ":")
 }
 
 func parseCriteriaString(criteria string) (*criteriaObject, error) {
@@ -243,163 +445,3 @@ func parseCriteriaString(criteria string) (*criteriaObject, error) {
 
 	return c, nil
 }
-
-var supportedAggregations = [...]string{"avg", "max", "min", "count", "sum", "value", "percentile"}
-
-func createKeptnMetricEventDTO(project string, stage string, service string, metric string, query string, condition string, threshold float64, managementZoneID int64) (*dynatrace.MetricEvent, error) {
-
-	// TODO: 2021-09-20: Check what parts are still needed
-	/*
-		need to map queries used by SLI-service to metric event definition.
-		example: builtin:service.response.time:merge("dt.entity.service"):percentile(90)?scope=tag(keptn_project:$PROJECT),tag(keptn_stage:$STAGE),tag(keptn_service:$SERVICE),tag(keptn_deployment:$DEPLOYMENT)
-
-		1. split by '?' and get first part => builtin:service.response.time:merge("dt.entity.service"):percentile(90)
-		2. split by ':' => builtin:service.response.time | merge("dt.entity.service") | percentile(90) => merge("dt.entity.service") is not needed
-		3. first part is the metricId and can be used for the Metric Event API => builtin:service.response.time
-		4. Aggregation is limited to: AVG, COUNT, MAX, MEDIAN, MIN, OF_INTEREST, OF_INTEREST_RATIO, OTHER, OTHER_RATIO, P90, SUM, VALUE
-	*/
-
-	if project == "" || stage == "" || service == "" || metric == "" || query == "" {
-		return nil, errors.New("missing input parameter values")
-	}
-
-	query = strings.TrimPrefix(query, "metricSelector=")
-	// 1. split by '?' and get first part => builtin:service.response.time:merge("dt.entity.service"):percentile(90)
-	split := strings.Split(query, "?")
-
-	// 2. split by ':' => builtin:service.response.time | merge("dt.entity.service") | percentile(90) => merge("dt.entity.service") is not needed/supported by MetricEvent API
-	splittedQuery := strings.Split(split[0], ":")
-
-	if len(splittedQuery) < 2 {
-		return nil, errors.New("invalid metricId")
-	}
-	metricId := splittedQuery[0] + ":" + splittedQuery[1]
-	meAggregation := ""
-	for _, transformation := range splittedQuery {
-		isSupportedAggregation := false
-		for _, aggregationType := range supportedAggregations {
-			if strings.Contains(strings.ToLower(transformation), aggregationType) {
-				isSupportedAggregation = true
-			}
-		}
-
-		if isSupportedAggregation {
-			meAggregation = getMetricEventAggregation(transformation)
-
-			/*
-				if meAggregation == "" {
-					return nil, errors.New("unsupported aggregation type: " + transformation)
-				}
-
-			*/
-		}
-	}
-	/*
-		if meAggregation == "" {
-			return nil, errors.New("no aggregation provided in query")
-		}
-	*/
-
-	meAlertCondition, err := parseAlertCondition(condition)
-	if err != nil {
-		return nil, err
-	}
-
-	metricEvent := &dynatrace.MetricEvent{
-		Metadata:          dynatrace.MEMetadata{},
-		MetricID:          metricId,
-		Name:              metric + " (Keptn." + project + "." + stage + "." + service + ")",
-		Description:       "Keptn SLI violated: The {metricname} value of {severity} was {alert_condition} your custom threshold of {threshold}.",
-		EventType:         "CUSTOM_ALERT",
-		Severity:          "CUSTOM_ALERT",
-		AlertCondition:    meAlertCondition,
-		Samples:           5, // taken from default value of custom metric events
-		ViolatingSamples:  3, // taken from default value of custom metric events
-		DealertingSamples: 5, // taken from default value of custom metric events
-		Threshold:         threshold,
-		Enabled:           false,
-		TagFilters:        nil, // not used anymore by MetricEvents API, replaced by AlertingScope
-		AlertingScope: []dynatrace.MEAlertingScope{
-			// LIMITATION: currently only a maximum of 3 tag filters is supported
-			{
-				FilterType:       "MANAGEMENT_ZONE",
-				ManagementZoneID: managementZoneID,
-			},
-			{
-				FilterType: "TAG",
-				TagFilter: &dynatrace.METagFilter{
-					Context: "CONTEXTLESS",
-					Key:     keptnService,
-					Value:   service,
-				},
-			},
-			{
-				FilterType: "TAG",
-				TagFilter: &dynatrace.METagFilter{
-					Context: "CONTEXTLESS",
-					Key:     keptnDeployment,
-					Value:   "primary",
-				},
-			},
-		},
-	}
-
-	// LIMITATION: currently we do not have the possibility of specifying units => assume MILLI_SECONDS for response time metrics
-	if strings.Contains(metric, "time") {
-		metricEvent.Unit = "MILLI_SECOND"
-	}
-
-	if meAggregation != "" {
-		metricEvent.AggregationType = meAggregation
-	}
-
-	return metricEvent, nil
-}
-
-func parseAlertCondition(condition string) (string, error) {
-	meAlertCondition := ""
-	if strings.Contains(condition, "+") || strings.Contains(condition, "-") || strings.Contains(condition, "%") {
-		return "", errors.New("unsupported condition. only fixed thresholds are supported")
-	}
-
-	if strings.Contains(condition, ">") {
-		meAlertCondition = "BELOW"
-	} else if strings.Contains(condition, "<") {
-		meAlertCondition = "ABOVE"
-	} else {
-		return "", errors.New("unsupported condition. only fixed thresholds are supported")
-	}
-	return meAlertCondition, nil
-}
-
-func getMetricEventAggregation(metricAPIAgg string) string {
-	// LIMITATION: currently, only single aggregations are supported, so, e.g. not (min,max)
-	metricAPIAgg = strings.ToLower(metricAPIAgg)
-
-	if strings.Contains(metricAPIAgg, "percentile") {
-		// only MEDIAN and P90 are supported for MetricEvents
-		// => if the percentile in the query is >= 90, use P90, otherwise assume MEDIAN
-		if strings.Contains(metricAPIAgg, "(9") {
-			return "P90"
-		} else {
-			return "MEDIAN"
-		}
-	}
-	// due to incompatibilities between metrics and metric event API it's safer to not pass an aggregation in the MetricEvent definition in most cases
-	// the Metric Event API will default it to an appropriate aggregation
-	/*else if strings.Contains(metricAPIAgg, "min") {
-		return "MIN"
-	} else if strings.Contains(metricAPIAgg, "max") {
-		return "MAX"
-	} else if strings.Contains(metricAPIAgg, "count") {
-		return "COUNT"
-	} else if strings.Contains(metricAPIAgg, "sum") {
-		return "SUM"
-	} else if strings.Contains(metricAPIAgg, "value") {
-		return "VALUE"
-	} else if strings.Contains(metricAPIAgg, "avg") {
-		return "AVG"
-	}
-	*/
-	return ""
-}
@@ -1,122 +1,309 @@
 package monitoring
 
-import "testing"
+import (
+	"context"
+	"encoding/json"
+	"testing"
 
-func Test_getAlertCondition(t *testing.T) {
-	type args struct {
-		condition string
+	keptnlib "github.com/keptn/go-utils/pkg/lib"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+	"github.com/keptn-contrib/dynatrace-service/internal/test"
+)
+
+const testMetricEventsSettingsObjectsURL = "/api/v2/settings/objects?fields=objectId%2Cvalue&pageSize=500&schemaIds=builtin%3Aanomaly-detection.metric-events&scopes=environment"
+
+const testResponseTimeP95MetricSelector = `builtin:service.response.time:filter(in("dt.entity.service",entitySelector("type(SERVICE),tag(keptn_project:sockshop),tag(keptn_stage:dev),tag(keptn_service:carts),tag(keptn_deployment:primary)"))):merge("dt.entity.service"):percentile(95)`
+
+func createMetricEventsReader() *sliAndSLOReaderMock {
+	return &sliAndSLOReaderMock{
+		slis: map[string]string{},
+		slos: &keptnlib.ServiceLevelObjectives{
+			Objectives: []*keptnlib.SLO{
+				{SLI: "response_time_p95", Pass: newSLOCriteria("<=600"), Warning: newSLOCriteria("<=800")},
+				{SLI: "error_rate", Pass: newSLOCriteria("<=+10%")},
+				{SLI: "throughput", Pass: newSLOCriteria("=0")},
+			},
+		},
 	}
+}
+
+func createMetricEventsHandler(t *testing.T) *test.FileBasedURLHandlerWithSink {
+	handler := test.NewFileBasedURLHandlerWithSink(t)
+	handler.AddExact("/api/config/v1/managementZones", "./testdata/cleanup/management_zones.json")
+	handler.AddExact(testMetricEventsSettingsObjectsURL, "./testdata/metric_events/metric_events.json")
+	handler.AddExact("/api/v2/settings/objects", "./testdata/metric_events/created.json")
+	handler.AddExact("/api/v2/settings/objects/me-1", "./testdata/metric_events/metric_event_1.json")
+	return handler
+}
+
+// TestMetricEventCreation_Create tests that metric events are created or updated for all pass and warning criteria and that unsupported criteria are reported.
+func TestMetricEventCreation_Create(t *testing.T) {
+	handler := createMetricEventsHandler(t)
+	dtClient, teardown := createDynatraceClient(t, handler)
+	defer teardown()
+
+	results := newMetricEventCreation(dtClient, nil, createMetricEventsReader()).create(context.Background(), "sockshop", "dev", "carts")
+	assert.Equal(t, []configResult{
+		{Name: "response_time_p95 (Keptn.sockshop.dev.carts)", Success: true},
+		{Name: "response_time_p95 warning (Keptn.sockshop.dev.carts)", Success: true},
+		{Name: "error_rate (Keptn.sockshop.dev.carts)", Success: true},
+		{Name: "throughput (Keptn.sockshop.dev.carts)", Success: false, Message: "Unsupported criteria: =0: only criteria with the operators <, <=, > and >= are supported"},
+	}, results)
+
+	var createdSettingsObjects []dynatrace.SettingsObject
+	handler.GetStoredPayloadForURL("/api/v2/settings/objects", &createdSettingsObjects)
+	require.Len(t, createdSettingsObjects, 1)
+	assert.Equal(t, "builtin:anomaly-detection.metric-events", createdSettingsObjects[0].SchemaID)
+
+	assert.Equal(t, &dynatrace.MetricEvent{
+		Summary: "response_time_p95 warning (Keptn.sockshop.dev.carts)",
+		QueryDefinition: dynatrace.MetricEventQueryDefinition{
+			Type:           "METRIC_SELECTOR",
+			MetricSelector: testResponseTimeP95MetricSelector,
+			ManagementZone: "2",
+		},
+		ModelProperties: dynatrace.MetricEventModelProperties{
+			Type:              "STATIC_THRESHOLD",
+			Threshold:         newFloat(800),
+			AlertCondition:    "ABOVE",
+			Samples:           5,
+			ViolatingSamples:  3,
+			DealertingSamples: 5,
+		},
+		EventTemplate: dynatrace.MetricEventTemplate{
+			Title:       "response_time_p95 warning (Keptn.sockshop.dev.carts)",
+			Description: "Keptn SLI violated: The {metricname} value of {severity} was {alert_condition} your custom threshold of {threshold}.",
+			EventType:   "INFO",
+			DavisMerge:  true,
+		},
+	}, getSettingsObjectMetricEvent(t, createdSettingsObjects[0]))
+
+	var updatedSettingsObject dynatrace.SettingsObject
+	handler.GetStoredPayloadForURL("/api/v2/settings/objects/me-1", &updatedSettingsObject)
+	updatedMetricEvent := getSettingsObjectMetricEvent(t, updatedSettingsObject)
+	assert.Equal(t, "AUTO_ADAPTIVE_THRESHOLD", updatedMetricEvent.ModelProperties.Type)
+	assert.Nil(t, updatedMetricEvent.ModelProperties.Threshold)
+}
+
+// TestMetricEventCreation_Diff tests that only missing or modified metric events and unsupported criteria are reported.
+func TestMetricEventCreation_Diff(t *testing.T) {
+	handler := createMetricEventsHandler(t)
+	dtClient, teardown := createDynatraceClient(t, handler)
+	defer teardown()
+
+	changes, err := newMetricEventCreation(dtClient, nil, createMetricEventsReader()).diff(context.Background(), "sockshop", "dev", "carts", nil)
+	require.NoError(t, err)
+	assert.Equal(t, []configChange{
+		{Name: "response_time_p95 (Keptn.sockshop.dev.carts)", Action: changeActionCreate},
+		{Name: "response_time_p95 warning (Keptn.sockshop.dev.carts)", Action: changeActionCreate},
+		{Name: "throughput (Keptn.sockshop.dev.carts)", Action: changeActionUnsupported, Message: "Unsupported criteria: =0: only criteria with the operators <, <=, > and >= are supported"},
+	}, changes)
+}
+
+// TestMetricEventCreation_Diff_PlannedManagementZone tests that existing metric events are reported as updated if the management zone of the stage is only planned, as they would be scoped to the new management zone.
+func TestMetricEventCreation_Diff_PlannedManagementZone(t *testing.T) {
+	handler := test.NewFileBasedURLHandlerWithSink(t)
+	handler.AddExact("/api/config/v1/managementZones", "./testdata/metric_events/management_zones_without_dev.json")
+	handler.AddExact(testMetricEventsSettingsObjectsURL, "./testdata/metric_events/metric_events.json")
+	dtClient, teardown := createDynatraceClient(t, handler)
+	defer teardown()
+
+	changes, err := newMetricEventCreation(dtClient, nil, createMetricEventsReader()).diff(context.Background(), "sockshop", "dev", "carts", nil)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+
+	changes, err = newMetricEventCreation(dtClient, nil, createMetricEventsReader()).diff(context.Background(), "sockshop", "dev", "carts", map[string]bool{"Keptn: sockshop dev": true})
+	require.NoError(t, err)
+	assert.Equal(t, []configChange{
+		{Name: "response_time_p95 (Keptn.sockshop.dev.carts)", Action: changeActionCreate},
+		{Name: "response_time_p95 warning (Keptn.sockshop.dev.carts)", Action: changeActionCreate},
+		{Name: "error_rate (Keptn.sockshop.dev.carts)", Action: changeActionUpdate},
+		{Name: "throughput (Keptn.sockshop.dev.carts)", Action: changeActionUnsupported, Message: "Unsupported criteria: =0: only criteria with the operators <, <=, > and >= are supported"},
+	}, changes)
+}
+
+func getSettingsObjectMetricEvent(t *testing.T, settingsObject dynatrace.SettingsObject) *dynatrace.MetricEvent {
+	metricEvent := &dynatrace.MetricEvent{}
+	require.NoError(t, json.Unmarshal(settingsObject.Value, metricEvent))
+	return metricEvent
+}
+
+func TestCreateMetricEventForCriteria(t *testing.T) {
 	tests := []struct {
-		name    string
-		args    args
-		want    string
-		wantErr bool
+		name                      string
+		criteria                  string
+		warning                   bool
+		expectError               bool
+		expectedModelType         string
+		expectedAlertCondition    string
+		expectedThreshold         *float64
+		expectedSignalFluctuation float64
+		expectedEventType         string
 	}{
 		{
-			name: "Expect ABOVE condition",
-			args: args{
-				condition: "<10",
-			},
-			want:    "ABOVE",
-			wantErr: false,
+			name:                   "upper static threshold",
+			criteria:               "<=600",
+			expectedModelType:      "STATIC_THRESHOLD",
+			expectedAlertCondition: "ABOVE",
+			expectedThreshold:      newFloat(600),
+			expectedEventType:      "CUSTOM_ALERT",
 		},
 		{
-			name: "Expect BELOW condition",
-			args: args{
-				condition: ">10",
-			},
-			want:    "BELOW",
-			wantErr: false,
+			name:                   "lower static threshold",
+			criteria:               "> 95",
+			expectedModelType:      "STATIC_THRESHOLD",
+			expectedAlertCondition: "BELOW",
+			expectedThreshold:      newFloat(95),
+			expectedEventType:      "CUSTOM_ALERT",
 		},
 		{
-			name: "Expect error",
-			args: args{
-				condition: ">+10",
-			},
-			want:    "",
-			wantErr: true,
+			name:                   "warning",
+			criteria:               "<800",
+			warning:                true,
+			expectedModelType:      "STATIC_THRESHOLD",
+			expectedAlertCondition: "ABOVE",
+			expectedThreshold:      newFloat(800),
+			expectedEventType:      "INFO",
 		},
 		{
-			name: "Expect error 2",
-			args: args{
-				condition: ">-10",
-			},
-			want:    "",
-			wantErr: true,
+			name:                      "relative increase",
+			criteria:                  "<=+10%",
+			expectedModelType:         "AUTO_ADAPTIVE_THRESHOLD",
+			expectedAlertCondition:    "ABOVE",
+			expectedSignalFluctuation: 1,
+			expectedEventType:         "CUSTOM_ALERT",
 		},
 		{
-			name: "Expect error 3",
-			args: args{
-				condition: ">10%",
-			},
-			want:    "",
-			wantErr: true,
+			name:                      "larger relative increase",
+			criteria:                  "<=+50%",
+			expectedModelType:         "AUTO_ADAPTIVE_THRESHOLD",
+			expectedAlertCondition:    "ABOVE",
+			expectedSignalFluctuation: 5,
+			expectedEventType:         "CUSTOM_ALERT",
+		},
+		{
+			name:                      "relative decrease",
+			criteria:                  ">=-5%",
+			expectedModelType:         "AUTO_ADAPTIVE_THRESHOLD",
+			expectedAlertCondition:    "BELOW",
+			expectedSignalFluctuation: 0.5,
+			expectedEventType:         "CUSTOM_ALERT",
+		},
+		{
+			name:        "absolute increase",
+			criteria:    "<+50",
+			expectError: true,
+		},
+		{
+			name:        "relative increase above maximum signal fluctuation",
+			criteria:    "<=+150%",
+			expectError: true,
+		},
+		{
+			name:        "relative increase of zero",
+			criteria:    "<=+0%",
+			expectError: true,
+		},
+		{
+			name:        "relative criteria requiring a decrease",
+			criteria:    "<=-10%",
+			expectError: true,
+		},
+		{
+			name:        "equality",
+			criteria:    "=0",
+			expectError: true,
+		},
+		{
+			name:        "invalid criteria",
+			criteria:    "foo",
+			expectError: true,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := parseAlertCondition(tt.args.condition)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("parseAlertCondition() error = %v, wantErr %v", err, tt.wantErr)
+			metricEvent, err := createMetricEventForCriteria("metric (Keptn.sockshop.dev.carts)", "builtin:service.response.time", tt.criteria, tt.warning)
+			if tt.expectError {
+				assert.Error(t, err)
 				return
 			}
-			if got != tt.want {
-				t.Errorf("parseAlertCondition() got = %v, want %v", got, tt.want)
-			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedModelType, metricEvent.ModelProperties.Type)
+			assert.Equal(t, tt.expectedAlertCondition, metricEvent.ModelProperties.AlertCondition)
+			assert.Equal(t, tt.expectedThreshold, metricEvent.ModelProperties.Threshold)
+			assert.Equal(t, tt.expectedSignalFluctuation, metricEvent.ModelProperties.SignalFluctuation)
+			assert.Equal(t, tt.expectedEventType, metricEvent.EventTemplate.EventType)
 		})
 	}
 }
 
-func Test_getMetricEventAggregation(t *testing.T) {
-	type args struct {
-		metricAPIAgg string
-	}
+func TestGetMetricEventMetricSelector(t *testing.T) {
 	tests := []struct {
-		name string
-		args args
-		want string
+		name                   string
+		query                  string
+		expectError            bool
+		expectedMetricSelector string
 	}{
 		{
-			name: "Expect P90",
-			args: args{
-				metricAPIAgg: "percentile(90.0)",
-			},
-			want: "P90",
+			name:                   "entity filter is added before merge",
+			query:                  `metricSelector=builtin:service.response.time:merge("dt.entity.service"):percentile(95)&entitySelector=type(SERVICE),tag(keptn_project:sockshop),tag(keptn_stage:dev),tag(keptn_service:carts),tag(keptn_deployment:primary)`,
+			expectedMetricSelector: testResponseTimeP95MetricSelector,
 		},
 		{
-			name: "Expect P90 2",
-			args: args{
-				metricAPIAgg: "percentile(95)",
-			},
-			want: "P90",
+			name:                   "without transformations",
+			query:                  `metricSelector=calc:service.successrate&entitySelector=type("SERVICE"),tag("keptn_service:carts")`,
+			expectedMetricSelector: `calc:service.successrate:filter(in("dt.entity.service",entitySelector("type(~"SERVICE~"),tag(~"keptn_service:carts~")")))`,
 		},
 		{
-			name: "Expect MEDIAN",
-			args: args{
-				metricAPIAgg: "percentile(10)",
-			},
-			want: "MEDIAN",
+			name:                   "without entity selector",
+			query:                  `metricSelector=builtin:host.cpu.usage:avg`,
+			expectedMetricSelector: `builtin:host.cpu.usage:avg`,
 		},
 		{
-			name: "Expect empty string",
-			args: args{
-				metricAPIAgg: "avg",
-			},
-			want: "",
+			name:                   "legacy query",
+			query:                  `builtin:service.errors.total.rate:merge("dt.entity.service"):avg?scope=tag(keptn_service:carts)`,
+			expectedMetricSelector: `builtin:service.errors.total.rate:filter(in("dt.entity.service",entitySelector("tag(keptn_service:carts),type(SERVICE)"))):merge("dt.entity.service"):avg`,
 		},
 		{
-			name: "Expect empty string 2",
-			args: args{
-				metricAPIAgg: "foo",
-			},
-			want: "",
+			name:        "entity selector without type",
+			query:       `metricSelector=builtin:service.response.time:avg&entitySelector=entityId(SERVICE-123)`,
+			expectError: true,
+		},
+		{
+			name:        "metric selector without metric key",
+			query:       `metricSelector=(builtin:service.response.time:avg):max&entitySelector=type(SERVICE)`,
+			expectError: true,
+		},
+		{
+			name:        "not a metrics query",
+			query:       "PV2;problemSelector=status(open)",
+			expectError: true,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := getMetricEventAggregation(tt.args.metricAPIAgg); got != tt.want {
-				t.Errorf("getMetricEventAggregation() = %v, want %v", got, tt.want)
+			metricSelector, err := getMetricEventMetricSelector(tt.query)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
 			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedMetricSelector, metricSelector)
 		})
 	}
 }
+
+func TestGetMetricEventName(t *testing.T) {
+	assert.Equal(t, "response_time_p95 (Keptn.sockshop.dev.carts)", getMetricEventName("sockshop", "dev", "carts", "response_time_p95", false, 0))
+	assert.Equal(t, "response_time_p95 #2 (Keptn.sockshop.dev.carts)", getMetricEventName("sockshop", "dev", "carts", "response_time_p95", false, 1))
+	assert.Equal(t, "response_time_p95 warning (Keptn.sockshop.dev.carts)", getMetricEventName("sockshop", "dev", "carts", "response_time_p95", true, 0))
+}
+
+func newFloat(value float64) *float64 {
+	return &value
+}
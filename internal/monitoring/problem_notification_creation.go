@@ -4,112 +4,290 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/keptn-contrib/dynatrace-service/internal/config"
 	"github.com/keptn-contrib/dynatrace-service/internal/credentials"
 	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
 
 	log "github.com/sirupsen/logrus"
 )
 
+const keptnAlertingProfileName = "Keptn"
+
+const (
+	alertingProfileIncludeModeNone       = "NONE"
+	alertingProfileIncludeModeIncludeAny = "INCLUDE_ANY"
+)
+
+// defaultAlertingProfileSeverityLevels are the severity levels included by an alerting profile without configured rules
+var defaultAlertingProfileSeverityLevels = []string{"AVAILABILITY", "ERROR", "PERFORMANCE", "RESOURCE_CONTENTION", "CUSTOM_ALERT", "MONITORING_UNAVAILABLE"}
+
+// desiredProblemNotification describes a problem notification forwarding the problems selected by its alerting profile to a Keptn project
+type desiredProblemNotification struct {
+	name            string
+	project         string
+	payload         string
+	alertingProfile *dynatrace.AlertingProfile
+}
+
 type problemNotificationCreation struct {
-	client                   dynatrace.ClientInterface
+	alertingProfilesClient   dynatrace.AlertingProfilesClientInterface
+	notificationsClient      dynatrace.NotificationsClientInterface
 	keptnCredentialsProvider credentials.KeptnCredentialsProvider
+	notificationsConfig      *config.ProblemNotificationsConfig
 }
 
-func newProblemNotificationCreation(client dynatrace.ClientInterface, keptnCredentialsProvider credentials.KeptnCredentialsProvider) *problemNotificationCreation {
+func newProblemNotificationCreation(alertingProfilesClient dynatrace.AlertingProfilesClientInterface, notificationsClient dynatrace.NotificationsClientInterface, keptnCredentialsProvider credentials.KeptnCredentialsProvider, notificationsConfig *config.ProblemNotificationsConfig) *problemNotificationCreation {
 	return &problemNotificationCreation{
-		client:                   client,
+		alertingProfilesClient:   alertingProfilesClient,
+		notificationsClient:      notificationsClient,
 		keptnCredentialsProvider: keptnCredentialsProvider,
+		notificationsConfig:      notificationsConfig,
 	}
 }
 
-// create sets up/updates the DT problem notification and returns it.
-func (pn *problemNotificationCreation) create(ctx context.Context, project string) *configResult {
+// create sets up/updates the alerting profiles and problem notifications for the project.
+// Keptn problem notifications that are not configured for the project, e.g. those of other projects, are retained.
+func (pn *problemNotificationCreation) create(ctx context.Context, project string) []configResult {
 	log.Info("Setting up problem notifications in Dynatrace Tenant")
 
-	alertingProfileID, err := getOrCreateKeptnAlertingProfile(ctx, dynatrace.NewAlertingProfilesClient(pn.client))
+	desiredNotifications := getDesiredProblemNotifications(project, pn.notificationsConfig)
+
+	keptnCredentials, err := pn.keptnCredentialsProvider.GetKeptnCredentials(ctx)
 	if err != nil {
-		log.WithError(err).Error("Failed to set up problem notification")
-		return &configResult{
-			Success: false,
-			Message: "failed to set up problem notification: " + err.Error(),
+		log.WithError(err).Error("Failed to retrieve Keptn API credentials")
+		results := make([]configResult, 0, len(desiredNotifications))
+		for _, desired := range desiredNotifications {
+			results = append(results, configResult{
+				Name:    desired.name,
+				Success: false,
+				Message: "failed to retrieve Keptn API credentials: " + err.Error(),
+			})
 		}
+		return results
 	}
 
-	notificationsClient := dynatrace.NewNotificationsClient(pn.client)
-	err = notificationsClient.DeleteExistingKeptnProblemNotifications(ctx)
-	if err != nil {
-		log.WithError(err).Error("Failed to delete existing notifications")
+	results := make([]configResult, 0, len(desiredNotifications))
+	for _, desired := range desiredNotifications {
+		results = append(results, pn.createProblemNotification(ctx, desired, keptnCredentials))
 	}
+	return results
+}
 
-	keptnCredentials, err := pn.keptnCredentialsProvider.GetKeptnCredentials(ctx)
+// createProblemNotification sets up/updates the alerting profile and problem notification.
+func (pn *problemNotificationCreation) createProblemNotification(ctx context.Context, desired desiredProblemNotification, keptnCredentials *credentials.KeptnCredentials) configResult {
+	alertingProfileID, err := pn.getOrCreateAlertingProfile(ctx, desired.alertingProfile)
 	if err != nil {
-		log.WithError(err).Error("Failed to retrieve Keptn API credentials")
-		return &configResult{
+		log.WithError(err).Error("Failed to set up alerting profile")
+		return configResult{
+			Name:    desired.name,
 			Success: false,
-			Message: "failed to retrieve Keptn API credentials: " + err.Error(),
+			Message: "failed to set up alerting profile: " + err.Error(),
 		}
 	}
 
-	err = notificationsClient.Create(ctx, keptnCredentials, alertingProfileID, project)
+	notification := dynatrace.NewKeptnProblemNotification(desired.name, keptnCredentials, alertingProfileID, desired.project, desired.payload)
+	err = pn.createOrUpdateNotification(ctx, notification)
 	if err != nil {
-		log.WithError(err).Error("Failed to create problem notification")
-		return &configResult{
+		log.WithError(err).Error("Failed to set up problem notification")
+		return configResult{
+			Name:    desired.name,
 			Success: false,
 			Message: "failed to set up problem notification: " + err.Error(),
 		}
 	}
 
-	return &configResult{
+	return configResult{
+		Name:    desired.name,
 		Success: true,
-		Message: "Successfully set up Keptn Alerting Profile and Problem Notifications",
+		Message: "Successfully set up alerting profile " + desired.alertingProfile.DisplayName + " and problem notification",
+	}
+}
+
+// createOrUpdateNotification creates the problem notification or updates the existing one with the same name. Further notifications with the same name are deleted.
+func (pn *problemNotificationCreation) createOrUpdateNotification(ctx context.Context, notification *dynatrace.Notification) error {
+	ids, err := pn.notificationsClient.GetIDsByName(ctx, notification.Name)
+	if err != nil {
+		return err
+	}
+
+	if len(ids) == 0 {
+		return pn.notificationsClient.Create(ctx, notification)
+	}
+
+	err = pn.notificationsClient.Update(ctx, ids[0], notification)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids[1:] {
+		err = pn.notificationsClient.DeleteByID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to delete notification with ID: %s: %w", id, err)
+		}
 	}
+	return nil
 }
 
-func getOrCreateKeptnAlertingProfile(ctx context.Context, alertingProfilesClient *dynatrace.AlertingProfilesClient) (string, error) {
-	log.Info("Checking Keptn alerting profile availability")
-	alertingProfileID, err := alertingProfilesClient.GetProfileID(ctx, "Keptn")
+// getOrCreateAlertingProfile returns the ID of the alerting profile with the name of the desired one, which is created if it does not exist or updated if it differs.
+func (pn *problemNotificationCreation) getOrCreateAlertingProfile(ctx context.Context, desired *dynatrace.AlertingProfile) (string, error) {
+	log.WithField("name", desired.DisplayName).Info("Checking alerting profile availability")
+	alertingProfileID, err := pn.alertingProfilesClient.GetProfileID(ctx, desired.DisplayName)
 	if err != nil {
-		log.WithError(err).Error("Could not get alerting profiles")
+		return "", fmt.Errorf("could not get alerting profiles: %w", err)
 	}
-	if alertingProfileID != "" {
-		log.Info("Keptn alerting profile available")
-		return alertingProfileID, nil
+
+	if alertingProfileID == "" {
+		log.WithField("name", desired.DisplayName).Info("Creating alerting profile")
+		profileID, err := pn.alertingProfilesClient.Create(ctx, desired)
+		if err != nil {
+			return "", fmt.Errorf("failed to create alerting profile %s: %w", desired.DisplayName, err)
+		}
+		return profileID, nil
 	}
 
-	log.Info("Creating Keptn alerting profile")
-	alertingProfile := createKeptnAlertingProfile()
-	profileID, err := alertingProfilesClient.Create(ctx, alertingProfile)
+	actual, err := pn.alertingProfilesClient.GetByID(ctx, alertingProfileID)
 	if err != nil {
-		return "", fmt.Errorf("failed to create Keptn alerting profile: %v", err)
+		return "", err
 	}
 
-	log.Info("Alerting profile created successfully")
-	return profileID, nil
+	if !isJSONSubset(desired, actual) {
+		log.WithField("name", desired.DisplayName).Info("Updating alerting profile")
+		desired.ID = alertingProfileID
+		err = pn.alertingProfilesClient.Update(ctx, alertingProfileID, desired)
+		if err != nil {
+			return "", fmt.Errorf("failed to update alerting profile %s: %w", desired.DisplayName, err)
+		}
+	}
+	return alertingProfileID, nil
 }
 
-func createKeptnAlertingProfile() *dynatrace.AlertingProfile {
-	return &dynatrace.AlertingProfile{
-		Metadata:    dynatrace.AlertingProfileMetadata{},
-		DisplayName: "Keptn",
-		Rules: []dynatrace.AlertingProfileRules{
-			createAlertingProfileRule("AVAILABILITY"),
-			createAlertingProfileRule("ERROR"),
-			createAlertingProfileRule("PERFORMANCE"),
-			createAlertingProfileRule("RESOURCE_CONTENTION"),
-			createAlertingProfileRule("CUSTOM_ALERT"),
-			createAlertingProfileRule("MONITORING_UNAVAILABLE"),
-		},
+// diff returns the changes to the alerting profiles and problem notifications that would be performed.
+func (pn *problemNotificationCreation) diff(ctx context.Context, project string) *entityDiff {
+	diff := &entityDiff{}
+
+	checkedAlertingProfiles := map[string]bool{}
+	for _, desired := range getDesiredProblemNotifications(project, pn.notificationsConfig) {
+		if !checkedAlertingProfiles[desired.alertingProfile.DisplayName] {
+			checkedAlertingProfiles[desired.alertingProfile.DisplayName] = true
+
+			change, err := pn.diffAlertingProfile(ctx, desired.alertingProfile)
+			if err != nil {
+				diff.Error = err
+				return diff
+			}
+			if change != nil {
+				diff.Changes = append(diff.Changes, *change)
+			}
+		}
+
+		notificationIDs, err := pn.notificationsClient.GetIDsByName(ctx, desired.name)
+		if err != nil {
+			diff.Error = err
+			return diff
+		}
+
+		if len(notificationIDs) == 0 {
+			diff.Changes = append(diff.Changes, configChange{Name: desired.name, Action: changeActionCreate})
+			continue
+		}
+
+		// the notification is always updated, as the Keptn API token cannot be compared
+		diff.Changes = append(diff.Changes, configChange{Name: desired.name, Action: changeActionUpdate})
+		for _, id := range notificationIDs[1:] {
+			diff.Changes = append(diff.Changes, configChange{Name: desired.name + " (" + id + ")", Action: changeActionDelete})
+		}
+	}
+
+	return diff
+}
+
+func (pn *problemNotificationCreation) diffAlertingProfile(ctx context.Context, desired *dynatrace.AlertingProfile) (*configChange, error) {
+	alertingProfileID, err := pn.alertingProfilesClient.GetProfileID(ctx, desired.DisplayName)
+	if err != nil {
+		return nil, err
+	}
+
+	if alertingProfileID == "" {
+		return &configChange{Name: "Alerting profile " + desired.DisplayName, Action: changeActionCreate}, nil
+	}
+
+	actual, err := pn.alertingProfilesClient.GetByID(ctx, alertingProfileID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isJSONSubset(desired, actual) {
+		return &configChange{Name: "Alerting profile " + desired.DisplayName, Action: changeActionUpdate}, nil
+	}
+	return nil, nil
+}
+
+// getDesiredProblemNotifications returns the problem notifications configured for the project.
+// If none are configured, a single notification forwarding all problems selected by the Keptn alerting profile to the project is returned.
+func getDesiredProblemNotifications(project string, notificationsConfig *config.ProblemNotificationsConfig) []desiredProblemNotification {
+	notificationConfigs := notificationsConfig.GetNotifications()
+	if len(notificationConfigs) == 0 {
+		notificationConfigs = []config.ProblemNotificationConfig{{}}
+	}
+
+	desiredNotifications := make([]desiredProblemNotification, 0, len(notificationConfigs))
+	for _, notificationConfig := range notificationConfigs {
+		targetProject := notificationConfig.Project
+		if targetProject == "" {
+			targetProject = project
+		}
+
+		desiredNotifications = append(desiredNotifications, desiredProblemNotification{
+			name:            dynatrace.GetKeptnProblemNotificationName(notificationConfig.Name),
+			project:         targetProject,
+			payload:         notificationConfig.Payload,
+			alertingProfile: createAlertingProfile(notificationConfig.AlertingProfile),
+		})
+	}
+	return desiredNotifications
+}
+
+// createAlertingProfile returns the alerting profile for the configuration, which may be nil.
+// Without name or rules, the name of the Keptn alerting profile or rules for all default severity levels are used.
+func createAlertingProfile(alertingProfileConfig *config.AlertingProfileConfig) *dynatrace.AlertingProfile {
+	alertingProfile := &dynatrace.AlertingProfile{
+		Metadata:         dynatrace.AlertingProfileMetadata{},
+		DisplayName:      keptnAlertingProfileName,
 		ManagementZoneID: nil,
 	}
+
+	if alertingProfileConfig != nil && alertingProfileConfig.Name != "" {
+		alertingProfile.DisplayName = alertingProfileConfig.Name
+	}
+
+	if alertingProfileConfig == nil || len(alertingProfileConfig.Rules) == 0 {
+		for _, severityLevel := range defaultAlertingProfileSeverityLevels {
+			alertingProfile.Rules = append(alertingProfile.Rules, createAlertingProfileRule(config.AlertingProfileRuleConfig{SeverityLevel: severityLevel}))
+		}
+		return alertingProfile
+	}
+
+	for _, ruleConfig := range alertingProfileConfig.Rules {
+		alertingProfile.Rules = append(alertingProfile.Rules, createAlertingProfileRule(ruleConfig))
+	}
+	return alertingProfile
 }
 
-func createAlertingProfileRule(severityLevel string) dynatrace.AlertingProfileRules {
+func createAlertingProfileRule(ruleConfig config.AlertingProfileRuleConfig) dynatrace.AlertingProfileRules {
+	includeMode := ruleConfig.IncludeMode
+	if includeMode == "" {
+		includeMode = alertingProfileIncludeModeNone
+		if len(ruleConfig.Tags) > 0 {
+			includeMode = alertingProfileIncludeModeIncludeAny
+		}
+	}
+
 	return dynatrace.AlertingProfileRules{
-		SeverityLevel: severityLevel,
+		SeverityLevel: ruleConfig.SeverityLevel,
 		TagFilter: dynatrace.AlertingProfileTagFilter{
-			IncludeMode: "NONE",
-			TagFilters:  nil,
+			IncludeMode: includeMode,
+			TagFilters:  ruleConfig.Tags,
 		},
-		DelayInMinutes: 0,
+		DelayInMinutes: ruleConfig.DelayInMinutes,
 	}
 }
@@ -0,0 +1,138 @@
+package monitoring
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/config"
+	"github.com/keptn-contrib/dynatrace-service/internal/credentials"
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+	"github.com/keptn-contrib/dynatrace-service/internal/test"
+)
+
+type keptnCredentialsProviderMock struct{}
+
+func (m *keptnCredentialsProviderMock) GetKeptnCredentials(_ context.Context) (*credentials.KeptnCredentials, error) {
+	return credentials.NewKeptnCredentials("https://keptn.example.com/api", "token", "")
+}
+
+func createProblemNotificationsConfig() *config.ProblemNotificationsConfig {
+	return &config.ProblemNotificationsConfig{
+		Notifications: []config.ProblemNotificationConfig{
+			{},
+			{
+				Name: "production",
+				AlertingProfile: &config.AlertingProfileConfig{
+					Name: "Keptn sockshop production",
+					Rules: []config.AlertingProfileRuleConfig{
+						{SeverityLevel: "AVAILABILITY", DelayInMinutes: 5, Tags: []string{"keptn_project:sockshop", "keptn_stage:production"}},
+					},
+				},
+				Payload: `{"data": {"ProblemID":"{ProblemID}", "KeptnProject":"$KEPTN_PROJECT", "Stage":"production"}}`,
+			},
+		},
+	}
+}
+
+func createProblemNotificationsHandler(t *testing.T) (*deleteRecordingHandler, *test.FileBasedURLHandlerWithSink) {
+	handler := test.NewFileBasedURLHandlerWithSink(t)
+	handler.AddExact("/api/config/v1/alertingProfiles", "./testdata/problem_notifications/alerting_profiles.json")
+	handler.AddExact("/api/config/v1/alertingProfiles/ap1", "./testdata/problem_notifications/alerting_profile_ap1.json")
+	handler.AddExact("/api/config/v1/notifications", "./testdata/problem_notifications/notifications.json")
+	handler.AddExact("/api/config/v1/notifications/n1", "./testdata/problem_notifications/notification_n1.json")
+	return &deleteRecordingHandler{Handler: handler}, handler
+}
+
+// TestProblemNotificationCreation_Create tests that the configured notifications are created or updated, while the notifications of other projects are retained.
+func TestProblemNotificationCreation_Create(t *testing.T) {
+	handler, sinkHandler := createProblemNotificationsHandler(t)
+	dtClient, teardown := createDynatraceClient(t, handler)
+	defer teardown()
+
+	configClients := dynatrace.NewConfigClients(dtClient)
+	results := newProblemNotificationCreation(configClients.AlertingProfiles, configClients.Notifications, &keptnCredentialsProviderMock{}, createProblemNotificationsConfig()).create(context.Background(), "sockshop")
+	assert.Equal(t, []configResult{
+		{Name: "Keptn Problem Notification", Success: true, Message: "Successfully set up alerting profile Keptn and problem notification"},
+		{Name: "Keptn Problem Notification production", Success: true, Message: "Successfully set up alerting profile Keptn sockshop production and problem notification"},
+	}, results)
+
+	// only the duplicate of the updated notification is deleted
+	assert.Equal(t, []string{"/api/config/v1/notifications/n3"}, handler.deletedURLs)
+
+	var updatedNotification dynatrace.Notification
+	sinkHandler.GetStoredPayloadForURL("/api/config/v1/notifications/n1", &updatedNotification)
+	assert.Equal(t, "ap1", updatedNotification.AlertingProfile)
+	assert.Contains(t, updatedNotification.Payload, `"KeptnProject":"sockshop"`)
+
+	var createdAlertingProfile dynatrace.AlertingProfile
+	sinkHandler.GetStoredPayloadForURL("/api/config/v1/alertingProfiles", &createdAlertingProfile)
+	assert.Equal(t, "Keptn sockshop production", createdAlertingProfile.DisplayName)
+	assert.Equal(t, []dynatrace.AlertingProfileRules{
+		{
+			SeverityLevel:  "AVAILABILITY",
+			TagFilter:      dynatrace.AlertingProfileTagFilter{IncludeMode: "INCLUDE_ANY", TagFilters: []string{"keptn_project:sockshop", "keptn_stage:production"}},
+			DelayInMinutes: 5,
+		},
+	}, createdAlertingProfile.Rules)
+
+	var createdNotification dynatrace.Notification
+	sinkHandler.GetStoredPayloadForURL("/api/config/v1/notifications", &createdNotification)
+	assert.Equal(t, "Keptn Problem Notification production", createdNotification.Name)
+	assert.Equal(t, `{"data": {"ProblemID":"{ProblemID}", "KeptnProject":"sockshop", "Stage":"production"}}`, createdNotification.Payload)
+}
+
+// TestProblemNotificationCreation_Diff tests that missing alerting profiles and notifications are created, existing notifications are updated and duplicates are deleted.
+func TestProblemNotificationCreation_Diff(t *testing.T) {
+	handler, _ := createProblemNotificationsHandler(t)
+	dtClient, teardown := createDynatraceClient(t, handler)
+	defer teardown()
+
+	configClients := dynatrace.NewConfigClients(dtClient)
+	diff := newProblemNotificationCreation(configClients.AlertingProfiles, configClients.Notifications, &keptnCredentialsProviderMock{}, createProblemNotificationsConfig()).diff(context.Background(), "sockshop")
+	require.NoError(t, diff.Error)
+	assert.Equal(t, []configChange{
+		{Name: "Keptn Problem Notification", Action: changeActionUpdate},
+		{Name: "Keptn Problem Notification (n3)", Action: changeActionDelete},
+		{Name: "Alerting profile Keptn sockshop production", Action: changeActionCreate},
+		{Name: "Keptn Problem Notification production", Action: changeActionCreate},
+	}, diff.Changes)
+	assert.Empty(t, handler.deletedURLs)
+}
+
+func TestGetDesiredProblemNotifications(t *testing.T) {
+	t.Run("no configuration", func(t *testing.T) {
+		desiredNotifications := getDesiredProblemNotifications("sockshop", nil)
+		require.Len(t, desiredNotifications, 1)
+		assert.Equal(t, "Keptn Problem Notification", desiredNotifications[0].name)
+		assert.Equal(t, "sockshop", desiredNotifications[0].project)
+		assert.Equal(t, keptnAlertingProfileName, desiredNotifications[0].alertingProfile.DisplayName)
+		assert.Len(t, desiredNotifications[0].alertingProfile.Rules, len(defaultAlertingProfileSeverityLevels))
+	})
+
+	t.Run("notification routing to another project", func(t *testing.T) {
+		notificationsConfig := &config.ProblemNotificationsConfig{
+			Notifications: []config.ProblemNotificationConfig{
+				{
+					Name:    "orders",
+					Project: "orders",
+					AlertingProfile: &config.AlertingProfileConfig{
+						Rules: []config.AlertingProfileRuleConfig{{SeverityLevel: "ERROR", IncludeMode: "INCLUDE_ALL", Tags: []string{"app:orders"}}, {SeverityLevel: "PERFORMANCE"}},
+					},
+				},
+			},
+		}
+
+		desiredNotifications := getDesiredProblemNotifications("sockshop", notificationsConfig)
+		require.Len(t, desiredNotifications, 1)
+		assert.Equal(t, "Keptn Problem Notification orders", desiredNotifications[0].name)
+		assert.Equal(t, "orders", desiredNotifications[0].project)
+		assert.Equal(t, keptnAlertingProfileName, desiredNotifications[0].alertingProfile.DisplayName)
+		assert.Equal(t, []dynatrace.AlertingProfileRules{
+			{SeverityLevel: "ERROR", TagFilter: dynatrace.AlertingProfileTagFilter{IncludeMode: "INCLUDE_ALL", TagFilters: []string{"app:orders"}}},
+			{SeverityLevel: "PERFORMANCE", TagFilter: dynatrace.AlertingProfileTagFilter{IncludeMode: "NONE"}},
+		}, desiredNotifications[0].alertingProfile.Rules)
+	})
+}
@@ -0,0 +1,90 @@
+package monitoring
+
+import (
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/keptn-contrib/dynatrace-service/internal/adapter"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+)
+
+type ProjectDeleteFinishedAdapterInterface interface {
+	adapter.EventContentAdapter
+
+	GetResult() keptnv2.ResultType
+}
+
+// ProjectDeleteFinishedAdapter is a content adaptor for events of type sh.keptn.event.project.delete.finished
+type ProjectDeleteFinishedAdapter struct {
+	event      keptnv2.ProjectDeleteFinishedEventData
+	cloudEvent adapter.CloudEventAdapter
+}
+
+// NewProjectDeleteFinishedAdapterFromEvent creates a new ProjectDeleteFinishedAdapter from a cloudevents Event
+func NewProjectDeleteFinishedAdapterFromEvent(e cloudevents.Event) (*ProjectDeleteFinishedAdapter, error) {
+	ceAdapter := adapter.NewCloudEventAdapter(e)
+
+	pdfData := &keptnv2.ProjectDeleteFinishedEventData{}
+	err := ceAdapter.PayloadAs(pdfData)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ProjectDeleteFinishedAdapter{
+		event:      *pdfData,
+		cloudEvent: ceAdapter,
+	}, nil
+}
+
+// GetShKeptnContext returns the shkeptncontext
+func (a ProjectDeleteFinishedAdapter) GetShKeptnContext() string {
+	return a.cloudEvent.GetShKeptnContext()
+}
+
+// GetSource returns the source specified in the CloudEvent context
+func (a ProjectDeleteFinishedAdapter) GetSource() string {
+	return a.cloudEvent.GetSource()
+}
+
+// GetEvent returns the event type
+func (a ProjectDeleteFinishedAdapter) GetEvent() string {
+	return keptnv2.GetFinishedEventType(keptnv2.ProjectDeleteTaskName)
+}
+
+// GetProject returns the project
+func (a ProjectDeleteFinishedAdapter) GetProject() string {
+	return a.event.Project
+}
+
+// GetStage returns the stage
+func (a ProjectDeleteFinishedAdapter) GetStage() string {
+	return ""
+}
+
+// GetService returns the service
+func (a ProjectDeleteFinishedAdapter) GetService() string {
+	return ""
+}
+
+// GetDeployment returns the name of the deployment
+func (a ProjectDeleteFinishedAdapter) GetDeployment() string {
+	return ""
+}
+
+// GetTestStrategy returns the used test strategy
+func (a ProjectDeleteFinishedAdapter) GetTestStrategy() string {
+	return ""
+}
+
+// GetDeploymentStrategy returns the used deployment strategy
+func (a ProjectDeleteFinishedAdapter) GetDeploymentStrategy() string {
+	return ""
+}
+
+// GetLabels returns a map of labels
+func (a ProjectDeleteFinishedAdapter) GetLabels() map[string]string {
+	return a.event.Labels
+}
+
+// GetResult returns the result
+func (a ProjectDeleteFinishedAdapter) GetResult() keptnv2.ResultType {
+	return a.event.Result
+}
@@ -0,0 +1,51 @@
+package monitoring
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+)
+
+// ProjectDeleteFinishedEventHandler cleans up the Dynatrace entities of a deleted Keptn project.
+type ProjectDeleteFinishedEventHandler struct {
+	event         ProjectDeleteFinishedAdapterInterface
+	dtClient      dynatrace.ClientInterface
+	configClients *dynatrace.ConfigClients
+	policy        CleanupPolicy
+}
+
+// NewProjectDeleteFinishedEventHandler creates a new ProjectDeleteFinishedEventHandler.
+func NewProjectDeleteFinishedEventHandler(event ProjectDeleteFinishedAdapterInterface, dtClient dynatrace.ClientInterface, configClients *dynatrace.ConfigClients, policy CleanupPolicy) *ProjectDeleteFinishedEventHandler {
+	return &ProjectDeleteFinishedEventHandler{
+		event:         event,
+		dtClient:      dtClient,
+		configClients: configClients,
+		policy:        policy,
+	}
+}
+
+// HandleEvent handles a project delete finished event.
+func (eh *ProjectDeleteFinishedEventHandler) HandleEvent(workCtx context.Context, _ context.Context) error {
+	if eh.policy != CleanupPolicyReport && eh.policy != CleanupPolicyDelete {
+		return fmt.Errorf("unsupported cleanup policy: %s", eh.policy)
+	}
+
+	// the project may still exist if its deletion failed
+	if eh.event.GetResult() == keptnv2.ResultFailed {
+		log.WithField("project", eh.event.GetProject()).Info("Project deletion failed, skipping cleanup of Dynatrace entities")
+		return nil
+	}
+
+	report := Cleanup(workCtx, eh.dtClient, eh.configClients, eh.event.GetProject(), "", eh.policy != CleanupPolicyDelete)
+	log.WithField("project", eh.event.GetProject()).Info(report.String())
+	if report.HasErrors() {
+		return errors.New("could not clean up all Dynatrace entities of project " + eh.event.GetProject())
+	}
+
+	return nil
+}
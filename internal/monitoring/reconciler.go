@@ -0,0 +1,285 @@
+package monitoring
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/config"
+	"github.com/keptn-contrib/dynatrace-service/internal/credentials"
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+	"github.com/keptn-contrib/dynatrace-service/internal/env"
+	"github.com/keptn-contrib/dynatrace-service/internal/keptn"
+	"github.com/keptn-contrib/dynatrace-service/internal/metrics"
+)
+
+// ReconciliationPolicy defines how the Reconciler handles drifted Dynatrace entities.
+type ReconciliationPolicy string
+
+const (
+	// ReconciliationPolicyReport only reports drifted entities in the log and metrics.
+	ReconciliationPolicyReport ReconciliationPolicy = "report"
+
+	// ReconciliationPolicyCorrect additionally restores the desired configuration of drifted entities.
+	ReconciliationPolicyCorrect ReconciliationPolicy = "correct"
+)
+
+const (
+	correctionResultSuccess = "success"
+	correctionResultFailure = "failure"
+)
+
+// DynatraceClientFactory creates a dynatrace.ClientInterface for the Dynatrace tenant whose credentials are stored in the specified secret.
+type DynatraceClientFactory func(ctx context.Context, credentialsSecretName string) (dynatrace.ClientInterface, error)
+
+// projectEventAdapter is used to retrieve the project level Dynatrace config
+type projectEventAdapter struct {
+	project string
+}
+
+func (projectEventAdapter) GetShKeptnContext() string {
+	return ""
+}
+
+func (projectEventAdapter) GetEvent() string {
+	return ""
+}
+
+func (projectEventAdapter) GetSource() string {
+	return ""
+}
+
+func (a projectEventAdapter) GetProject() string {
+	return a.project
+}
+
+func (projectEventAdapter) GetStage() string {
+	return ""
+}
+
+func (projectEventAdapter) GetService() string {
+	return ""
+}
+
+func (projectEventAdapter) GetDeployment() string {
+	return ""
+}
+
+func (projectEventAdapter) GetTestStrategy() string {
+	return ""
+}
+
+func (projectEventAdapter) GetDeploymentStrategy() string {
+	return ""
+}
+
+func (projectEventAdapter) GetLabels() map[string]string {
+	return nil
+}
+
+// Reconciler periodically checks the Dynatrace entities generated when configuring the monitoring of each Keptn project that enabled it for drift.
+// Drifted entities are only corrected if the policy is ReconciliationPolicyCorrect and the monitoring configuration of the project does not request a dry run.
+type Reconciler struct {
+	projectClient            keptn.ProjectClientInterface
+	shipyardReader           keptn.ShipyardReaderInterface
+	dashboardTemplateReader  keptn.DashboardTemplateReaderInterface
+	serviceClient            keptn.ServiceClientInterface
+	configProvider           config.DynatraceConfigProvider
+	keptnCredentialsProvider credentials.KeptnCredentialsProvider
+	createDynatraceClient    DynatraceClientFactory
+	interval                 time.Duration
+	policy                   ReconciliationPolicy
+
+	// reportedProjects are the projects whose drifted entities are currently reported by the MonitoringDriftedEntities metric
+	reportedProjects map[string]struct{}
+}
+
+// NewReconciler creates a new Reconciler.
+func NewReconciler(projectClient keptn.ProjectClientInterface, shipyardReader keptn.ShipyardReaderInterface, dashboardTemplateReader keptn.DashboardTemplateReaderInterface, serviceClient keptn.ServiceClientInterface, configProvider config.DynatraceConfigProvider, keptnCredentialsProvider credentials.KeptnCredentialsProvider, createDynatraceClient DynatraceClientFactory, interval time.Duration, policy ReconciliationPolicy) *Reconciler {
+	return &Reconciler{
+		projectClient:            projectClient,
+		shipyardReader:           shipyardReader,
+		dashboardTemplateReader:  dashboardTemplateReader,
+		serviceClient:            serviceClient,
+		configProvider:           configProvider,
+		keptnCredentialsProvider: keptnCredentialsProvider,
+		createDynatraceClient:    createDynatraceClient,
+		interval:                 interval,
+		policy:                   policy,
+		reportedProjects:         make(map[string]struct{}),
+	}
+}
+
+// NewDefaultReconciler creates a new Reconciler configured by the RECONCILE_MONITORING_* environment variables.
+func NewDefaultReconciler() (*Reconciler, error) {
+	policy := ReconciliationPolicy(env.GetMonitoringReconciliationPolicy())
+	if policy != ReconciliationPolicyReport && policy != ReconciliationPolicyCorrect {
+		return nil, fmt.Errorf("unsupported reconciliation policy: %s", policy)
+	}
+
+	clientFactory, err := keptn.NewClientFactory()
+	if err != nil {
+		return nil, err
+	}
+
+	keptnCredentialsProvider, err := credentials.NewDefaultKeptnCredentialsReader()
+	if err != nil {
+		return nil, fmt.Errorf("could not create Keptn credentials reader: %w", err)
+	}
+
+	configClient := keptn.NewConfigClient(clientFactory.CreateResourceClient())
+
+	return NewReconciler(
+		clientFactory.CreateProjectClient(),
+		configClient,
+		configClient,
+		clientFactory.CreateServiceClient(),
+		config.NewDynatraceConfigGetter(configClient),
+		keptnCredentialsProvider,
+		createDynatraceClientFromSecret,
+		time.Duration(env.GetMonitoringReconciliationInterval())*time.Second,
+		policy), nil
+}
+
+func createDynatraceClientFromSecret(ctx context.Context, credentialsSecretName string) (dynatrace.ClientInterface, error) {
+	credentialsProvider, err := credentials.NewDefaultDynatraceK8sSecretReader()
+	if err != nil {
+		return nil, fmt.Errorf("could not create Dynatrace credentials reader: %w", err)
+	}
+
+	dynatraceCredentials, err := credentialsProvider.GetDynatraceCredentials(ctx, credentialsSecretName)
+	if err != nil {
+		return nil, fmt.Errorf("could not get Dynatrace credentials: %w", err)
+	}
+
+	return dynatrace.NewClient(dynatraceCredentials), nil
+}
+
+// Run runs the reconciler which does not return unless cancelled.
+// Cancelling runCtx will stop any new reconciliation runs, cancelling reconciliationCtx will stop an in progress reconciliation.
+func (r *Reconciler) Run(runCtx context.Context, reconciliationCtx context.Context) {
+	log.WithFields(log.Fields{"interval": r.interval, "policy": r.policy}).Info("Reconciler will check for drift periodically")
+	for {
+		r.reconcileProjects(reconciliationCtx)
+
+		select {
+		case <-runCtx.Done():
+			log.Info("Reconciler has terminated")
+			return
+
+		case <-time.After(r.interval):
+		}
+	}
+}
+
+// reconcileProjects performs a single reconciliation run for all Keptn projects
+func (r *Reconciler) reconcileProjects(ctx context.Context) {
+	projects, err := r.projectClient.GetProjectNames(ctx)
+	if err != nil {
+		log.WithError(err).Error("Could not get projects from Keptn")
+		return
+	}
+
+	reconciledProjects := make(map[string]struct{}, len(projects))
+	for _, project := range projects {
+		driftedEntities, err := r.reconcileProject(ctx, project)
+		if err != nil {
+			log.WithError(err).WithField("project", project).Error("Could not reconcile project")
+			continue
+		}
+
+		if driftedEntities != nil {
+			r.reportDriftedEntities(project, driftedEntities)
+			reconciledProjects[project] = struct{}{}
+		}
+	}
+
+	// projects that have been skipped or no longer exist should not keep reporting the drifted entities of an earlier run
+	for project := range r.reportedProjects {
+		if _, ok := reconciledProjects[project]; !ok {
+			r.deleteDriftedEntities(project)
+		}
+	}
+}
+
+// reconcileProject detects and, depending on the policy, corrects drift of the Dynatrace entities of the project.
+// It returns the number of remaining drifted entities per entity type, or nil if the project has not enabled reconciliation.
+func (r *Reconciler) reconcileProject(ctx context.Context, project string) (map[string]int, error) {
+	dynatraceConfig, err := r.configProvider.GetDynatraceConfig(ctx, projectEventAdapter{project: project})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load Dynatrace config: %w", err)
+	}
+
+	if !dynatraceConfig.Monitoring.IsReconciliationEnabled() {
+		log.WithField("project", project).Debug("Skipping reconciliation of project that has not enabled it")
+		return nil, nil
+	}
+
+	dtClient, err := r.createDynatraceClient(ctx, dynatraceConfig.DtCreds)
+	if err != nil {
+		return nil, err
+	}
+
+	configClients, err := dynatrace.NewConfigClientsForAPI(ctx, dtClient, dynatraceConfig.ConfigAPI)
+	if err != nil {
+		return nil, err
+	}
+
+	shipyard, err := r.shipyardReader.GetShipyard(ctx, project)
+	if err != nil {
+		return nil, err
+	}
+
+	correct := r.policy == ReconciliationPolicyCorrect && !dynatraceConfig.Monitoring.IsDryRun()
+	drifts := newDriftDetector(dtClient, configClients, r.dashboardTemplateReader, r.serviceClient, r.keptnCredentialsProvider, dynatraceConfig.Monitoring).detect(ctx, project, *shipyard)
+
+	driftedEntities := make(map[string]int, len(driftEntityTypes))
+	for _, entityType := range driftEntityTypes {
+		driftedEntities[entityType] = 0
+	}
+
+	for _, d := range drifts {
+		driftedEntities[d.EntityType]++
+		logger := log.WithFields(log.Fields{
+			"project":    project,
+			"entityType": d.EntityType,
+			"name":       d.Name,
+			"drift":      d.Kind,
+		})
+
+		if !correct {
+			logger.Warn("Detected drift of Dynatrace entity")
+			continue
+		}
+
+		if err := d.correct(ctx); err != nil {
+			logger.WithError(err).Error("Could not correct drift of Dynatrace entity")
+			metrics.MonitoringDriftCorrectionsTotal.WithLabelValues(d.EntityType, correctionResultFailure).Inc()
+			continue
+		}
+
+		logger.Info("Corrected drift of Dynatrace entity")
+		metrics.MonitoringDriftCorrectionsTotal.WithLabelValues(d.EntityType, correctionResultSuccess).Inc()
+		driftedEntities[d.EntityType]--
+	}
+
+	return driftedEntities, nil
+}
+
+// reportDriftedEntities sets the MonitoringDriftedEntities metric of the project.
+func (r *Reconciler) reportDriftedEntities(project string, driftedEntities map[string]int) {
+	for entityType, count := range driftedEntities {
+		metrics.MonitoringDriftedEntities.WithLabelValues(project, entityType).Set(float64(count))
+	}
+	r.reportedProjects[project] = struct{}{}
+}
+
+// deleteDriftedEntities removes the MonitoringDriftedEntities metric of the project.
+func (r *Reconciler) deleteDriftedEntities(project string) {
+	for _, entityType := range driftEntityTypes {
+		metrics.MonitoringDriftedEntities.DeleteLabelValues(project, entityType)
+	}
+	delete(r.reportedProjects, project)
+}
@@ -0,0 +1,235 @@
+package monitoring
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/adapter"
+	"github.com/keptn-contrib/dynatrace-service/internal/config"
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+	"github.com/keptn-contrib/dynatrace-service/internal/metrics"
+	"github.com/keptn-contrib/dynatrace-service/internal/test"
+)
+
+type projectClientMock struct {
+	projects []string
+}
+
+func (m *projectClientMock) GetProjectNames(_ context.Context) ([]string, error) {
+	return m.projects, nil
+}
+
+type shipyardReaderMock struct {
+	shipyard *keptnv2.Shipyard
+}
+
+func (m *shipyardReaderMock) GetShipyard(_ context.Context, _ string) (*keptnv2.Shipyard, error) {
+	return m.shipyard, nil
+}
+
+type dynatraceConfigProviderMock struct {
+	dynatraceConfig *config.DynatraceConfig
+}
+
+func (m *dynatraceConfigProviderMock) GetDynatraceConfig(_ context.Context, _ adapter.EventContentAdapter) (*config.DynatraceConfig, error) {
+	return m.dynatraceConfig, nil
+}
+
+// readOnlyHandler fails the test for any request other than GET
+type readOnlyHandler struct {
+	http.Handler
+	t *testing.T
+}
+
+func (h readOnlyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.t.Fatalf("unexpected %s request for URL: %s", r.Method, r.URL.String())
+	}
+	h.Handler.ServeHTTP(w, r)
+}
+
+func setupReconciler(t *testing.T, handler http.Handler, policy ReconciliationPolicy) (*Reconciler, func()) {
+	return setupReconcilerWithMonitoringConfig(t, handler, policy, func(*config.MonitoringConfig) {})
+}
+
+func setupReconcilerWithMonitoringConfig(t *testing.T, handler http.Handler, policy ReconciliationPolicy, modifyMonitoringConfig func(monitoringConfig *config.MonitoringConfig)) (*Reconciler, func()) {
+	dtClient, teardown := createDynatraceClient(t, handler)
+
+	enabled := true
+	disabled := false
+	dynatraceConfig := config.NewDynatraceConfigWithDefaults()
+	dynatraceConfig.Monitoring = &config.MonitoringConfig{
+		Reconcile:            true,
+		TaggingRules:         &config.MonitoringEntityConfig{Enabled: &disabled},
+		ProblemNotifications: &config.ProblemNotificationsConfig{Enabled: &disabled},
+		ManagementZones:      &config.MonitoringEntityConfig{Enabled: &enabled},
+		Dashboards:           &config.MonitoringEntityConfig{Enabled: &disabled},
+	}
+	modifyMonitoringConfig(dynatraceConfig.Monitoring)
+
+	shipyard := &keptnv2.Shipyard{
+		Spec: keptnv2.ShipyardSpec{
+			Stages: []keptnv2.Stage{{Name: "dev"}, {Name: "production"}},
+		},
+	}
+
+	reconciler := NewReconciler(
+		&projectClientMock{projects: []string{"sockshop"}},
+		&shipyardReaderMock{shipyard: shipyard},
+		nil,
+		nil,
+		&dynatraceConfigProviderMock{dynatraceConfig: dynatraceConfig},
+		nil,
+		func(_ context.Context, _ string) (dynatrace.ClientInterface, error) {
+			return dtClient, nil
+		},
+		time.Hour,
+		policy)
+
+	return reconciler, teardown
+}
+
+func createDriftHandler(t *testing.T) *test.FileBasedURLHandlerWithSink {
+	handler := test.NewFileBasedURLHandlerWithSink(t)
+	handler.AddExact("/api/config/v1/managementZones", "./testdata/drift/management_zones.json")
+	handler.AddExact("/api/config/v1/managementZones/1", "./testdata/drift/management_zone_project.json")
+	handler.AddExact("/api/config/v1/managementZones/2", "./testdata/drift/management_zone_dev_modified.json")
+	return handler
+}
+
+// TestReconciler_ReportPolicy tests that drifted entities are detected, but not changed.
+func TestReconciler_ReportPolicy(t *testing.T) {
+	reconciler, teardown := setupReconciler(t, readOnlyHandler{Handler: createDriftHandler(t), t: t}, ReconciliationPolicyReport)
+	defer teardown()
+
+	dynatraceConfig, _ := reconciler.configProvider.GetDynatraceConfig(context.Background(), nil)
+	dtClient, _ := reconciler.createDynatraceClient(context.Background(), "")
+	shipyard, _ := reconciler.shipyardReader.GetShipyard(context.Background(), "sockshop")
+
+	drifts := newDriftDetector(dtClient, dynatrace.NewConfigClients(dtClient), nil, nil, nil, dynatraceConfig.Monitoring).detect(context.Background(), "sockshop", *shipyard)
+	if assert.Len(t, drifts, 2) {
+		assert.Equal(t, entityTypeManagementZone, drifts[0].EntityType)
+		assert.Equal(t, "Keptn: sockshop dev", drifts[0].Name)
+		assert.Equal(t, driftKindModified, drifts[0].Kind)
+
+		assert.Equal(t, entityTypeManagementZone, drifts[1].EntityType)
+		assert.Equal(t, "Keptn: sockshop production", drifts[1].Name)
+		assert.Equal(t, driftKindMissing, drifts[1].Kind)
+	}
+
+	reconciler.reconcileProjects(context.Background())
+}
+
+// TestReconciler_CorrectPolicy tests that missing entities are created and modified entities are updated.
+func TestReconciler_CorrectPolicy(t *testing.T) {
+	handler := createDriftHandler(t)
+	reconciler, teardown := setupReconciler(t, handler, ReconciliationPolicyCorrect)
+	defer teardown()
+
+	reconciler.reconcileProjects(context.Background())
+
+	updatedManagementZone := &dynatrace.ManagementZone{}
+	handler.GetStoredPayloadForURL("/api/config/v1/managementZones/2", updatedManagementZone)
+	assert.Equal(t, createManagementZoneForStage("sockshop", "dev"), updatedManagementZone)
+
+	createdManagementZone := &dynatrace.ManagementZone{}
+	handler.GetStoredPayloadForURL("/api/config/v1/managementZones", createdManagementZone)
+	assert.Equal(t, createManagementZoneForStage("sockshop", "production"), createdManagementZone)
+}
+
+// TestReconciler_DryRun tests that drifted entities are not changed if the monitoring configuration of the project requests a dry run, even with the correct policy.
+func TestReconciler_DryRun(t *testing.T) {
+	reconciler, teardown := setupReconcilerWithMonitoringConfig(t, readOnlyHandler{Handler: createDriftHandler(t), t: t}, ReconciliationPolicyCorrect, func(monitoringConfig *config.MonitoringConfig) {
+		monitoringConfig.DryRun = true
+	})
+	defer teardown()
+
+	reconciler.reconcileProjects(context.Background())
+}
+
+// TestReconciler_DeletesDriftedEntitiesOfProjectsNoLongerReconciled tests that the drifted entities of projects that have disabled reconciliation or no longer exist are no longer reported.
+func TestReconciler_DeletesDriftedEntitiesOfProjectsNoLongerReconciled(t *testing.T) {
+	reconciler, teardown := setupReconciler(t, readOnlyHandler{Handler: createDriftHandler(t), t: t}, ReconciliationPolicyReport)
+	defer teardown()
+
+	reconciler.reconcileProjects(context.Background())
+	assert.EqualValues(t, 2, testutil.ToFloat64(metrics.MonitoringDriftedEntities.WithLabelValues("sockshop", entityTypeManagementZone)))
+
+	dynatraceConfig, _ := reconciler.configProvider.GetDynatraceConfig(context.Background(), nil)
+	dynatraceConfig.Monitoring.Reconcile = false
+	reconciler.reconcileProjects(context.Background())
+	assert.Zero(t, testutil.CollectAndCount(metrics.MonitoringDriftedEntities), "the drifted entities of a skipped project should not be reported")
+
+	dynatraceConfig.Monitoring.Reconcile = true
+	reconciler.reconcileProjects(context.Background())
+	assert.Equal(t, len(driftEntityTypes), testutil.CollectAndCount(metrics.MonitoringDriftedEntities))
+
+	reconciler.projectClient = &projectClientMock{}
+	reconciler.reconcileProjects(context.Background())
+	assert.Zero(t, testutil.CollectAndCount(metrics.MonitoringDriftedEntities), "the drifted entities of a deleted project should not be reported")
+}
+
+// TestReconciler_NotEnabled tests that projects that have not enabled reconciliation are not checked for drift.
+func TestReconciler_NotEnabled(t *testing.T) {
+	handler := http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		t.Fatalf("unexpected %s request for URL: %s", r.Method, r.URL.String())
+	})
+	reconciler, teardown := setupReconcilerWithMonitoringConfig(t, handler, ReconciliationPolicyCorrect, func(monitoringConfig *config.MonitoringConfig) {
+		monitoringConfig.Reconcile = false
+	})
+	defer teardown()
+
+	reconciler.reconcileProjects(context.Background())
+}
+
+func TestIsJSONSubset(t *testing.T) {
+	tests := []struct {
+		name    string
+		desired interface{}
+		actual  interface{}
+		want    bool
+	}{
+		{
+			name:    "additional values are ignored",
+			desired: map[string]interface{}{"name": "a"},
+			actual:  map[string]interface{}{"name": "a", "id": "1"},
+			want:    true,
+		},
+		{
+			name:    "null values and empty strings are ignored",
+			desired: map[string]interface{}{"name": "a", "id": "", "metadata": nil},
+			actual:  map[string]interface{}{"name": "a", "id": "1", "metadata": map[string]interface{}{"clusterVersion": "1"}},
+			want:    true,
+		},
+		{
+			name:    "different values are detected",
+			desired: map[string]interface{}{"enabled": true},
+			actual:  map[string]interface{}{"enabled": false},
+			want:    false,
+		},
+		{
+			name:    "missing values are detected",
+			desired: map[string]interface{}{"enabled": true},
+			actual:  map[string]interface{}{},
+			want:    false,
+		},
+		{
+			name:    "different array lengths are detected",
+			desired: map[string]interface{}{"rules": []string{"a"}},
+			actual:  map[string]interface{}{"rules": []string{"a", "b"}},
+			want:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isJSONSubset(tt.desired, tt.actual))
+		})
+	}
+}
@@ -0,0 +1,292 @@
+package monitoring
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	keptnlib "github.com/keptn/go-utils/pkg/lib"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/common"
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+	"github.com/keptn-contrib/dynatrace-service/internal/keptn"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/metrics"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/query"
+	v1metrics "github.com/keptn-contrib/dynatrace-service/internal/sli/v1/metrics"
+	v1mv2 "github.com/keptn-contrib/dynatrace-service/internal/sli/v1/mv2"
+	v1problems "github.com/keptn-contrib/dynatrace-service/internal/sli/v1/problemsv2"
+	v1secpv2 "github.com/keptn-contrib/dynatrace-service/internal/sli/v1/secpv2"
+	v1slo "github.com/keptn-contrib/dynatrace-service/internal/sli/v1/slo"
+	v1usql "github.com/keptn-contrib/dynatrace-service/internal/sli/v1/usql"
+)
+
+// sloTimeframe is the timeframe over which generated SLOs are evaluated
+const sloTimeframe = "-1w"
+
+var invalidSLOMetricNameCharacters = regexp.MustCompile(`[^a-z0-9_]`)
+
+// serviceEventAdapter is used to replace the placeholders in the SLI queries of a service
+type serviceEventAdapter struct {
+	projectEventAdapter
+	stage      string
+	service    string
+	deployment string
+}
+
+func (a serviceEventAdapter) GetStage() string {
+	return a.stage
+}
+
+func (a serviceEventAdapter) GetService() string {
+	return a.service
+}
+
+func (a serviceEventAdapter) GetDeployment() string {
+	return a.deployment
+}
+
+type sloCreation struct {
+	dtClient        dynatrace.ClientInterface
+	sliAndSLOReader keptn.SLIAndSLOReaderInterface
+}
+
+func newSLOCreation(dynatraceClient dynatrace.ClientInterface, sliAndSLOReader keptn.SLIAndSLOReaderInterface) *sloCreation {
+	return &sloCreation{
+		dtClient:        dynatraceClient,
+		sliAndSLOReader: sliAndSLOReader,
+	}
+}
+
+// create creates or updates a Dynatrace SLO for each objective of the service that can be mapped to one.
+func (sc *sloCreation) create(ctx context.Context, project string, stage string, service string) []configResult {
+	slos, err := sc.getDesiredSLOs(ctx, project, stage, service)
+	if err != nil {
+		log.WithError(err).WithFields(log.Fields{"service": service, "stage": stage}).Info("No SLOs defined for service. Skipping creation of Dynatrace SLOs.")
+		return nil
+	}
+
+	client := dynatrace.NewSLODefinitionsClient(sc.dtClient)
+	existingSLOIDs, err := client.GetIDsByName(ctx)
+	if err != nil {
+		log.WithError(err).WithFields(log.Fields{"project": project, "stage": stage}).Error("Could not retrieve SLOs")
+		return []configResult{{Success: false, Message: err.Error()}}
+	}
+
+	var results []configResult
+	for _, slo := range slos {
+		if id, exists := existingSLOIDs[slo.Name]; exists {
+			err = client.Update(ctx, id, slo)
+		} else {
+			err = client.Create(ctx, slo)
+		}
+
+		if err != nil {
+			log.WithError(err).WithField("name", slo.Name).Error("Could not create SLO")
+			results = append(results, configResult{Name: slo.Name, Success: false, Message: err.Error()})
+			continue
+		}
+
+		log.WithField("name", slo.Name).Info("Created SLO")
+		results = append(results, configResult{Name: slo.Name, Success: true})
+	}
+	return results
+}
+
+// diff returns the SLOs for the service that would be created or updated.
+func (sc *sloCreation) diff(ctx context.Context, project string, stage string, service string) ([]configChange, error) {
+	slos, err := sc.getDesiredSLOs(ctx, project, stage, service)
+	if err != nil {
+		// no SLOs, so no Dynatrace SLOs would be created
+		return nil, nil
+	}
+
+	client := dynatrace.NewSLODefinitionsClient(sc.dtClient)
+	existingSLOIDs, err := client.GetIDsByName(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []configChange
+	for _, slo := range slos {
+		id, exists := existingSLOIDs[slo.Name]
+		if !exists {
+			changes = append(changes, configChange{Name: slo.Name, Action: changeActionCreate})
+			continue
+		}
+
+		existingSLO, err := client.GetByID(ctx, id)
+		if err != nil {
+			return changes, err
+		}
+
+		existingSLO.ID = ""
+		if *existingSLO != *slo {
+			changes = append(changes, configChange{Name: slo.Name, Action: changeActionUpdate})
+		}
+	}
+	return changes, nil
+}
+
+// getDesiredSLOs returns the Dynatrace SLOs for the objectives of the service. Objectives that cannot be mapped are skipped.
+func (sc *sloCreation) getDesiredSLOs(ctx context.Context, project string, stage string, service string) ([]*dynatrace.SLODefinition, error) {
+	slos, err := sc.sliAndSLOReader.GetSLOs(ctx, project, stage, service)
+	if err != nil {
+		return nil, err
+	}
+
+	slis, err := sc.sliAndSLOReader.GetSLIs(ctx, project, stage, service)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get SLIs for service %s in stage %s: %w", service, stage, err)
+	}
+	customQueries := query.NewCustomQueries(slis)
+	eventAdapter := serviceEventAdapter{projectEventAdapter: projectEventAdapter{project: project}, stage: stage, service: service}
+
+	var desiredSLOs []*dynatrace.SLODefinition
+	for _, objective := range slos.Objectives {
+		sliQuery, err := customQueries.GetQueryByNameOrDefault(objective.SLI)
+		if err != nil {
+			log.WithField("sli", objective.SLI).Warn("Could not find query for SLI, skipping SLO")
+			continue
+		}
+
+		slo, err := createSLOForObjective(project, stage, service, objective, common.ReplaceQueryParameters(sliQuery, nil, eventAdapter))
+		if err != nil {
+			log.WithError(err).WithField("sli", objective.SLI).Warn("Could not map objective to SLO, skipping SLO")
+			continue
+		}
+
+		desiredSLOs = append(desiredSLOs, slo)
+	}
+	return desiredSLOs, nil
+}
+
+// createSLOForObjective maps a Keptn objective to a Dynatrace SLO.
+// As Dynatrace SLOs are percentages where higher values are better, the SLI must be a percentage and the objective must have a single fixed pass threshold and at most a single fixed warning threshold.
+// For objectives where lower values are better, e.g. error rates, the SLO is based on 100 minus the SLI value.
+func createSLOForObjective(project string, stage string, service string, objective *keptnlib.SLO, sliQuery string) (*dynatrace.SLODefinition, error) {
+	metricSelector, err := getMetricSelector(sliQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	pass, err := getSingleFixedCriteria(objective.Pass)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported pass criteria: %w", err)
+	}
+	if pass == nil {
+		return nil, errors.New("objective has no pass criteria")
+	}
+
+	warning, err := getSingleFixedCriteria(objective.Warning)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported warning criteria: %w", err)
+	}
+
+	lowerIsBetter := strings.HasPrefix(pass.Operator, "<")
+	if warning != nil && strings.HasPrefix(warning.Operator, "<") != lowerIsBetter {
+		return nil, errors.New("pass and warning criteria have different directions")
+	}
+
+	metricExpression := metricSelector
+	target := pass.Value
+	warningValue := pass.Value
+	if warning != nil {
+		target = warning.Value
+	}
+	if lowerIsBetter {
+		metricExpression = "(100)-(" + metricSelector + ")"
+		target = 100 - target
+		warningValue = 100 - warningValue
+	}
+
+	if target < 0 || warningValue > 100 || target > warningValue {
+		return nil, fmt.Errorf("thresholds are not valid percentages: target %v, warning %v", target, warningValue)
+	}
+
+	return &dynatrace.SLODefinition{
+		Enabled:           true,
+		Name:              getSLOName(project, stage, service, objective.SLI),
+		CustomDescription: "Keptn objective " + objective.SLI + " of service " + service + " in stage " + stage + " of project " + project,
+		MetricName:        getSLOMetricName(project, stage, service, objective.SLI),
+		MetricExpression:  metricExpression,
+		EvaluationType:    dynatrace.SLOEvaluationTypeAggregate,
+		Filter:            fmt.Sprintf("type(\"SERVICE\"),tag(\"%s:%s\"),tag(\"%s:%s\"),tag(\"%s:%s\")", dynatrace.KeptnProject, project, dynatrace.KeptnStage, stage, keptnService, service),
+		Target:            target,
+		Warning:           warningValue,
+		Timeframe:         sloTimeframe,
+	}, nil
+}
+
+// getSingleFixedCriteria returns the only criteria of the criteria groups or nil if there is none. It must be a fixed threshold.
+func getSingleFixedCriteria(criteriaGroups []*keptnlib.SLOCriteria) (*criteriaObject, error) {
+	var criteria []string
+	for _, group := range criteriaGroups {
+		criteria = append(criteria, group.Criteria...)
+	}
+
+	if len(criteria) == 0 {
+		return nil, nil
+	}
+	if len(criteria) > 1 {
+		return nil, errors.New("only a single criteria is supported")
+	}
+
+	c, err := parseCriteriaString(criteria[0])
+	if err != nil {
+		return nil, err
+	}
+	if c.IsComparison || c.CheckPercentage || c.Operator == "=" {
+		return nil, fmt.Errorf("only fixed thresholds are supported: %s", criteria[0])
+	}
+	return c, nil
+}
+
+// getMetricSelector returns the metric selector of a metrics SLI query. The entity selector is ignored, as SLOs are filtered by the Keptn tags.
+func getMetricSelector(sliQuery string) (string, error) {
+	metricsQuery, err := getMetricsQuery(sliQuery)
+	if err != nil {
+		return "", err
+	}
+
+	return metricsQuery.GetMetricSelector(), nil
+}
+
+// getMetricsQuery parses a metrics SLI query, which may also be an MV2 query or use the legacy format. All other types of SLI queries are not supported.
+func getMetricsQuery(sliQuery string) (*metrics.Query, error) {
+	for _, prefix := range []string{v1usql.USQLPrefix, v1slo.SLOPrefix, v1problems.ProblemsV2Prefix, v1secpv2.SecurityProblemsV2Prefix} {
+		if strings.HasPrefix(sliQuery, prefix) {
+			return nil, fmt.Errorf("only metrics queries are supported: %s", sliQuery)
+		}
+	}
+
+	if strings.HasPrefix(sliQuery, v1mv2.MV2Prefix) {
+		mv2Query, err := v1mv2.NewQueryParser(sliQuery).Parse()
+		if err != nil {
+			return nil, err
+		}
+		metricsQuery := mv2Query.GetQuery()
+		return &metricsQuery, nil
+	}
+
+	metricsQuery, err := v1metrics.NewQueryParser(sliQuery).Parse()
+	if err != nil {
+		var legacyErr error
+		metricsQuery, legacyErr = v1metrics.NewLegacyQueryParser(sliQuery).Parse()
+		if legacyErr != nil {
+			return nil, err
+		}
+	}
+	return metricsQuery, nil
+}
+
+func getSLOName(project string, stage string, service string, sli string) string {
+	return sli + " (Keptn." + project + "." + stage + "." + service + ")"
+}
+
+// getSLOMetricName returns the name of the metric Dynatrace creates for the SLO, which may only contain lower case letters, digits and underscores.
+func getSLOMetricName(project string, stage string, service string, sli string) string {
+	return invalidSLOMetricNameCharacters.ReplaceAllString(strings.ToLower("keptn_"+project+"_"+stage+"_"+service+"_"+sli), "_")
+}
@@ -0,0 +1,184 @@
+package monitoring
+
+import (
+	"context"
+	"testing"
+
+	keptnlib "github.com/keptn/go-utils/pkg/lib"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+	"github.com/keptn-contrib/dynatrace-service/internal/test"
+)
+
+type sliAndSLOReaderMock struct {
+	slis map[string]string
+	slos *keptnlib.ServiceLevelObjectives
+}
+
+func (m *sliAndSLOReaderMock) GetSLIs(_ context.Context, _ string, _ string, _ string) (map[string]string, error) {
+	return m.slis, nil
+}
+
+func (m *sliAndSLOReaderMock) GetSLOs(_ context.Context, _ string, _ string, _ string) (*keptnlib.ServiceLevelObjectives, error) {
+	return m.slos, nil
+}
+
+func newSLOCriteria(criteria ...string) []*keptnlib.SLOCriteria {
+	return []*keptnlib.SLOCriteria{{Criteria: criteria}}
+}
+
+func createSLOsReader() *sliAndSLOReaderMock {
+	return &sliAndSLOReaderMock{
+		slis: map[string]string{
+			"success_rate": "metricSelector=calc:service.successrate:filter(eq(\"service\",\"$SERVICE\")):avg&entitySelector=type(SERVICE)",
+		},
+		slos: &keptnlib.ServiceLevelObjectives{
+			Objectives: []*keptnlib.SLO{
+				{SLI: "error_rate", Pass: newSLOCriteria("<=5")},
+				{SLI: "success_rate", Pass: newSLOCriteria(">=99"), Warning: newSLOCriteria(">=95")},
+				{SLI: "response_time_p95", Pass: newSLOCriteria("<=600")},
+			},
+		},
+	}
+}
+
+// TestSLOCreation_Create tests that SLOs are created or updated for all objectives that can be mapped.
+func TestSLOCreation_Create(t *testing.T) {
+	handler := test.NewFileBasedURLHandlerWithSink(t)
+	handler.AddExact("/api/v2/slo?evaluate=false&pageSize=500", "./testdata/slos/slos.json")
+	handler.AddExact("/api/v2/slo", "./testdata/slos/created.json")
+	handler.AddExact("/api/v2/slo/slo-1", "./testdata/slos/created.json")
+
+	dtClient, teardown := createDynatraceClient(t, handler)
+	defer teardown()
+
+	results := newSLOCreation(dtClient, createSLOsReader()).create(context.Background(), "sockshop", "dev", "carts")
+	assert.Equal(t, []configResult{
+		{Name: "error_rate (Keptn.sockshop.dev.carts)", Success: true},
+		{Name: "success_rate (Keptn.sockshop.dev.carts)", Success: true},
+	}, results)
+
+	var updatedSLO dynatrace.SLODefinition
+	handler.GetStoredPayloadForURL("/api/v2/slo/slo-1", &updatedSLO)
+	assert.Equal(t, "(100)-(builtin:service.errors.total.rate:merge(\"dt.entity.service\"):avg)", updatedSLO.MetricExpression)
+
+	var createdSLO dynatrace.SLODefinition
+	handler.GetStoredPayloadForURL("/api/v2/slo", &createdSLO)
+	assert.Equal(t, dynatrace.SLODefinition{
+		Enabled:           true,
+		Name:              "success_rate (Keptn.sockshop.dev.carts)",
+		CustomDescription: "Keptn objective success_rate of service carts in stage dev of project sockshop",
+		MetricName:        "keptn_sockshop_dev_carts_success_rate",
+		MetricExpression:  "calc:service.successrate:filter(eq(\"service\",\"carts\")):avg",
+		EvaluationType:    "AGGREGATE",
+		Filter:            "type(\"SERVICE\"),tag(\"keptn_project:sockshop\"),tag(\"keptn_stage:dev\"),tag(\"keptn_service:carts\")",
+		Target:            95,
+		Warning:           99,
+		Timeframe:         "-1w",
+	}, createdSLO)
+}
+
+// TestSLOCreation_Diff tests that only missing or modified SLOs are reported.
+func TestSLOCreation_Diff(t *testing.T) {
+	handler := test.NewFileBasedURLHandler(t)
+	handler.AddExact("/api/v2/slo?evaluate=false&pageSize=500", "./testdata/slos/slos.json")
+	handler.AddExact("/api/v2/slo/slo-1", "./testdata/slos/slo_1.json")
+
+	dtClient, teardown := createDynatraceClient(t, handler)
+	defer teardown()
+
+	changes, err := newSLOCreation(dtClient, createSLOsReader()).diff(context.Background(), "sockshop", "dev", "carts")
+	require.NoError(t, err)
+	assert.Equal(t, []configChange{{Name: "success_rate (Keptn.sockshop.dev.carts)", Action: changeActionCreate}}, changes)
+}
+
+func TestCreateSLOForObjective(t *testing.T) {
+	const errorRateQuery = "metricSelector=builtin:service.errors.total.rate:merge(\"dt.entity.service\"):avg&entitySelector=type(SERVICE)"
+
+	tests := []struct {
+		name                     string
+		objective                *keptnlib.SLO
+		query                    string
+		expectError              bool
+		expectedMetricExpression string
+		expectedTarget           float64
+		expectedWarning          float64
+	}{
+		{
+			name:                     "higher is better with warning",
+			objective:                &keptnlib.SLO{SLI: "availability", Pass: newSLOCriteria(">=99.5"), Warning: newSLOCriteria(">=98")},
+			query:                    "metricSelector=builtin:synthetic.browser.availability.location.total:avg",
+			expectedMetricExpression: "builtin:synthetic.browser.availability.location.total:avg",
+			expectedTarget:           98,
+			expectedWarning:          99.5,
+		},
+		{
+			name:                     "lower is better",
+			objective:                &keptnlib.SLO{SLI: "error_rate", Pass: newSLOCriteria("<5"), Warning: newSLOCriteria("<10")},
+			query:                    errorRateQuery,
+			expectedMetricExpression: "(100)-(builtin:service.errors.total.rate:merge(\"dt.entity.service\"):avg)",
+			expectedTarget:           90,
+			expectedWarning:          95,
+		},
+		{
+			name:                     "legacy query",
+			objective:                &keptnlib.SLO{SLI: "error_rate", Pass: newSLOCriteria("<=5")},
+			query:                    "builtin:service.errors.total.rate:merge(\"dt.entity.service\"):avg?scope=tag(keptn_project:sockshop)",
+			expectedMetricExpression: "(100)-(builtin:service.errors.total.rate:merge(\"dt.entity.service\"):avg)",
+			expectedTarget:           95,
+			expectedWarning:          95,
+		},
+		{
+			name:        "informative objective",
+			objective:   &keptnlib.SLO{SLI: "error_rate"},
+			query:       errorRateQuery,
+			expectError: true,
+		},
+		{
+			name:        "relative criteria",
+			objective:   &keptnlib.SLO{SLI: "error_rate", Pass: newSLOCriteria("<=+10%")},
+			query:       errorRateQuery,
+			expectError: true,
+		},
+		{
+			name:        "several criteria",
+			objective:   &keptnlib.SLO{SLI: "error_rate", Pass: newSLOCriteria("<=5", "<10")},
+			query:       errorRateQuery,
+			expectError: true,
+		},
+		{
+			name:        "different directions",
+			objective:   &keptnlib.SLO{SLI: "error_rate", Pass: newSLOCriteria("<=5"), Warning: newSLOCriteria(">=1")},
+			query:       errorRateQuery,
+			expectError: true,
+		},
+		{
+			name:        "not a percentage",
+			objective:   &keptnlib.SLO{SLI: "response_time_p95", Pass: newSLOCriteria("<=600")},
+			query:       "metricSelector=builtin:service.response.time:merge(\"dt.entity.service\"):percentile(95)",
+			expectError: true,
+		},
+		{
+			name:        "not a metrics query",
+			objective:   &keptnlib.SLO{SLI: "problems", Pass: newSLOCriteria("<=0")},
+			query:       "PV2;problemSelector=status(open)",
+			expectError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slo, err := createSLOForObjective("sockshop", "dev", "carts", tt.objective, tt.query)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedMetricExpression, slo.MetricExpression)
+			assert.Equal(t, tt.expectedTarget, slo.Target)
+			assert.Equal(t, tt.expectedWarning, slo.Warning)
+		})
+	}
+}
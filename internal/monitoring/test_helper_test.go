@@ -0,0 +1,23 @@
+package monitoring
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/credentials"
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+	"github.com/keptn-contrib/dynatrace-service/internal/test"
+)
+
+const testDynatraceAPIToken = "dt0c01.ST2EY72KQINMH574WMNVI7YN.G3DFPBEJYMODIDAEX454M7YWBUVEFOWKPRVMWFASS64NFH52PX6BNDVFFM572RZM"
+
+func createDynatraceClient(t *testing.T, handler http.Handler) (dynatrace.ClientInterface, func()) {
+	httpClient, url, teardown := test.CreateHTTPSClient(handler)
+
+	dynatraceCredentials, err := credentials.NewDynatraceCredentials(url, testDynatraceAPIToken)
+	assert.NoError(t, err)
+
+	return dynatrace.NewClientWithHTTP(dynatraceCredentials, httpClient), teardown
+}
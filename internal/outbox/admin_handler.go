@@ -0,0 +1,66 @@
+package outbox
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// AdminPath is the path of the endpoint listing the pending outbox entries.
+const AdminPath = "/outbox"
+
+type adminResponse struct {
+	Entries []Entry `json:"entries"`
+}
+
+// adminHandler is an http.Handler listing the pending outbox entries as JSON, oldest first.
+type adminHandler struct {
+	store Store
+}
+
+// NewAdminHandler creates a new http.Handler listing the pending entries of the store.
+func NewAdminHandler(store Store) http.Handler {
+	return &adminHandler{store: store}
+}
+
+// RunAdminEndpoint serves the pending entries of the store on the specified port. It blocks until the server fails.
+// As the entries contain the full event payloads, the endpoint only listens on the loopback interface, i.e. it can only be reached from within the pod, e.g. using port forwarding.
+func RunAdminEndpoint(port string, store Store) {
+	mux := http.NewServeMux()
+	mux.Handle(AdminPath, NewAdminHandler(store))
+
+	err := http.ListenAndServe("localhost:"+port, mux)
+	if err != nil {
+		log.WithError(err).Error("Outbox admin endpoint stopped")
+	}
+}
+
+// ServeHTTP lists the pending outbox entries.
+func (h *adminHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	entries := []Entry{}
+	if h.store != nil {
+		storedEntries, err := h.store.List(r.Context())
+		if err != nil {
+			log.WithError(err).Error("Could not list outbox entries")
+			http.Error(w, "could not list outbox entries", http.StatusInternalServerError)
+			return
+		}
+		entries = append(entries, storedEntries...)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].CreatedAt.Before(entries[j].CreatedAt)
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(adminResponse{Entries: entries}); err != nil {
+		log.WithError(err).Error("Could not write outbox entries")
+	}
+}
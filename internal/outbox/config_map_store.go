@@ -0,0 +1,125 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/keptn/go-utils/pkg/common/kubeutils"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/env"
+)
+
+const (
+	configMapNamePrefix = "dynatrace-service-outbox-"
+	configMapLabel      = "dynatrace-service/outbox"
+	configMapEntryKey   = "entry"
+)
+
+// ConfigMapStore is a Store persisting each entry as a K8s ConfigMap, so that entries survive restarts and are shared between replicas.
+type ConfigMapStore struct {
+	k8sClient kubernetes.Interface
+	namespace string
+}
+
+// NewConfigMapStore creates a new ConfigMapStore creating ConfigMaps in the specified namespace.
+func NewConfigMapStore(k8sClient kubernetes.Interface, namespace string) *ConfigMapStore {
+	return &ConfigMapStore{
+		k8sClient: k8sClient,
+		namespace: namespace,
+	}
+}
+
+// NewDefaultConfigMapStore creates a new ConfigMapStore using the default K8s client and the namespace of the pod.
+func NewDefaultConfigMapStore() (*ConfigMapStore, error) {
+	useInClusterConfig := env.GetKubernetesServiceHost() != ""
+	k8sClient, err := kubeutils.GetClientSet(useInClusterConfig)
+	if err != nil {
+		return nil, fmt.Errorf("could not initialize outbox ConfigMapStore: %w", err)
+	}
+	return NewConfigMapStore(k8sClient, env.GetPodNamespace()), nil
+}
+
+// Add adds a new entry.
+func (s *ConfigMapStore) Add(ctx context.Context, entry Entry) error {
+	configMap, err := s.toConfigMap(entry)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.k8sClient.CoreV1().ConfigMaps(s.namespace).Create(ctx, configMap, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("could not create outbox ConfigMap: %w", err)
+	}
+	return nil
+}
+
+// Update replaces an existing entry and returns the updated entry, or ErrConflict if it has been modified or removed since it was listed.
+func (s *ConfigMapStore) Update(ctx context.Context, entry Entry) (Entry, error) {
+	configMap, err := s.toConfigMap(entry)
+	if err != nil {
+		return Entry{}, err
+	}
+
+	updatedConfigMap, err := s.k8sClient.CoreV1().ConfigMaps(s.namespace).Update(ctx, configMap, metav1.UpdateOptions{})
+	if k8serrors.IsConflict(err) || k8serrors.IsNotFound(err) {
+		return Entry{}, ErrConflict
+	}
+	if err != nil {
+		return Entry{}, fmt.Errorf("could not update outbox ConfigMap: %w", err)
+	}
+
+	entry.version = updatedConfigMap.ResourceVersion
+	return entry, nil
+}
+
+// Remove removes the entry with the specified ID.
+func (s *ConfigMapStore) Remove(ctx context.Context, id string) error {
+	err := s.k8sClient.CoreV1().ConfigMaps(s.namespace).Delete(ctx, configMapNamePrefix+id, metav1.DeleteOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("could not delete outbox ConfigMap: %w", err)
+	}
+	return nil
+}
+
+// List returns all entries.
+func (s *ConfigMapStore) List(ctx context.Context) ([]Entry, error) {
+	configMaps, err := s.k8sClient.CoreV1().ConfigMaps(s.namespace).List(ctx, metav1.ListOptions{LabelSelector: configMapLabel + "=true"})
+	if err != nil {
+		return nil, fmt.Errorf("could not list outbox ConfigMaps: %w", err)
+	}
+
+	entries := make([]Entry, 0, len(configMaps.Items))
+	for _, configMap := range configMaps.Items {
+		var entry Entry
+		if err := json.Unmarshal([]byte(configMap.Data[configMapEntryKey]), &entry); err != nil {
+			return nil, fmt.Errorf("could not parse outbox ConfigMap %s: %w", configMap.Name, err)
+		}
+		entry.version = configMap.ResourceVersion
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func (s *ConfigMapStore) toConfigMap(entry Entry) (*corev1.ConfigMap, error) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal outbox entry: %w", err)
+	}
+
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            configMapNamePrefix + entry.ID,
+			Namespace:       s.namespace,
+			Labels:          map[string]string{configMapLabel: "true"},
+			ResourceVersion: entry.version,
+		},
+		Data: map[string]string{
+			configMapEntryKey: string(data),
+		},
+	}, nil
+}
@@ -0,0 +1,150 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+	"github.com/keptn-contrib/dynatrace-service/internal/metrics"
+)
+
+// EventsClient is a dynatrace.EventsClientInterface decorator that adds events that could not be sent due to a temporary failure to the outbox, so that they are retried later.
+type EventsClient struct {
+	eventsClient          dynatrace.EventsClientInterface
+	store                 Store
+	credentialsSecretName string
+	eventsAPIVersion      string
+}
+
+// NewEventsClient creates a new EventsClient adding failed events to the store.
+// The credentials secret name and events API version are recorded to send the events to the same Dynatrace tenant in the same way later.
+func NewEventsClient(eventsClient dynatrace.EventsClientInterface, store Store, credentialsSecretName string, eventsAPIVersion string) *EventsClient {
+	return &EventsClient{
+		eventsClient:          eventsClient,
+		store:                 store,
+		credentialsSecretName: credentialsSecretName,
+		eventsAPIVersion:      eventsAPIVersion,
+	}
+}
+
+// AddAnnotationEvent sends an annotation event or adds it to the outbox.
+func (c *EventsClient) AddAnnotationEvent(ctx context.Context, ae dynatrace.AnnotationEvent) error {
+	return c.sendOrAdd(ctx, ae.EventType, ae, c.eventsClient.AddAnnotationEvent(ctx, ae))
+}
+
+// AddConfigurationEvent sends a configuration event or adds it to the outbox.
+func (c *EventsClient) AddConfigurationEvent(ctx context.Context, ce dynatrace.ConfigurationEvent) error {
+	return c.sendOrAdd(ctx, ce.EventType, ce, c.eventsClient.AddConfigurationEvent(ctx, ce))
+}
+
+// AddDeploymentEvent sends a deployment event or adds it to the outbox.
+func (c *EventsClient) AddDeploymentEvent(ctx context.Context, de dynatrace.DeploymentEvent) error {
+	return c.sendOrAdd(ctx, de.EventType, de, c.eventsClient.AddDeploymentEvent(ctx, de))
+}
+
+// AddInfoEvent sends an info event or adds it to the outbox.
+func (c *EventsClient) AddInfoEvent(ctx context.Context, ie dynatrace.InfoEvent) error {
+	return c.sendOrAdd(ctx, ie.EventType, ie, c.eventsClient.AddInfoEvent(ctx, ie))
+}
+
+// AddMarkedForTerminationEvent sends an event marking entities for termination or adds it to the outbox.
+func (c *EventsClient) AddMarkedForTerminationEvent(ctx context.Context, me dynatrace.MarkedForTerminationEvent) error {
+	return c.sendOrAdd(ctx, me.EventType, me, c.eventsClient.AddMarkedForTerminationEvent(ctx, me))
+}
+
+// sendOrAdd adds the event to the outbox if sending it failed temporarily. Otherwise, the result of sending the event is returned.
+func (c *EventsClient) sendOrAdd(ctx context.Context, eventType string, dtEvent interface{}, sendErr error) error {
+	if sendErr == nil || !isTemporaryError(sendErr) {
+		return sendErr
+	}
+
+	entry, err := newEntry(eventType, dtEvent, c.credentialsSecretName, c.eventsAPIVersion, time.Now())
+	if err == nil {
+		entry.Payload, err = withRemainingAttachRules(entry.Payload, sendErr)
+	}
+	if err != nil {
+		log.WithError(err).Error("Could not create outbox entry")
+		return sendErr
+	}
+
+	if err := c.store.Add(ctx, entry); err != nil {
+		log.WithError(err).Error("Could not add event to outbox")
+		return sendErr
+	}
+
+	metrics.OutboxEntriesTotal.WithLabelValues(outboxResultAdded).Inc()
+	log.WithError(sendErr).WithField("id", entry.ID).Warn("Could not send event to Dynatrace, added it to the outbox to retry later")
+	return nil
+}
+
+func newEntry(eventType string, dtEvent interface{}, credentialsSecretName string, eventsAPIVersion string, now time.Time) (Entry, error) {
+	id, err := newEntryID()
+	if err != nil {
+		return Entry{}, err
+	}
+
+	payload, err := json.Marshal(dtEvent)
+	if err != nil {
+		return Entry{}, fmt.Errorf("could not marshal event payload: %w", err)
+	}
+
+	return Entry{
+		ID:                    id,
+		EventType:             eventType,
+		Payload:               payload,
+		CredentialsSecretName: credentialsSecretName,
+		EventsAPIVersion:      eventsAPIVersion,
+		CreatedAt:             now,
+		Attempts:              1,
+		NextAttemptAt:         now,
+		LastError:             "",
+	}, nil
+}
+
+// withRemainingAttachRules replaces the attach rules of the payload with the remaining ones if the event was only sent for some of its entity selectors, so that it is not sent again for the others when it is retried.
+func withRemainingAttachRules(payload []byte, sendErr error) ([]byte, error) {
+	var partialErr *dynatrace.PartialEventIngestError
+	if !errors.As(sendErr, &partialErr) {
+		return payload, nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &fields); err != nil {
+		return nil, fmt.Errorf("could not parse event payload: %w", err)
+	}
+
+	attachRules, err := json.Marshal(partialErr.RemainingAttachRules)
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal remaining attach rules: %w", err)
+	}
+	fields["attachRules"] = attachRules
+
+	return json.Marshal(fields)
+}
+
+// isTemporaryError returns whether sending the event may succeed later, i.e. if it failed due to a network error, a timeout, or Dynatrace being overloaded or failing itself.
+// Other errors, e.g. events that could not be marshaled or cancelled requests, are not temporary.
+func isTemporaryError(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var apiErr *dynatrace.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Code() == http.StatusTooManyRequests || apiErr.Code() >= http.StatusInternalServerError
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
@@ -0,0 +1,159 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/credentials"
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+)
+
+// TestEventsClient tests that only events that could not be sent due to a temporary failure are added to the outbox.
+func TestEventsClient(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		wantError    bool
+		wantEntries  int
+		wantReceived int
+	}{
+		{
+			name:         "sent event",
+			status:       http.StatusOK,
+			wantReceived: 1,
+		},
+		{
+			name:        "server error",
+			status:      http.StatusServiceUnavailable,
+			wantEntries: 1,
+		},
+		{
+			name:        "too many requests",
+			status:      http.StatusTooManyRequests,
+			wantEntries: 1,
+		},
+		{
+			name:      "rejected event",
+			status:    http.StatusBadRequest,
+			wantError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventsClient, api := createEventsClient(t, tt.status)
+			store, err := NewFileStore(t.TempDir())
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			client := NewEventsClient(eventsClient, store, "dynatrace", dynatrace.EventsAPIVersion2)
+			err = client.AddDeploymentEvent(context.Background(), dynatrace.DeploymentEvent{EventType: dynatrace.DeploymentEventType, DeploymentName: "my-deployment"})
+			if tt.wantError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wantReceived, api.getReceived())
+
+			entries, err := store.List(context.Background())
+			assert.NoError(t, err)
+			if !assert.Len(t, entries, tt.wantEntries) || tt.wantEntries == 0 {
+				return
+			}
+
+			assert.Equal(t, dynatrace.DeploymentEventType, entries[0].EventType)
+			assert.Equal(t, "dynatrace", entries[0].CredentialsSecretName)
+			assert.Equal(t, dynatrace.EventsAPIVersion2, entries[0].EventsAPIVersion)
+			assert.JSONEq(t, `{"eventType":"CUSTOM_DEPLOYMENT","source":"","deploymentName":"my-deployment","deploymentVersion":"","deploymentProject":"","customProperties":null,"attachRules":{}}`, string(entries[0].Payload))
+		})
+	}
+}
+
+func TestIsTemporaryError(t *testing.T) {
+	networkErr := &url.Error{Op: "Post", URL: "https://mySampleEnv.live.dynatrace.com/api/v2/events/ingest", Err: errors.New("connection refused")}
+
+	assert.True(t, isTemporaryError(fmt.Errorf("could not create event: %w", networkErr)))
+	assert.True(t, isTemporaryError(fmt.Errorf("could not create event: %w", context.DeadlineExceeded)))
+	assert.False(t, isTemporaryError(fmt.Errorf("could not create event: %w", context.Canceled)))
+	assert.False(t, isTemporaryError(&url.Error{Op: "Post", URL: networkErr.URL, Err: context.Canceled}))
+	assert.False(t, isTemporaryError(errors.New("could not marshal event payload: json: unsupported type: chan int")))
+}
+
+// TestEventsClient_PartiallySentEvent tests that an event only sent for some of its entity selectors is only retried for the remaining ones.
+func TestEventsClient_PartiallySentEvent(t *testing.T) {
+	t.Setenv("DYNATRACE_API_MAX_RETRIES", "0")
+
+	failing := true
+	var receivedEntitySelectors []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var ingestedEvent struct {
+			EntitySelector string `json:"entitySelector"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&ingestedEvent))
+
+		if failing && strings.HasPrefix(ingestedEvent.EntitySelector, `type("PROCESS_GROUP")`) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"error":{"code":503,"message":"Service Unavailable"}}`))
+			return
+		}
+
+		receivedEntitySelectors = append(receivedEntitySelectors, ingestedEvent.EntitySelector)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	dynatraceCredentials, err := credentials.NewDynatraceCredentials(server.URL, testDynatraceAPIToken)
+	if !assert.NoError(t, err) {
+		return
+	}
+	eventsClient := dynatrace.NewEventsV2Client(dynatrace.NewClientWithHTTP(dynatraceCredentials, server.Client()))
+
+	store, err := NewFileStore(t.TempDir())
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	err = NewEventsClient(eventsClient, store, "dynatrace", dynatrace.EventsAPIVersion2).AddDeploymentEvent(context.Background(), dynatrace.DeploymentEvent{
+		EventType:      dynatrace.DeploymentEventType,
+		DeploymentName: "my-deployment",
+		AttachRules: dynatrace.AttachRules{
+			EntityIds: []string{"SERVICE-1"},
+			TagRule:   []dynatrace.TagRule{{MeTypes: []string{"SERVICE", "PROCESS_GROUP"}, Tags: []dynatrace.TagEntry{{Key: "keptn_project", Value: "sockshop"}}}},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{`entityId("SERVICE-1")`, `type("SERVICE"),tag("keptn_project:sockshop")`}, receivedEntitySelectors)
+
+	entries := listEntries(t, store)
+	if !assert.Len(t, entries, 1) {
+		return
+	}
+
+	var storedEvent dynatrace.DeploymentEvent
+	assert.NoError(t, json.Unmarshal(entries[0].Payload, &storedEvent))
+	assert.Equal(t, "my-deployment", storedEvent.DeploymentName)
+	assert.Equal(t, dynatrace.AttachRules{
+		TagRule: []dynatrace.TagRule{{MeTypes: []string{"PROCESS_GROUP"}, Tags: []dynatrace.TagEntry{{Key: "keptn_project", Value: "sockshop"}}}},
+	}, storedEvent.AttachRules)
+
+	failing = false
+	relay := NewRelay(store, func(_ context.Context, _ string, _ string) (dynatrace.EventsClientInterface, error) {
+		return eventsClient, nil
+	}, time.Minute, time.Hour, time.Hour)
+	relay.relayDueEntries(context.Background())
+
+	assert.Empty(t, listEntries(t, store))
+	assert.Equal(t, []string{`entityId("SERVICE-1")`, `type("SERVICE"),tag("keptn_project:sockshop")`, `type("PROCESS_GROUP"),tag("keptn_project:sockshop")`}, receivedEntitySelectors)
+}
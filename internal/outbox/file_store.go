@@ -0,0 +1,135 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+const entryFileExtension = ".json"
+
+// FileStore is a Store persisting each entry as a file in a directory, e.g. on a persistent volume.
+// The directory must not be shared between multiple instances.
+type FileStore struct {
+	mutex     sync.Mutex
+	directory string
+}
+
+// NewFileStore creates a new FileStore using the specified directory, which is created if it does not exist.
+func NewFileStore(directory string) (*FileStore, error) {
+	if directory == "" {
+		return nil, errors.New("no directory for the outbox has been specified")
+	}
+
+	if err := os.MkdirAll(directory, 0700); err != nil {
+		return nil, fmt.Errorf("could not create outbox directory: %w", err)
+	}
+
+	return &FileStore{directory: directory}, nil
+}
+
+// Add adds a new entry.
+func (s *FileStore) Add(_ context.Context, entry Entry) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.write(entry)
+}
+
+// Update replaces an existing entry and returns the updated entry, or ErrConflict if it has been removed.
+func (s *FileStore) Update(_ context.Context, entry Entry) (Entry, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, err := os.Stat(s.getPath(entry.ID)); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return Entry{}, ErrConflict
+		}
+		return Entry{}, fmt.Errorf("could not read outbox entry: %w", err)
+	}
+
+	if err := s.write(entry); err != nil {
+		return Entry{}, err
+	}
+	return entry, nil
+}
+
+// Remove removes the entry with the specified ID.
+func (s *FileStore) Remove(_ context.Context, id string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	err := os.Remove(s.getPath(id))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("could not remove outbox entry: %w", err)
+	}
+	return nil
+}
+
+// List returns all entries.
+func (s *FileStore) List(_ context.Context) ([]Entry, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	files, err := os.ReadDir(s.directory)
+	if err != nil {
+		return nil, fmt.Errorf("could not read outbox directory: %w", err)
+	}
+
+	var entries []Entry
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), entryFileExtension) {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(s.directory, file.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("could not read outbox entry: %w", err)
+		}
+
+		var entry Entry
+		if err := json.Unmarshal(data, &entry); err != nil {
+			return nil, fmt.Errorf("could not parse outbox entry %s: %w", file.Name(), err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func (s *FileStore) getPath(id string) string {
+	return filepath.Join(s.directory, id+entryFileExtension)
+}
+
+// write atomically writes the entry to its file. The caller must hold the mutex.
+func (s *FileStore) write(entry Entry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("could not marshal outbox entry: %w", err)
+	}
+
+	// temporary files don't have the entry file extension, so they are never listed
+	tempFile, err := os.CreateTemp(s.directory, entry.ID+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("could not create temporary outbox entry file: %w", err)
+	}
+	defer os.Remove(tempFile.Name())
+
+	_, err = tempFile.Write(data)
+	closeErr := tempFile.Close()
+	if err != nil {
+		return fmt.Errorf("could not write outbox entry: %w", err)
+	}
+	if closeErr != nil {
+		return fmt.Errorf("could not write outbox entry: %w", closeErr)
+	}
+
+	if err := os.Rename(tempFile.Name(), s.getPath(entry.ID)); err != nil {
+		return fmt.Errorf("could not replace outbox entry: %w", err)
+	}
+	return nil
+}
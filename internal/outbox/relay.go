@@ -0,0 +1,236 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/credentials"
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+	"github.com/keptn-contrib/dynatrace-service/internal/env"
+	"github.com/keptn-contrib/dynatrace-service/internal/metrics"
+)
+
+const (
+	outboxResultAdded    = "added"
+	outboxResultSent     = "sent"
+	outboxResultExpired  = "expired"
+	outboxResultRejected = "rejected"
+)
+
+// EventsClientFactory creates a dynatrace.EventsClientInterface using the specified events API version for the Dynatrace tenant whose credentials are stored in the specified secret.
+type EventsClientFactory func(ctx context.Context, credentialsSecretName string, eventsAPIVersion string) (dynatrace.EventsClientInterface, error)
+
+// Relay periodically retries to send the events in the outbox to Dynatrace.
+type Relay struct {
+	store              Store
+	createEventsClient EventsClientFactory
+	interval           time.Duration
+	maxBackoff         time.Duration
+	maxAge             time.Duration
+	now                func() time.Time
+}
+
+// NewRelay creates a new Relay that checks the store for due entries at every interval.
+// The delay between two attempts of an entry starts with the interval and doubles up to maxBackoff. Entries older than maxAge are discarded.
+func NewRelay(store Store, createEventsClient EventsClientFactory, interval time.Duration, maxBackoff time.Duration, maxAge time.Duration) *Relay {
+	return &Relay{
+		store:              store,
+		createEventsClient: createEventsClient,
+		interval:           interval,
+		maxBackoff:         maxBackoff,
+		maxAge:             maxAge,
+		now:                time.Now,
+	}
+}
+
+// NewDefaultRelay creates a new Relay configured by the OUTBOX_* environment variables, reading credentials from K8s secrets.
+func NewDefaultRelay(store Store) *Relay {
+	return NewRelay(store, createEventsClientFromSecret, env.GetOutboxRetryInterval(), env.GetOutboxMaxBackoff(), env.GetOutboxMaxAge())
+}
+
+func createEventsClientFromSecret(ctx context.Context, credentialsSecretName string, eventsAPIVersion string) (dynatrace.EventsClientInterface, error) {
+	credentialsProvider, err := credentials.NewDefaultDynatraceK8sSecretReader()
+	if err != nil {
+		return nil, fmt.Errorf("could not create Dynatrace credentials reader: %w", err)
+	}
+
+	dynatraceCredentials, err := credentialsProvider.GetDynatraceCredentials(ctx, credentialsSecretName)
+	if err != nil {
+		return nil, fmt.Errorf("could not get Dynatrace credentials: %w", err)
+	}
+
+	return dynatrace.NewEventsClientForAPIVersion(dynatrace.NewClient(dynatraceCredentials), eventsAPIVersion)
+}
+
+// Run relays due entries at every interval until the context is done.
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		r.relayDueEntries(ctx)
+
+		select {
+		case <-ctx.Done():
+			log.Info("Outbox relay has been stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// relayDueEntries tries to send all entries whose next attempt is due and discards expired entries.
+func (r *Relay) relayDueEntries(ctx context.Context) {
+	entries, err := r.store.List(ctx)
+	if err != nil {
+		log.WithError(err).Error("Could not list outbox entries")
+		return
+	}
+	metrics.OutboxPendingEntries.Set(float64(len(entries)))
+
+	now := r.now()
+	for _, entry := range entries {
+		if ctx.Err() != nil {
+			return
+		}
+
+		if now.Sub(entry.CreatedAt) > r.maxAge {
+			log.WithField("id", entry.ID).WithField("lastError", entry.LastError).Error("Discarding outbox entry that could not be sent within the maximum age")
+			r.remove(ctx, entry, outboxResultExpired)
+			continue
+		}
+
+		if now.Before(entry.NextAttemptAt) {
+			continue
+		}
+
+		r.relay(ctx, entry)
+	}
+}
+
+// relay claims the entry by scheduling its next attempt, so that it is not sent concurrently by another replica, and then tries to send it.
+func (r *Relay) relay(ctx context.Context, entry Entry) {
+	sendEvent, err := parsePayload(entry)
+	if err != nil {
+		log.WithError(err).WithField("id", entry.ID).Error("Discarding invalid outbox entry")
+		r.remove(ctx, entry, outboxResultRejected)
+		return
+	}
+
+	entry.NextAttemptAt = r.now().Add(r.getBackoff(entry.Attempts))
+	entry.Attempts++
+	entry, err = r.store.Update(ctx, entry)
+	if err != nil {
+		if !errors.Is(err, ErrConflict) {
+			log.WithError(err).Error("Could not update outbox entry")
+		}
+		return
+	}
+
+	eventsClient, err := r.createEventsClient(ctx, entry.CredentialsSecretName, entry.EventsAPIVersion)
+	if err == nil {
+		err = sendEvent(ctx, eventsClient)
+	}
+
+	if err == nil {
+		log.WithField("id", entry.ID).Info("Sent event from outbox to Dynatrace")
+		r.remove(ctx, entry, outboxResultSent)
+		return
+	}
+
+	if !isTemporaryError(err) {
+		log.WithError(err).WithField("id", entry.ID).Error("Discarding outbox entry that was rejected by Dynatrace")
+		r.remove(ctx, entry, outboxResultRejected)
+		return
+	}
+
+	log.WithError(err).WithField("id", entry.ID).WithField("attempts", entry.Attempts).Warn("Could not send event from outbox to Dynatrace")
+	entry.LastError = err.Error()
+	if payload, err := withRemainingAttachRules(entry.Payload, err); err == nil {
+		entry.Payload = payload
+	} else {
+		log.WithError(err).WithField("id", entry.ID).Error("Could not update remaining attach rules of outbox entry")
+	}
+	if _, err := r.store.Update(ctx, entry); err != nil && !errors.Is(err, ErrConflict) {
+		log.WithError(err).WithField("id", entry.ID).Error("Could not update outbox entry")
+	}
+}
+
+func (r *Relay) remove(ctx context.Context, entry Entry, result string) {
+	if err := r.store.Remove(ctx, entry.ID); err != nil {
+		log.WithError(err).WithField("id", entry.ID).Error("Could not remove outbox entry")
+		return
+	}
+	metrics.OutboxEntriesTotal.WithLabelValues(result).Inc()
+}
+
+// getBackoff gets the delay after the specified number of attempts, starting with the interval and doubling up to the maximum backoff.
+func (r *Relay) getBackoff(attempts int) time.Duration {
+	backoff := r.interval
+	for i := 1; i < attempts && backoff < r.maxBackoff; i++ {
+		backoff *= 2
+	}
+
+	if backoff > r.maxBackoff {
+		return r.maxBackoff
+	}
+	return backoff
+}
+
+// parsePayload parses the payload of the entry and returns a function sending it using an events client.
+func parsePayload(entry Entry) (func(ctx context.Context, eventsClient dynatrace.EventsClientInterface) error, error) {
+	switch entry.EventType {
+	case dynatrace.AnnotationEventType:
+		var ae dynatrace.AnnotationEvent
+		if err := json.Unmarshal(entry.Payload, &ae); err != nil {
+			return nil, fmt.Errorf("could not parse annotation event: %w", err)
+		}
+		return func(ctx context.Context, eventsClient dynatrace.EventsClientInterface) error {
+			return eventsClient.AddAnnotationEvent(ctx, ae)
+		}, nil
+
+	case dynatrace.ConfigurationEventType:
+		var ce dynatrace.ConfigurationEvent
+		if err := json.Unmarshal(entry.Payload, &ce); err != nil {
+			return nil, fmt.Errorf("could not parse configuration event: %w", err)
+		}
+		return func(ctx context.Context, eventsClient dynatrace.EventsClientInterface) error {
+			return eventsClient.AddConfigurationEvent(ctx, ce)
+		}, nil
+
+	case dynatrace.DeploymentEventType:
+		var de dynatrace.DeploymentEvent
+		if err := json.Unmarshal(entry.Payload, &de); err != nil {
+			return nil, fmt.Errorf("could not parse deployment event: %w", err)
+		}
+		return func(ctx context.Context, eventsClient dynatrace.EventsClientInterface) error {
+			return eventsClient.AddDeploymentEvent(ctx, de)
+		}, nil
+
+	case dynatrace.InfoEventType:
+		var ie dynatrace.InfoEvent
+		if err := json.Unmarshal(entry.Payload, &ie); err != nil {
+			return nil, fmt.Errorf("could not parse info event: %w", err)
+		}
+		return func(ctx context.Context, eventsClient dynatrace.EventsClientInterface) error {
+			return eventsClient.AddInfoEvent(ctx, ie)
+		}, nil
+
+	case dynatrace.MarkedForTerminationEventType:
+		var me dynatrace.MarkedForTerminationEvent
+		if err := json.Unmarshal(entry.Payload, &me); err != nil {
+			return nil, fmt.Errorf("could not parse marked for termination event: %w", err)
+		}
+		return func(ctx context.Context, eventsClient dynatrace.EventsClientInterface) error {
+			return eventsClient.AddMarkedForTerminationEvent(ctx, me)
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("unknown event type: %s", entry.EventType)
+	}
+}
@@ -0,0 +1,146 @@
+package outbox
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+)
+
+// TestRelay tests that due entries are retried with an increasing backoff until they are sent, while expired and rejected entries are discarded.
+func TestRelay(t *testing.T) {
+	eventsClient, api := createEventsClient(t, http.StatusServiceUnavailable)
+	store, err := NewFileStore(t.TempDir())
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	now := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+	entry, err := newEntry(dynatrace.InfoEventType, dynatrace.InfoEvent{EventType: dynatrace.InfoEventType, Title: "my-title"}, "dynatrace", dynatrace.EventsAPIVersion2, now)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.NoError(t, store.Add(context.Background(), entry))
+
+	relay := NewRelay(store, func(_ context.Context, credentialsSecretName string, eventsAPIVersion string) (dynatrace.EventsClientInterface, error) {
+		assert.Equal(t, "dynatrace", credentialsSecretName)
+		assert.Equal(t, dynatrace.EventsAPIVersion2, eventsAPIVersion)
+		return eventsClient, nil
+	}, time.Minute, 3*time.Minute, time.Hour)
+	relay.now = func() time.Time { return now }
+
+	// first retry fails and is scheduled after the interval
+	relay.relayDueEntries(context.Background())
+	entries := listEntries(t, store)
+	assert.Equal(t, 2, entries[0].Attempts)
+	assert.Equal(t, now.Add(time.Minute), entries[0].NextAttemptAt)
+	assert.Contains(t, entries[0].LastError, "503")
+
+	// entry is not yet due
+	now = now.Add(30 * time.Second)
+	relay.relayDueEntries(context.Background())
+	assert.Equal(t, 2, listEntries(t, store)[0].Attempts)
+
+	// second retry fails and is scheduled after twice the interval
+	now = now.Add(30 * time.Second)
+	relay.relayDueEntries(context.Background())
+	entries = listEntries(t, store)
+	assert.Equal(t, 3, entries[0].Attempts)
+	assert.Equal(t, now.Add(2*time.Minute), entries[0].NextAttemptAt)
+
+	// third retry succeeds and the entry is removed
+	now = now.Add(2 * time.Minute)
+	api.setStatus(http.StatusOK)
+	relay.relayDueEntries(context.Background())
+	assert.Empty(t, listEntries(t, store))
+	assert.Equal(t, 1, api.getReceived())
+}
+
+// TestRelay_MarkedForTerminationEvent tests that entries of events marking entities for termination are decoded and sent.
+func TestRelay_MarkedForTerminationEvent(t *testing.T) {
+	eventsClient, api := createEventsClient(t, http.StatusOK)
+	store, err := NewFileStore(t.TempDir())
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	now := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+	entry, err := newEntry(dynatrace.MarkedForTerminationEventType, dynatrace.MarkedForTerminationEvent{EventType: dynatrace.MarkedForTerminationEventType, Description: "my-description"}, "dynatrace", "", now)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.NoError(t, store.Add(context.Background(), entry))
+
+	relay := NewRelay(store, func(_ context.Context, _ string, _ string) (dynatrace.EventsClientInterface, error) {
+		return eventsClient, nil
+	}, time.Minute, time.Hour, time.Hour)
+	relay.now = func() time.Time { return now }
+
+	relay.relayDueEntries(context.Background())
+	assert.Empty(t, listEntries(t, store))
+	assert.Equal(t, 1, api.getReceived())
+}
+
+func TestRelay_DiscardsEntries(t *testing.T) {
+	now := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		status    int
+		createdAt time.Time
+		eventType string
+	}{
+		{
+			name:      "expired entry",
+			status:    http.StatusOK,
+			createdAt: now.Add(-2 * time.Hour),
+			eventType: dynatrace.InfoEventType,
+		},
+		{
+			name:      "rejected entry",
+			status:    http.StatusBadRequest,
+			createdAt: now,
+			eventType: dynatrace.InfoEventType,
+		},
+		{
+			name:      "entry with unknown event type",
+			status:    http.StatusOK,
+			createdAt: now,
+			eventType: "UNKNOWN",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventsClient, api := createEventsClient(t, tt.status)
+			store, err := NewFileStore(t.TempDir())
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			entry, err := newEntry(tt.eventType, dynatrace.InfoEvent{EventType: dynatrace.InfoEventType}, "dynatrace", "", tt.createdAt)
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.NoError(t, store.Add(context.Background(), entry))
+
+			relay := NewRelay(store, func(_ context.Context, _ string, _ string) (dynatrace.EventsClientInterface, error) {
+				return eventsClient, nil
+			}, time.Minute, time.Hour, time.Hour)
+			relay.now = func() time.Time { return now }
+
+			relay.relayDueEntries(context.Background())
+			assert.Empty(t, listEntries(t, store))
+			assert.Equal(t, 0, api.getReceived())
+		})
+	}
+}
+
+func listEntries(t *testing.T, store Store) []Entry {
+	entries, err := store.List(context.Background())
+	assert.NoError(t, err)
+	return entries
+}
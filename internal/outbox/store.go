@@ -0,0 +1,93 @@
+package outbox
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/env"
+)
+
+const (
+	storeTypeNone      = "none"
+	storeTypeConfigMap = "configmap"
+	storeTypeFile      = "file"
+)
+
+// ErrConflict is returned when updating an entry that has been modified concurrently, e.g. by another replica.
+var ErrConflict = errors.New("outbox entry has been modified concurrently")
+
+// Entry is an event that could not be sent to Dynatrace and is waiting to be retried.
+type Entry struct {
+	// ID identifies the entry.
+	ID string `json:"id"`
+
+	// EventType is the Dynatrace event type of the payload, e.g. CUSTOM_DEPLOYMENT.
+	EventType string `json:"eventType"`
+
+	// Payload is the event as it would have been sent to Dynatrace.
+	Payload json.RawMessage `json:"payload"`
+
+	// CredentialsSecretName is the name of the secret holding the credentials of the Dynatrace tenant the event should be sent to.
+	CredentialsSecretName string `json:"credentialsSecretName"`
+
+	// EventsAPIVersion is the version of the Dynatrace events API the event should be sent with. If empty, the Events API v1 is used.
+	EventsAPIVersion string `json:"eventsApiVersion,omitempty"`
+
+	// CreatedAt is the time the event was first attempted to be sent.
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Attempts is the number of times the event has been attempted to be sent.
+	Attempts int `json:"attempts"`
+
+	// NextAttemptAt is the earliest time of the next attempt.
+	NextAttemptAt time.Time `json:"nextAttemptAt"`
+
+	// LastError is the error of the last attempt.
+	LastError string `json:"lastError,omitempty"`
+
+	// version is used by stores to detect concurrent modifications.
+	version string
+}
+
+// newEntryID creates a new random entry ID.
+func newEntryID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("could not create outbox entry ID: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Store persists outbox entries.
+type Store interface {
+	// Add adds a new entry.
+	Add(ctx context.Context, entry Entry) error
+
+	// Update replaces an existing entry and returns the updated entry, or ErrConflict if it has been modified or removed since it was listed.
+	Update(ctx context.Context, entry Entry) (Entry, error)
+
+	// Remove removes the entry with the specified ID.
+	Remove(ctx context.Context, id string) error
+
+	// List returns all entries.
+	List(ctx context.Context) ([]Entry, error)
+}
+
+// NewDefaultStore creates the Store configured by the OUTBOX_* environment variables. It returns nil if the outbox is disabled.
+func NewDefaultStore() (Store, error) {
+	switch storeType := env.GetOutboxStore(); storeType {
+	case storeTypeNone:
+		return nil, nil
+	case storeTypeConfigMap:
+		return NewDefaultConfigMapStore()
+	case storeTypeFile:
+		return NewFileStore(env.GetOutboxDirectory())
+	default:
+		return nil, fmt.Errorf("unknown outbox store type: %s", storeType)
+	}
+}
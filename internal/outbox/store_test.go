@@ -0,0 +1,106 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// TestStores tests adding, updating, listing and removing entries using all stores.
+func TestStores(t *testing.T) {
+	tests := []struct {
+		name        string
+		createStore func(t *testing.T) Store
+	}{
+		{
+			name: "file store",
+			createStore: func(t *testing.T) Store {
+				store, err := NewFileStore(t.TempDir())
+				assert.NoError(t, err)
+				return store
+			},
+		},
+		{
+			name: "ConfigMap store",
+			createStore: func(t *testing.T) Store {
+				return NewConfigMapStore(fake.NewSimpleClientset(), "keptn")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := tt.createStore(t)
+			ctx := context.Background()
+
+			entry := Entry{
+				ID:                    "abc",
+				EventType:             "CUSTOM_INFO",
+				Payload:               json.RawMessage(`{"title":"my-title"}`),
+				CredentialsSecretName: "dynatrace",
+				CreatedAt:             time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC),
+				Attempts:              1,
+			}
+			assert.NoError(t, store.Add(ctx, entry))
+
+			entries, err := store.List(ctx)
+			if !assert.NoError(t, err) || !assert.Len(t, entries, 1) {
+				return
+			}
+			assert.Equal(t, entry.ID, entries[0].ID)
+			assert.JSONEq(t, string(entry.Payload), string(entries[0].Payload))
+
+			entries[0].Attempts = 2
+			_, err = store.Update(ctx, entries[0])
+			assert.NoError(t, err)
+			entries, err = store.List(ctx)
+			if !assert.NoError(t, err) || !assert.Len(t, entries, 1) {
+				return
+			}
+			assert.Equal(t, 2, entries[0].Attempts)
+
+			assert.NoError(t, store.Remove(ctx, entry.ID))
+			entries, err = store.List(ctx)
+			assert.NoError(t, err)
+			assert.Empty(t, entries)
+
+			_, err = store.Update(ctx, entry)
+			assert.ErrorIs(t, err, ErrConflict, "updating a removed entry should fail")
+		})
+	}
+}
+
+func TestAdminHandler(t *testing.T) {
+	store, err := NewFileStore(t.TempDir())
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	for _, id := range []string{"newer", "older"} {
+		createdAt := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+		if id == "older" {
+			createdAt = createdAt.Add(-time.Hour)
+		}
+		assert.NoError(t, store.Add(context.Background(), Entry{ID: id, EventType: "CUSTOM_INFO", Payload: json.RawMessage(`{}`), CreatedAt: createdAt}))
+	}
+
+	recorder := httptest.NewRecorder()
+	NewAdminHandler(store).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, AdminPath, nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	var response adminResponse
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	if assert.Len(t, response.Entries, 2) {
+		assert.Equal(t, "older", response.Entries[0].ID)
+		assert.Equal(t, "newer", response.Entries[1].ID)
+	}
+
+	recorder = httptest.NewRecorder()
+	NewAdminHandler(nil).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, AdminPath, nil))
+	assert.JSONEq(t, `{"entries":[]}`, recorder.Body.String())
+}
@@ -0,0 +1,56 @@
+package outbox
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/credentials"
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+)
+
+const testDynatraceAPIToken = "dt0c01.ST2EY72KQINMH574WMNVI7YN.G3DFPBEJYMODIDAEX454M7YWBUVEFOWKPRVMWFASS64NFH52PX6BNDVFFM572RZM"
+
+// eventsAPI is a fake Dynatrace events API responding with the configured status code and counting the received events.
+type eventsAPI struct {
+	status   int32
+	received int32
+}
+
+func (a *eventsAPI) setStatus(status int) {
+	atomic.StoreInt32(&a.status, int32(status))
+}
+
+func (a *eventsAPI) getReceived() int {
+	return int(atomic.LoadInt32(&a.received))
+}
+
+func (a *eventsAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	status := int(atomic.LoadInt32(&a.status))
+	w.WriteHeader(status)
+	if status != http.StatusOK {
+		_, _ = fmt.Fprintf(w, `{"error":{"code":%d,"message":"%s"}}`, status, http.StatusText(status))
+		return
+	}
+
+	atomic.AddInt32(&a.received, 1)
+	_, _ = w.Write([]byte(`{}`))
+}
+
+// createEventsClient creates a dynatrace.EventsClient sending events to a fake events API, without any retries.
+func createEventsClient(t *testing.T, status int) (dynatrace.EventsClientInterface, *eventsAPI) {
+	t.Setenv("DYNATRACE_API_MAX_RETRIES", "0")
+
+	api := &eventsAPI{status: int32(status)}
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+
+	dynatraceCredentials, err := credentials.NewDynatraceCredentials(server.URL, testDynatraceAPIToken)
+	assert.NoError(t, err)
+
+	return dynatrace.NewEventsClient(dynatrace.NewClientWithHTTP(dynatraceCredentials, server.Client())), api
+}
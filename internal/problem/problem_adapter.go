@@ -1,14 +1,18 @@
 package problem
 
 import (
-	"strings"
-
 	cloudevents "github.com/cloudevents/sdk-go/v2"
 	"github.com/keptn-contrib/dynatrace-service/internal/adapter"
 )
 
 const remediationTaskName = "remediation"
 
+const (
+	keptnProjectTagKey = "keptn_project"
+	keptnStageTagKey   = "keptn_stage"
+	keptnServiceTagKey = "keptn_service"
+)
+
 // RawProblem is the raw problem datastructure
 type RawProblem map[string]interface{}
 
@@ -22,16 +26,19 @@ type ProblemAdapterInterface interface {
 	IsResolved() bool
 	GetProblemURL() string
 	GetRawProblem() RawProblem
+	GetProblemDetails() *ProblemDetails
 }
 
 // ProblemAdapter is a content adaptor for events of type sh.keptn.event.action.finished
 type ProblemAdapter struct {
 	event      DTProblemEvent
+	details    *ProblemDetails
 	rawProblem RawProblem
 	cloudEvent adapter.CloudEventAdapter
 }
 
-// NewProblemAdapterFromEvent creates a new ProblemAdapter from a cloudevents Event
+// NewProblemAdapterFromEvent creates a new ProblemAdapter from a cloudevents Event.
+// The problem may be sent either in the legacy format or in the Problems API v2 format.
 func NewProblemAdapterFromEvent(e cloudevents.Event) (*ProblemAdapter, error) {
 	ceAdapter := adapter.NewCloudEventAdapter(e)
 
@@ -47,16 +54,46 @@ func NewProblemAdapterFromEvent(e cloudevents.Event) (*ProblemAdapter, error) {
 		return nil, err
 	}
 
+	details, err := getProblemDetails(ceAdapter, problem, pData)
+	if err != nil {
+		return nil, err
+	}
+
 	// we need to set the project, stage and service names also from tags, if available
-	setProjectStageAndServiceFromTags(pData)
+	setProjectStageAndServiceFromTags(pData, details.EntityTags)
 
 	return &ProblemAdapter{
 		event:      *pData,
+		details:    details,
 		rawProblem: problem,
 		cloudEvent: ceAdapter,
 	}, nil
 }
 
+// getProblemDetails returns the typed details of the problem. For problems sent in the Problems API v2 format, the legacy fields of the event are set from the details.
+func getProblemDetails(ceAdapter adapter.CloudEventAdapter, rawProblem RawProblem, event *DTProblemEvent) (*ProblemDetails, error) {
+	if !isProblemV2(rawProblem) {
+		return getProblemDetailsFromLegacyEvent(event, rawProblem), nil
+	}
+
+	details := &ProblemDetails{}
+	err := ceAdapter.PayloadAs(details)
+	if err != nil {
+		return nil, err
+	}
+
+	event.PID = details.ProblemID
+	event.ProblemID = details.DisplayID
+	if event.ProblemID == "" {
+		event.ProblemID = details.ProblemID
+	}
+	event.ProblemTitle = details.Title
+	event.State = getProblemStateFromStatus(details.Status)
+	event.Tags = ""
+	event.ImpactedEntities = nil
+	return details, nil
+}
+
 // GetShKeptnContext returns the shkeptncontext
 func (a ProblemAdapter) GetShKeptnContext() string {
 	return a.cloudEvent.GetShKeptnContext()
@@ -137,6 +174,11 @@ func (a ProblemAdapter) GetRawProblem() RawProblem {
 	return a.rawProblem
 }
 
+// GetProblemDetails returns the typed details of the problem
+func (a ProblemAdapter) GetProblemDetails() *ProblemDetails {
+	return a.details
+}
+
 // IsOpen returns true if the problem is open
 func (a ProblemAdapter) IsOpen() bool {
 	return a.GetState() == "OPEN"
@@ -147,23 +189,20 @@ func (a ProblemAdapter) IsResolved() bool {
 	return a.GetState() == "RESOLVED"
 }
 
-func setProjectStageAndServiceFromTags(dtProblemEvent *DTProblemEvent) {
+func setProjectStageAndServiceFromTags(dtProblemEvent *DTProblemEvent, tags []EntityTag) {
 	// we analyze the tag list as its possible that the problem was raised for a specific monitored service that has keptn tags
-	splittedTags := strings.Split(dtProblemEvent.Tags, ",")
-
-	for _, tag := range splittedTags {
-		tag = strings.TrimSpace(tag)
-		split := strings.Split(tag, ":")
-		if len(split) > 1 {
-			if split[0] == "keptn_project" {
-				dtProblemEvent.KeptnProject = split[1]
-			}
-			if split[0] == "keptn_stage" {
-				dtProblemEvent.KeptnStage = split[1]
-			}
-			if split[0] == "keptn_service" {
-				dtProblemEvent.KeptnService = split[1]
-			}
+	for _, tag := range tags {
+		if tag.Value == "" {
+			continue
+		}
+
+		switch tag.Key {
+		case keptnProjectTagKey:
+			dtProblemEvent.KeptnProject = tag.Value
+		case keptnStageTagKey:
+			dtProblemEvent.KeptnStage = tag.Value
+		case keptnServiceTagKey:
+			dtProblemEvent.KeptnService = tag.Value
 		}
 	}
 }
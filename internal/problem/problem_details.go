@@ -0,0 +1,175 @@
+package problem
+
+import (
+	"strings"
+)
+
+const (
+	problemStatusOpen   = "OPEN"
+	problemStatusClosed = "CLOSED"
+
+	problemStateOpen     = "OPEN"
+	problemStateResolved = "RESOLVED"
+)
+
+// problemV2IDKey is the key identifying a problem sent in the Problems API v2 format, i.e. using the {ProblemDetailsJSONv2} placeholder
+const problemV2IDKey = "problemId"
+
+// ProblemDetails contains the typed details of a Dynatrace problem. Its structure follows the problem object of the Problems API v2.
+// For problems sent in the legacy format, the details are derived from the legacy fields.
+type ProblemDetails struct {
+	ProblemID        string           `json:"problemId"`
+	DisplayID        string           `json:"displayId,omitempty"`
+	Title            string           `json:"title,omitempty"`
+	Status           string           `json:"status,omitempty"`
+	SeverityLevel    string           `json:"severityLevel,omitempty"`
+	ImpactLevel      string           `json:"impactLevel,omitempty"`
+	ImpactedEntities []EntityStub     `json:"impactedEntities,omitempty"`
+	RootCauseEntity  *EntityStub      `json:"rootCauseEntity,omitempty"`
+	ManagementZones  []ManagementZone `json:"managementZones,omitempty"`
+	EntityTags       []EntityTag      `json:"entityTags,omitempty"`
+	EvidenceDetails  *EvidenceDetails `json:"evidenceDetails,omitempty"`
+}
+
+// EntityStub identifies a Dynatrace entity.
+type EntityStub struct {
+	EntityID EntityID `json:"entityId"`
+	Name     string   `json:"name,omitempty"`
+}
+
+// EntityID is the ID and type of a Dynatrace entity.
+type EntityID struct {
+	ID   string `json:"id"`
+	Type string `json:"type,omitempty"`
+}
+
+// ManagementZone is a management zone a problem is part of.
+type ManagementZone struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// EntityTag is a tag of an entity affected by a problem.
+type EntityTag struct {
+	Context              string `json:"context,omitempty"`
+	Key                  string `json:"key"`
+	Value                string `json:"value,omitempty"`
+	StringRepresentation string `json:"stringRepresentation,omitempty"`
+}
+
+// EvidenceDetails contains the evidence of a problem.
+type EvidenceDetails struct {
+	TotalCount int        `json:"totalCount"`
+	Details    []Evidence `json:"details"`
+}
+
+// Evidence is a single piece of evidence of a problem, e.g. an event or a metric.
+type Evidence struct {
+	EvidenceType      string     `json:"evidenceType"`
+	DisplayName       string     `json:"displayName"`
+	Entity            EntityStub `json:"entity"`
+	RootCauseRelevant bool       `json:"rootCauseRelevant"`
+	StartTime         int64      `json:"startTime"`
+}
+
+// legacyImpactedEntity is an impacted entity of a problem sent in the legacy format, i.e. using the {ImpactedEntities} placeholder
+type legacyImpactedEntity struct {
+	Entity string `json:"entity"`
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+}
+
+// isProblemV2 returns whether the raw problem was sent in the Problems API v2 format.
+func isProblemV2(rawProblem RawProblem) bool {
+	_, ok := rawProblem[problemV2IDKey]
+	return ok
+}
+
+// legacyProblemDetailsKey is the key of the problem details sent in the legacy format, i.e. using the {ProblemDetailsJSON} placeholder
+const legacyProblemDetailsKey = "ProblemDetails"
+
+// getProblemDetailsFromLegacyEvent derives the typed problem details from a problem sent in the legacy format.
+func getProblemDetailsFromLegacyEvent(event *DTProblemEvent, rawProblem RawProblem) *ProblemDetails {
+	details := &ProblemDetails{
+		ProblemID:     event.PID,
+		DisplayID:     event.ProblemID,
+		Title:         event.ProblemTitle,
+		Status:        getProblemStatusFromState(event.State),
+		SeverityLevel: getLegacyProblemDetailsField(rawProblem, "severityLevel"),
+		ImpactLevel:   getLegacyProblemDetailsField(rawProblem, "impactLevel"),
+		EntityTags:    parseTags(event.Tags),
+	}
+
+	for _, entity := range event.ImpactedEntities {
+		details.ImpactedEntities = append(details.ImpactedEntities, EntityStub{
+			EntityID: EntityID{ID: entity.Entity, Type: entity.Type},
+			Name:     entity.Name,
+		})
+	}
+	return details
+}
+
+// getLegacyProblemDetailsField returns the string field of the legacy problem details or an empty string if it is not available.
+// The problem details are not part of DTProblemEvent, as they may also be sent as text, i.e. using the {ProblemDetailsText} placeholder.
+func getLegacyProblemDetailsField(rawProblem RawProblem, key string) string {
+	legacyDetails, ok := rawProblem[legacyProblemDetailsKey].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+
+	value, _ := legacyDetails[key].(string)
+	return value
+}
+
+// getProblemStatusFromState maps the legacy state of a problem to its status. MERGED problems are considered closed.
+func getProblemStatusFromState(state string) string {
+	switch state {
+	case problemStateOpen:
+		return problemStatusOpen
+	case "":
+		return ""
+	default:
+		return problemStatusClosed
+	}
+}
+
+// getProblemStateFromStatus maps the status of a problem to its legacy state.
+func getProblemStateFromStatus(status string) string {
+	switch status {
+	case problemStatusOpen:
+		return problemStateOpen
+	case problemStatusClosed:
+		return problemStateResolved
+	default:
+		return status
+	}
+}
+
+// parseTags parses the comma-separated tags of a problem sent in the legacy format, e.g. "[Kubernetes]app:carts, keptn_stage:production, important".
+func parseTags(tags string) []EntityTag {
+	var entityTags []EntityTag
+	for _, tag := range strings.Split(tags, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			continue
+		}
+
+		entityTag := EntityTag{StringRepresentation: tag}
+		if strings.HasPrefix(tag, "[") {
+			if end := strings.Index(tag, "]"); end > 0 {
+				entityTag.Context = tag[1:end]
+				tag = tag[end+1:]
+			}
+		}
+
+		// only the first colon separates key and value, the value may contain further colons
+		keyAndValue := strings.SplitN(tag, ":", 2)
+		entityTag.Key = keyAndValue[0]
+		if len(keyAndValue) > 1 {
+			entityTag.Value = keyAndValue[1]
+		}
+
+		entityTags = append(entityTags, entityTag)
+	}
+	return entityTags
+}
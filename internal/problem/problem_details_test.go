@@ -0,0 +1,48 @@
+package problem
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTags(t *testing.T) {
+	tests := []struct {
+		name string
+		tags string
+		want []EntityTag
+	}{
+		{
+			name: "no tags",
+			tags: "",
+			want: nil,
+		},
+		{
+			name: "tags with and without values",
+			tags: "keptn_stage:production, important",
+			want: []EntityTag{
+				{Key: "keptn_stage", Value: "production", StringRepresentation: "keptn_stage:production"},
+				{Key: "important", StringRepresentation: "important"},
+			},
+		},
+		{
+			name: "tag with context",
+			tags: "[Kubernetes]app:carts",
+			want: []EntityTag{
+				{Context: "Kubernetes", Key: "app", Value: "carts", StringRepresentation: "[Kubernetes]app:carts"},
+			},
+		},
+		{
+			name: "value containing colons",
+			tags: "url:http://carts:8080",
+			want: []EntityTag{
+				{Key: "url", Value: "http://carts:8080", StringRepresentation: "url:http://carts:8080"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, parseTags(tt.tags))
+		})
+	}
+}
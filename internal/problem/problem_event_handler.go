@@ -2,41 +2,63 @@ package problem
 
 import (
 	"context"
+	"time"
 
 	"github.com/keptn-contrib/dynatrace-service/internal/adapter"
+	"github.com/keptn-contrib/dynatrace-service/internal/config"
+	"github.com/keptn-contrib/dynatrace-service/internal/deduplication"
 	"github.com/keptn-contrib/dynatrace-service/internal/keptn"
 	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
 	log "github.com/sirupsen/logrus"
 )
 
 type DTProblemEvent struct {
-	PID          string `json:"PID"`
-	ProblemID    string `json:"ProblemID"`
-	ProblemURL   string `json:"ProblemURL"`
-	State        string `json:"State"`
-	Tags         string `json:"Tags"`
-	KeptnProject string `json:"KeptnProject"`
-	KeptnService string `json:"KeptnService"`
-	KeptnStage   string `json:"KeptnStage"`
+	PID              string                 `json:"PID"`
+	ProblemID        string                 `json:"ProblemID"`
+	ProblemTitle     string                 `json:"ProblemTitle"`
+	ProblemURL       string                 `json:"ProblemURL"`
+	State            string                 `json:"State"`
+	Tags             string                 `json:"Tags"`
+	ImpactedEntities []legacyImpactedEntity `json:"ImpactedEntities"`
+	KeptnProject     string                 `json:"KeptnProject"`
+	KeptnService     string                 `json:"KeptnService"`
+	KeptnStage       string                 `json:"KeptnStage"`
 }
 
 type ProblemEventHandler struct {
 	event             ProblemAdapterInterface
 	eventSenderClient keptn.EventSenderClientInterface
+	commentClient     problemCommentClientInterface
+	router            problemRouter
+	filter            problemFilter
 }
 
-func NewProblemEventHandler(event ProblemAdapterInterface, client keptn.EventSenderClientInterface) ProblemEventHandler {
+// NewProblemEventHandler creates a new ProblemEventHandler. The routing and filter configurations may be nil, the entities client is only used if entity tag lookup is enabled.
+// Deduplication windows and cooldowns are tracked in the window store, which may be nil to disable them.
+func NewProblemEventHandler(event ProblemAdapterInterface, client keptn.EventSenderClientInterface, entitiesClient entitiesClientInterface, commentClient problemCommentClientInterface, windowStore deduplication.WindowStore, routingConfig *config.ProblemRoutingConfig, filterConfig *config.ProblemFilterConfig) ProblemEventHandler {
 	return ProblemEventHandler{
 		event:             event,
 		eventSenderClient: client,
+		commentClient:     commentClient,
+		router: problemRouter{
+			entitiesClient: entitiesClient,
+			routingConfig:  routingConfig,
+		},
+		filter: problemFilter{
+			filterConfig: filterConfig,
+			windowStore:  windowStore,
+		},
 	}
 }
 
 type RemediationTriggeredEventData struct {
 	keptnv2.EventData
 
-	// Problem contains details about the problem
+	// Problem contains details about the problem as sent by Dynatrace
 	Problem RawProblem `json:"problem"`
+
+	// ProblemDetails contains the typed details of the problem, regardless of the format it was sent in
+	ProblemDetails *ProblemDetails `json:"problemDetails,omitempty"`
 }
 
 // HandleEvent handles a problem event.
@@ -46,41 +68,58 @@ func (eh ProblemEventHandler) HandleEvent(workCtx context.Context, replyCtx cont
 		return nil
 	}
 
-	if eh.event.IsOpen() {
-		return eh.handleOpenedProblemFromDT()
-	}
-	if eh.event.IsResolved() {
-		return eh.handleClosedProblemFromDT()
+	if !eh.event.IsOpen() && !eh.event.IsResolved() {
+		return nil
 	}
 
-	return nil
+	event := eh.router.route(workCtx, eh.event)
+	if event.IsOpen() {
+		return eh.handleOpenedProblemFromDT(workCtx, event)
+	}
+	return eh.handleClosedProblemFromDT(event)
 }
 
-func (eh ProblemEventHandler) handleClosedProblemFromDT() error {
-	err := eh.sendEvent(NewProblemClosedEventFactory(eh.event))
+func (eh ProblemEventHandler) handleClosedProblemFromDT(event ProblemAdapterInterface) error {
+	err := eh.sendEvent(NewProblemClosedEventFactory(event))
 	if err != nil {
 		return err
 	}
 
-	log.WithField("PID", eh.event.GetPID()).Debug("Successfully sent Keptn PROBLEM CLOSED event")
+	log.WithField("PID", event.GetPID()).Debug("Successfully sent Keptn PROBLEM CLOSED event")
 	return nil
 }
 
-func (eh ProblemEventHandler) handleOpenedProblemFromDT() error {
-	if eh.event.GetStage() == "" {
+func (eh ProblemEventHandler) handleOpenedProblemFromDT(ctx context.Context, event ProblemAdapterInterface) error {
+	if event.GetStage() == "" {
 		log.Debug("Dropping open problem event as it has no stage")
 		return nil
 	}
 
-	err := eh.sendEvent(NewRemediationTriggeredEventFactory(eh.event))
+	now := time.Now()
+	if reason := eh.filter.getDropReason(ctx, event, now); reason != "" {
+		eh.dropOpenedProblem(ctx, event, reason)
+		return nil
+	}
+
+	err := eh.sendEvent(NewRemediationTriggeredEventFactory(event))
 	if err != nil {
+		eh.filter.releaseTriggeredRemediation(ctx, event, now)
 		return err
 	}
 
-	log.WithField("PID", eh.event.GetPID()).Debug("Successfully sent Keptn PROBLEM OPEN event")
+	log.WithField("PID", event.GetPID()).Debug("Successfully sent Keptn PROBLEM OPEN event")
 	return nil
 }
 
+// dropOpenedProblem logs why the open problem does not trigger a remediation and, if configured, adds this as a comment to the problem.
+func (eh ProblemEventHandler) dropOpenedProblem(ctx context.Context, event ProblemAdapterInterface, reason string) {
+	log.WithField("PID", event.GetPID()).WithField("reason", reason).Info("Dropping open problem event")
+
+	if eh.filter.filterConfig.AddProblemComment && eh.commentClient != nil {
+		eh.commentClient.AddProblemComment(ctx, event.GetPID(), "Keptn remediation not triggered: "+reason)
+	}
+}
+
 func (eh ProblemEventHandler) sendEvent(factory adapter.CloudEventFactoryInterface) error {
 	err := eh.eventSenderClient.SendCloudEvent(factory)
 	if err != nil {
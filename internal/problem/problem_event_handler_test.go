@@ -49,6 +49,23 @@ func TestProblemEventHandler_HandleEvent(t *testing.T) {
 			wantEmittedEvent:     true,
 			expectedEmittedEvent: readCloudEventFromFile("./testdata/closed_problem_existing_labels/expected_emitted_ce.json"),
 		},
+		{
+			name:                 "open problem event in problems API v2 format",
+			receivedEvent:        readCloudEventFromFile("./testdata/open_problem_v2/received_ce.json"),
+			wantEmittedEvent:     true,
+			expectedEmittedEvent: readCloudEventFromFile("./testdata/open_problem_v2/expected_emitted_ce.json"),
+		},
+		{
+			name:             "open problem event in problems API v2 format with management zones but no tags",
+			receivedEvent:    readCloudEventFromFile("./testdata/open_problem_v2_management_zone/received_ce.json"),
+			wantEmittedEvent: false,
+		},
+		{
+			name:                 "closed problem event in problems API v2 format",
+			receivedEvent:        readCloudEventFromFile("./testdata/closed_problem_v2/received_ce.json"),
+			wantEmittedEvent:     true,
+			expectedEmittedEvent: readCloudEventFromFile("./testdata/closed_problem_v2/expected_emitted_ce.json"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -58,7 +75,7 @@ func TestProblemEventHandler_HandleEvent(t *testing.T) {
 			}
 
 			eventSenderClient := &eventSenderClientMock{}
-			ph := NewProblemEventHandler(adapter, eventSenderClient)
+			ph := NewProblemEventHandler(adapter, eventSenderClient, nil, nil, nil, nil, nil)
 
 			err = ph.HandleEvent(context.Background(), context.Background())
 
@@ -76,6 +93,9 @@ func TestProblemEventHandler_HandleEvent(t *testing.T) {
 
 type eventSenderClientMock struct {
 	eventSink []*cloudevents.Event
+
+	// failures is the number of sends that fail before events are accepted
+	failures int
 }
 
 func (m *eventSenderClientMock) SendCloudEvent(factory adapter.CloudEventFactoryInterface) error {
@@ -84,6 +104,11 @@ func (m *eventSenderClientMock) SendCloudEvent(factory adapter.CloudEventFactory
 		return fmt.Errorf("missing factory")
 	}
 
+	if m.failures > 0 {
+		m.failures--
+		return fmt.Errorf("could not send event")
+	}
+
 	ce, err := factory.CreateCloudEvent()
 	if err != nil {
 		return err
@@ -66,7 +66,8 @@ func (f *RemediationTriggeredEventFactory) CreateCloudEvent() (*cloudevents.Even
 			Stage:   f.event.GetStage(),
 			Service: f.event.GetService(),
 		},
-		Problem: f.event.GetRawProblem(),
+		Problem:        f.event.GetRawProblem(),
+		ProblemDetails: f.event.GetProblemDetails(),
 	}
 
 	// https://github.com/keptn-contrib/dynatrace-service/issues/176
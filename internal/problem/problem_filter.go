@@ -0,0 +1,175 @@
+package problem
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/config"
+	"github.com/keptn-contrib/dynatrace-service/internal/deduplication"
+)
+
+// problemSeverityLevels are the severity levels of problems, most severe first
+var problemSeverityLevels = []string{"AVAILABILITY", "ERROR", "PERFORMANCE", "RESOURCE_CONTENTION", "CUSTOM_ALERT", "MONITORING_UNAVAILABLE"}
+
+type problemCommentClientInterface interface {
+	AddProblemComment(ctx context.Context, pid string, comment string)
+}
+
+// problemFilter determines whether an open problem triggers a remediation.
+type problemFilter struct {
+	filterConfig *config.ProblemFilterConfig
+	windowStore  deduplication.WindowStore
+}
+
+// getDropReason returns why the open problem should not trigger a remediation or an empty string if it should.
+// The deduplication window and the cooldown are only checked if the problem passes all other filters. If it passes them as well, they are reserved atomically starting at the specified time,
+// so that concurrent notifications for the same problem or service are dropped. If triggering the remediation fails, the reservations must be released using releaseTriggeredRemediation.
+func (f problemFilter) getDropReason(ctx context.Context, event ProblemAdapterInterface, now time.Time) string {
+	filterConfig := f.filterConfig
+	if filterConfig == nil {
+		return ""
+	}
+
+	details := event.GetProblemDetails()
+	if details == nil {
+		details = &ProblemDetails{}
+	}
+
+	if reason := getSeverityLevelDropReason(filterConfig.MinSeverityLevel, details.SeverityLevel); reason != "" {
+		return reason
+	}
+
+	if details.ImpactLevel != "" && len(filterConfig.ImpactLevels) > 0 && !containsIgnoringCase(filterConfig.ImpactLevels, details.ImpactLevel) {
+		return fmt.Sprintf("impact level %s is not one of %s", details.ImpactLevel, strings.Join(filterConfig.ImpactLevels, ", "))
+	}
+
+	if reason := getTitleDropReason(filterConfig.AllowedTitles, filterConfig.DeniedTitles, details.Title); reason != "" {
+		return reason
+	}
+
+	if filterConfig.DeduplicationWindowMinutes > 0 && !f.reserve(ctx, getProblemWindowKey(event), now, filterConfig.DeduplicationWindowMinutes) {
+		return fmt.Sprintf("a notification for the problem was already received within the last %d minutes", filterConfig.DeduplicationWindowMinutes)
+	}
+
+	if filterConfig.CooldownMinutes > 0 && !f.reserve(ctx, getServiceWindowKey(event), now, filterConfig.CooldownMinutes) {
+		// the problem is dropped, so a later notification for it should not be considered a duplicate
+		if filterConfig.DeduplicationWindowMinutes > 0 {
+			f.release(ctx, getProblemWindowKey(event), now)
+		}
+		return fmt.Sprintf("a remediation for service %s in stage %s was already triggered within the last %d minutes", event.GetService(), event.GetStage(), filterConfig.CooldownMinutes)
+	}
+
+	return ""
+}
+
+// releaseTriggeredRemediation releases the deduplication window of the problem and the cooldown of its service reserved by getDropReason at the specified time, so that a redelivered notification is not dropped.
+func (f problemFilter) releaseTriggeredRemediation(ctx context.Context, event ProblemAdapterInterface, now time.Time) {
+	if f.filterConfig == nil {
+		return
+	}
+
+	if f.filterConfig.DeduplicationWindowMinutes > 0 {
+		f.release(ctx, getProblemWindowKey(event), now)
+	}
+
+	if f.filterConfig.CooldownMinutes > 0 {
+		f.release(ctx, getServiceWindowKey(event), now)
+	}
+}
+
+// reserve atomically checks that the key was not recorded within the window and records it.
+// If the window store is not available, the key is considered outside the window.
+func (f problemFilter) reserve(ctx context.Context, key string, now time.Time, windowMinutes int) bool {
+	if f.windowStore == nil {
+		return true
+	}
+
+	reserved, err := f.windowStore.Reserve(ctx, key, now, time.Duration(windowMinutes)*time.Minute)
+	if err != nil {
+		log.WithError(err).WithField("key", key).Warn("Could not check problem deduplication window, processing problem anyway")
+		return true
+	}
+	return reserved
+}
+
+func (f problemFilter) release(ctx context.Context, key string, now time.Time) {
+	if f.windowStore == nil {
+		return
+	}
+
+	if err := f.windowStore.Release(ctx, key, now); err != nil {
+		log.WithError(err).WithField("key", key).Warn("Could not release problem deduplication window")
+	}
+}
+
+func getProblemWindowKey(event ProblemAdapterInterface) string {
+	return "problem/" + event.GetPID()
+}
+
+func getServiceWindowKey(event ProblemAdapterInterface) string {
+	return "service/" + event.GetProject() + "/" + event.GetStage() + "/" + event.GetService()
+}
+
+// getSeverityLevelDropReason returns why a problem of the severity level is dropped or an empty string if it is not.
+// Problems with an unknown severity level are not dropped.
+func getSeverityLevelDropReason(minSeverityLevel string, severityLevel string) string {
+	if minSeverityLevel == "" || severityLevel == "" {
+		return ""
+	}
+
+	minRank := getSeverityLevelRank(minSeverityLevel)
+	if minRank < 0 {
+		log.WithField("minSeverityLevel", minSeverityLevel).Warn("Ignoring unknown minimum severity level")
+		return ""
+	}
+
+	rank := getSeverityLevelRank(severityLevel)
+	if rank < 0 || rank <= minRank {
+		return ""
+	}
+	return fmt.Sprintf("severity level %s is below %s", severityLevel, minSeverityLevel)
+}
+
+// getSeverityLevelRank returns the rank of the severity level, 0 being the most severe, or -1 if it is unknown.
+func getSeverityLevelRank(severityLevel string) int {
+	for i, s := range problemSeverityLevels {
+		if strings.EqualFold(s, severityLevel) {
+			return i
+		}
+	}
+	return -1
+}
+
+// getTitleDropReason returns why a problem with the title is dropped or an empty string if it is not.
+func getTitleDropReason(allowedTitles []string, deniedTitles []string, title string) string {
+	if len(allowedTitles) > 0 && !matchesAnyPattern(allowedTitles, title) {
+		return fmt.Sprintf("title '%s' is not allowed", title)
+	}
+
+	if matchesAnyPattern(deniedTitles, title) {
+		return fmt.Sprintf("title '%s' is denied", title)
+	}
+	return ""
+}
+
+func matchesAnyPattern(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		if matchesPattern(pattern, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsIgnoringCase(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
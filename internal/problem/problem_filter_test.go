@@ -0,0 +1,216 @@
+package problem
+
+import (
+	"context"
+	"testing"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/config"
+	"github.com/keptn-contrib/dynatrace-service/internal/deduplication"
+)
+
+const testOpenProblemV2 = "./testdata/open_problem_v2/received_ce.json"
+
+// TestProblemEventHandler_HandleEvent_Filter tests that open problems are dropped by the configured filters and that a comment is added to them if configured.
+func TestProblemEventHandler_HandleEvent_Filter(t *testing.T) {
+	tests := []struct {
+		name             string
+		filterConfig     *config.ProblemFilterConfig
+		wantEmittedEvent bool
+		expectedComment  string
+	}{
+		{
+			name:             "no filter",
+			wantEmittedEvent: true,
+		},
+		{
+			name: "severity level above minimum",
+			filterConfig: &config.ProblemFilterConfig{
+				MinSeverityLevel: "RESOURCE_CONTENTION",
+			},
+			wantEmittedEvent: true,
+		},
+		{
+			name: "severity level below minimum",
+			filterConfig: &config.ProblemFilterConfig{
+				MinSeverityLevel:  "ERROR",
+				AddProblemComment: true,
+			},
+			wantEmittedEvent: false,
+			expectedComment:  "Keptn remediation not triggered: severity level PERFORMANCE is below ERROR",
+		},
+		{
+			name: "impact level not included",
+			filterConfig: &config.ProblemFilterConfig{
+				ImpactLevels: []string{"APPLICATION", "ENVIRONMENT"},
+			},
+			wantEmittedEvent: false,
+		},
+		{
+			name: "allowed and not denied title",
+			filterConfig: &config.ProblemFilterConfig{
+				ImpactLevels:  []string{"services"},
+				AllowedTitles: []string{"Response time*", "Failure rate*"},
+				DeniedTitles:  []string{"*CPU*"},
+			},
+			wantEmittedEvent: true,
+		},
+		{
+			name: "title not allowed",
+			filterConfig: &config.ProblemFilterConfig{
+				AllowedTitles:     []string{"Failure rate*"},
+				AddProblemComment: true,
+			},
+			wantEmittedEvent: false,
+			expectedComment:  "Keptn remediation not triggered: title 'Response time degradation' is not allowed",
+		},
+		{
+			name: "title denied",
+			filterConfig: &config.ProblemFilterConfig{
+				DeniedTitles: []string{"*degradation"},
+			},
+			wantEmittedEvent: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adapter, err := NewProblemAdapterFromEvent(*readCloudEventFromFile(testOpenProblemV2))
+			require.NoError(t, err)
+
+			eventSenderClient := &eventSenderClientMock{}
+			commentClient := &problemCommentClientMock{}
+			err = NewProblemEventHandler(adapter, eventSenderClient, nil, commentClient, deduplication.NewInMemoryWindowStore(10), nil, tt.filterConfig).HandleEvent(context.Background(), context.Background())
+			require.NoError(t, err)
+
+			if tt.wantEmittedEvent {
+				assert.Len(t, eventSenderClient.eventSink, 1)
+			} else {
+				assert.Empty(t, eventSenderClient.eventSink)
+			}
+
+			if tt.expectedComment != "" {
+				assert.Equal(t, []string{tt.expectedComment}, commentClient.comments["-1234567890123456789_1641333360000V2"])
+			} else {
+				assert.Empty(t, commentClient.comments)
+			}
+		})
+	}
+}
+
+// TestProblemEventHandler_HandleEvent_DeduplicationWindowAndCooldown tests that repeated notifications for a problem and further problems of the same service are dropped within the configured windows.
+func TestProblemEventHandler_HandleEvent_DeduplicationWindowAndCooldown(t *testing.T) {
+	tests := []struct {
+		name              string
+		filterConfig      *config.ProblemFilterConfig
+		secondProblemID   string
+		wantEmittedEvents int
+	}{
+		{
+			name:              "repeated notification without deduplication window",
+			filterConfig:      &config.ProblemFilterConfig{},
+			wantEmittedEvents: 2,
+		},
+		{
+			name: "repeated notification within deduplication window",
+			filterConfig: &config.ProblemFilterConfig{
+				DeduplicationWindowMinutes: 30,
+			},
+			wantEmittedEvents: 1,
+		},
+		{
+			name: "other problem of the same service without cooldown",
+			filterConfig: &config.ProblemFilterConfig{
+				DeduplicationWindowMinutes: 30,
+			},
+			secondProblemID:   "-9876543210987654321_1641333960000V2",
+			wantEmittedEvents: 2,
+		},
+		{
+			name: "other problem of the same service within cooldown",
+			filterConfig: &config.ProblemFilterConfig{
+				DeduplicationWindowMinutes: 30,
+				CooldownMinutes:            15,
+			},
+			secondProblemID:   "-9876543210987654321_1641333960000V2",
+			wantEmittedEvents: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			windowStore := deduplication.NewInMemoryWindowStore(10)
+			eventSenderClient := &eventSenderClientMock{}
+
+			firstEvent := readCloudEventFromFile(testOpenProblemV2)
+			secondEvent := readCloudEventFromFile(testOpenProblemV2)
+			if tt.secondProblemID != "" {
+				setProblemID(t, secondEvent, tt.secondProblemID)
+			}
+
+			for _, event := range []*cloudevents.Event{firstEvent, secondEvent} {
+				adapter, err := NewProblemAdapterFromEvent(*event)
+				require.NoError(t, err)
+
+				err = NewProblemEventHandler(adapter, eventSenderClient, nil, nil, windowStore, nil, tt.filterConfig).HandleEvent(context.Background(), context.Background())
+				require.NoError(t, err)
+			}
+
+			assert.Len(t, eventSenderClient.eventSink, tt.wantEmittedEvents)
+		})
+	}
+}
+
+// TestProblemEventHandler_HandleEvent_RedeliveryAfterFailedSend tests that the deduplication window and the cooldown are released if triggering the remediation fails, so that a redelivered notification is not dropped.
+func TestProblemEventHandler_HandleEvent_RedeliveryAfterFailedSend(t *testing.T) {
+	windowStore := deduplication.NewInMemoryWindowStore(10)
+	eventSenderClient := &eventSenderClientMock{failures: 1}
+	filterConfig := &config.ProblemFilterConfig{
+		DeduplicationWindowMinutes: 30,
+		CooldownMinutes:            15,
+	}
+
+	adapter, err := NewProblemAdapterFromEvent(*readCloudEventFromFile(testOpenProblemV2))
+	require.NoError(t, err)
+
+	err = NewProblemEventHandler(adapter, eventSenderClient, nil, nil, windowStore, nil, filterConfig).HandleEvent(context.Background(), context.Background())
+	require.Error(t, err)
+	assert.Empty(t, eventSenderClient.eventSink)
+
+	err = NewProblemEventHandler(adapter, eventSenderClient, nil, nil, windowStore, nil, filterConfig).HandleEvent(context.Background(), context.Background())
+	require.NoError(t, err)
+	assert.Len(t, eventSenderClient.eventSink, 1)
+
+	err = NewProblemEventHandler(adapter, eventSenderClient, nil, nil, windowStore, nil, filterConfig).HandleEvent(context.Background(), context.Background())
+	require.NoError(t, err)
+	assert.Len(t, eventSenderClient.eventSink, 1)
+}
+
+func TestGetSeverityLevelDropReason(t *testing.T) {
+	assert.Empty(t, getSeverityLevelDropReason("", "CUSTOM_ALERT"))
+	assert.Empty(t, getSeverityLevelDropReason("ERROR", ""))
+	assert.Empty(t, getSeverityLevelDropReason("ERROR", "AVAILABILITY"))
+	assert.Empty(t, getSeverityLevelDropReason("ERROR", "error"))
+	assert.Empty(t, getSeverityLevelDropReason("ERROR", "UNKNOWN"))
+	assert.Empty(t, getSeverityLevelDropReason("UNKNOWN", "CUSTOM_ALERT"))
+	assert.Equal(t, "severity level CUSTOM_ALERT is below ERROR", getSeverityLevelDropReason("ERROR", "CUSTOM_ALERT"))
+}
+
+func setProblemID(t *testing.T, event *cloudevents.Event, problemID string) {
+	data := map[string]interface{}{}
+	require.NoError(t, event.DataAs(&data))
+	data[problemV2IDKey] = problemID
+	require.NoError(t, event.SetData(cloudevents.ApplicationJSON, data))
+}
+
+type problemCommentClientMock struct {
+	comments map[string][]string
+}
+
+func (m *problemCommentClientMock) AddProblemComment(_ context.Context, pid string, comment string) {
+	if m.comments == nil {
+		m.comments = map[string][]string{}
+	}
+	m.comments[pid] = append(m.comments[pid], comment)
+}
@@ -0,0 +1,223 @@
+package problem
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/config"
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+)
+
+type entitiesClientInterface interface {
+	GetEntitiesWithTagsByIDs(ctx context.Context, entityIDs []string) ([]dynatrace.Entity, error)
+}
+
+// routedProblemAdapter is a ProblemAdapterInterface with the Keptn project, stage and service and the entity tags determined by routing.
+type routedProblemAdapter struct {
+	ProblemAdapterInterface
+	project string
+	stage   string
+	service string
+	details *ProblemDetails
+}
+
+// GetProject returns the project
+func (a routedProblemAdapter) GetProject() string {
+	return a.project
+}
+
+// GetStage returns the stage
+func (a routedProblemAdapter) GetStage() string {
+	return a.stage
+}
+
+// GetService returns the service
+func (a routedProblemAdapter) GetService() string {
+	return a.service
+}
+
+// GetProblemDetails returns the typed details of the problem including the tags retrieved for its entities
+func (a routedProblemAdapter) GetProblemDetails() *ProblemDetails {
+	return a.details
+}
+
+// problemRouter determines the Keptn project, stage and service a problem is routed to.
+type problemRouter struct {
+	entitiesClient entitiesClientInterface
+	routingConfig  *config.ProblemRoutingConfig
+}
+
+// route returns the problem with the project, stage and service set by the first matching routing rule.
+// If entity tag lookup is enabled, the tags of the impacted entities are retrieved for problems without tags and considered as if they had been sent with the problem.
+func (r problemRouter) route(ctx context.Context, event ProblemAdapterInterface) ProblemAdapterInterface {
+	rules := r.routingConfig.GetRules()
+	lookupEntityTags := r.routingConfig.IsEntityTagLookupEnabled() && r.entitiesClient != nil
+	if len(rules) == 0 && !lookupEntityTags {
+		return event
+	}
+
+	target := &DTProblemEvent{
+		KeptnProject: event.GetProject(),
+		KeptnStage:   event.GetStage(),
+		KeptnService: event.GetService(),
+	}
+
+	details := event.GetProblemDetails()
+	if details == nil {
+		details = &ProblemDetails{}
+	}
+
+	if lookupEntityTags && len(details.EntityTags) == 0 {
+		entityTags, err := r.getEntityTags(ctx, details)
+		if err != nil {
+			log.WithError(err).Warn("Could not retrieve tags of the entities impacted by the problem")
+		}
+
+		if len(entityTags) > 0 {
+			detailsWithTags := *details
+			detailsWithTags.EntityTags = entityTags
+			details = &detailsWithTags
+
+			setProjectStageAndServiceFromTags(target, entityTags)
+		}
+	}
+
+	if rule := findMatchingProblemRoutingRule(rules, details); rule != nil {
+		log.WithField("PID", event.GetPID()).WithField("rule", *rule).Debug("Routing problem using rule")
+		setProjectStageAndServiceFromRoutingRule(target, *rule)
+	}
+
+	return routedProblemAdapter{
+		ProblemAdapterInterface: event,
+		project:                 target.KeptnProject,
+		stage:                   target.KeptnStage,
+		service:                 target.KeptnService,
+		details:                 details,
+	}
+}
+
+// getEntityTags retrieves the tags of the impacted entities and the root cause entity of the problem.
+func (r problemRouter) getEntityTags(ctx context.Context, details *ProblemDetails) ([]EntityTag, error) {
+	entityIDs := getEntityIDs(details)
+	if len(entityIDs) == 0 {
+		return nil, nil
+	}
+
+	entities, err := r.entitiesClient.GetEntitiesWithTagsByIDs(ctx, entityIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var entityTags []EntityTag
+	for _, entity := range entities {
+		for _, tag := range entity.Tags {
+			entityTags = append(entityTags, EntityTag{
+				Context:              tag.Context,
+				Key:                  tag.Key,
+				Value:                tag.Value,
+				StringRepresentation: tag.StringRepresentation,
+			})
+		}
+	}
+	return entityTags, nil
+}
+
+// getEntityIDs returns the distinct IDs of the impacted entities and the root cause entity of the problem.
+func getEntityIDs(details *ProblemDetails) []string {
+	entities := make([]EntityStub, 0, len(details.ImpactedEntities)+1)
+	entities = append(entities, details.ImpactedEntities...)
+	if details.RootCauseEntity != nil {
+		entities = append(entities, *details.RootCauseEntity)
+	}
+
+	var entityIDs []string
+	seen := map[string]bool{}
+	for _, entity := range entities {
+		if entity.EntityID.ID == "" || seen[entity.EntityID.ID] {
+			continue
+		}
+		seen[entity.EntityID.ID] = true
+		entityIDs = append(entityIDs, entity.EntityID.ID)
+	}
+	return entityIDs
+}
+
+func setProjectStageAndServiceFromRoutingRule(dtProblemEvent *DTProblemEvent, rule config.ProblemRoutingRule) {
+	if rule.Project != "" {
+		dtProblemEvent.KeptnProject = rule.Project
+	}
+	if rule.Stage != "" {
+		dtProblemEvent.KeptnStage = rule.Stage
+	}
+	if rule.Service != "" {
+		dtProblemEvent.KeptnService = rule.Service
+	}
+}
+
+// findMatchingProblemRoutingRule returns the first rule matching the problem or nil if there is none.
+func findMatchingProblemRoutingRule(rules []config.ProblemRoutingRule, details *ProblemDetails) *config.ProblemRoutingRule {
+	for i := range rules {
+		if matchesProblemRoutingRule(rules[i], details) {
+			return &rules[i]
+		}
+	}
+	return nil
+}
+
+func matchesProblemRoutingRule(rule config.ProblemRoutingRule, details *ProblemDetails) bool {
+	if rule.ManagementZone != "" && !matchesAnyManagementZone(rule.ManagementZone, details.ManagementZones) {
+		return false
+	}
+
+	for _, tagPattern := range rule.Tags {
+		if !matchesAnyTag(tagPattern, details.EntityTags) {
+			return false
+		}
+	}
+
+	if rule.SeverityLevel != "" && !strings.EqualFold(rule.SeverityLevel, details.SeverityLevel) {
+		return false
+	}
+
+	if rule.RootCauseEntityType != "" && (details.RootCauseEntity == nil || !strings.EqualFold(rule.RootCauseEntityType, details.RootCauseEntity.EntityID.Type)) {
+		return false
+	}
+
+	return true
+}
+
+func matchesAnyManagementZone(pattern string, managementZones []ManagementZone) bool {
+	for _, managementZone := range managementZones {
+		if matchesPattern(pattern, managementZone.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesAnyTag returns whether the pattern matches a tag, either including its context, e.g. "[Kubernetes]app:carts", or not, e.g. "app:carts".
+func matchesAnyTag(pattern string, tags []EntityTag) bool {
+	for _, tag := range tags {
+		tagWithoutContext := tag.Key
+		if tag.Value != "" {
+			tagWithoutContext += ":" + tag.Value
+		}
+
+		if matchesPattern(pattern, tagWithoutContext) || (tag.StringRepresentation != "" && matchesPattern(pattern, tag.StringRepresentation)) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesPattern returns whether the value matches the pattern, in which * matches any sequence of characters.
+func matchesPattern(pattern string, value string) bool {
+	quotedParts := strings.Split(pattern, "*")
+	for i, part := range quotedParts {
+		quotedParts[i] = regexp.QuoteMeta(part)
+	}
+	return regexp.MustCompile("^" + strings.Join(quotedParts, ".*") + "$").MatchString(value)
+}
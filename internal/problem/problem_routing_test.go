@@ -0,0 +1,193 @@
+package problem
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/config"
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+)
+
+// TestProblemEventHandler_HandleEvent_Routing tests that problems are routed according to the first matching rule and the tags retrieved for their entities.
+func TestProblemEventHandler_HandleEvent_Routing(t *testing.T) {
+	tests := []struct {
+		name             string
+		receivedEvent    string
+		routingConfig    *config.ProblemRoutingConfig
+		entitiesClient   *entitiesClientMock
+		wantEmittedEvent bool
+		expectedProject  string
+		expectedStage    string
+		expectedService  string
+		expectedTags     []EntityTag
+	}{
+		{
+			name:          "problem without stage routed by tag",
+			receivedEvent: "./testdata/open_problem_no_stage/received_ce.json",
+			routingConfig: &config.ProblemRoutingConfig{
+				Rules: []config.ProblemRoutingRule{
+					{Tags: []string{"othertag"}, Stage: "staging"},
+					{Tags: []string{"testtag*"}, Stage: "production"},
+					{Stage: "dev"},
+				},
+			},
+			wantEmittedEvent: true,
+			expectedProject:  "shop",
+			expectedStage:    "production",
+			expectedService:  "carts",
+			expectedTags: []EntityTag{
+				{Key: "testtag1", StringRepresentation: "testtag1"},
+				{Key: "testtag2", StringRepresentation: "testtag2"},
+			},
+		},
+		{
+			name:          "problem without stage not matching any rule is dropped",
+			receivedEvent: "./testdata/open_problem_no_stage/received_ce.json",
+			routingConfig: &config.ProblemRoutingConfig{
+				Rules: []config.ProblemRoutingRule{
+					{ManagementZone: "Keptn: *", Stage: "production"},
+				},
+			},
+			wantEmittedEvent: false,
+		},
+		{
+			name:          "problem routed by severity and root cause entity type",
+			receivedEvent: "./testdata/open_problem_v2/received_ce.json",
+			routingConfig: &config.ProblemRoutingConfig{
+				Rules: []config.ProblemRoutingRule{
+					{SeverityLevel: "AVAILABILITY", Service: "availability"},
+					{SeverityLevel: "performance", RootCauseEntityType: "SERVICE", ManagementZone: "Keptn: sockshop *", Service: "performance"},
+				},
+			},
+			wantEmittedEvent: true,
+			expectedProject:  "sockshop",
+			expectedStage:    "production",
+			expectedService:  "performance",
+		},
+		{
+			name:          "problem routed to another project",
+			receivedEvent: "./testdata/open_problem_v2/received_ce.json",
+			routingConfig: &config.ProblemRoutingConfig{
+				Rules: []config.ProblemRoutingRule{
+					{ManagementZone: "Keptn: sockshop *", Project: "sockshop-ops", Stage: "remediation"},
+				},
+			},
+			wantEmittedEvent: true,
+			expectedProject:  "sockshop-ops",
+			expectedStage:    "remediation",
+			expectedService:  "carts",
+		},
+		{
+			name:          "problem without tags routed using tags of impacted entities",
+			receivedEvent: "./testdata/open_problem_v2_management_zone/received_ce.json",
+			routingConfig: &config.ProblemRoutingConfig{
+				LookupEntityTags: true,
+				Rules: []config.ProblemRoutingRule{
+					{Tags: []string{"app:carts"}, Service: "carts-app"},
+				},
+			},
+			entitiesClient: &entitiesClientMock{
+				entities: []dynatrace.Entity{
+					{
+						EntityID: "SERVICE-XXXXXXXXXXXXX",
+						Tags: []dynatrace.Tag{
+							{Context: "CONTEXTLESS", Key: "keptn_project", Value: "sockshop", StringRepresentation: "keptn_project:sockshop"},
+							{Context: "CONTEXTLESS", Key: "keptn_stage", Value: "staging", StringRepresentation: "keptn_stage:staging"},
+							{Context: "Kubernetes", Key: "app", Value: "carts", StringRepresentation: "[Kubernetes]app:carts"},
+						},
+					},
+				},
+			},
+			wantEmittedEvent: true,
+			expectedProject:  "sockshop",
+			expectedStage:    "staging",
+			expectedService:  "carts-app",
+			expectedTags: []EntityTag{
+				{Context: "CONTEXTLESS", Key: "keptn_project", Value: "sockshop", StringRepresentation: "keptn_project:sockshop"},
+				{Context: "CONTEXTLESS", Key: "keptn_stage", Value: "staging", StringRepresentation: "keptn_stage:staging"},
+				{Context: "Kubernetes", Key: "app", Value: "carts", StringRepresentation: "[Kubernetes]app:carts"},
+			},
+		},
+		{
+			name:          "problem without stage is dropped if entity tag lookup fails",
+			receivedEvent: "./testdata/open_problem_v2_management_zone/received_ce.json",
+			routingConfig: &config.ProblemRoutingConfig{
+				LookupEntityTags: true,
+			},
+			entitiesClient: &entitiesClientMock{
+				err: errors.New("entities API not available"),
+			},
+			wantEmittedEvent: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adapter, err := NewProblemAdapterFromEvent(*readCloudEventFromFile(tt.receivedEvent))
+			require.NoError(t, err)
+
+			var entitiesClient entitiesClientInterface
+			if tt.entitiesClient != nil {
+				entitiesClient = tt.entitiesClient
+			}
+
+			eventSenderClient := &eventSenderClientMock{}
+			err = NewProblemEventHandler(adapter, eventSenderClient, entitiesClient, nil, nil, tt.routingConfig, nil).HandleEvent(context.Background(), context.Background())
+			require.NoError(t, err)
+
+			if !tt.wantEmittedEvent {
+				assert.Empty(t, eventSenderClient.eventSink)
+				return
+			}
+
+			require.Len(t, eventSenderClient.eventSink, 1)
+			assert.Equal(t, "sh.keptn.event."+tt.expectedStage+".remediation.triggered", eventSenderClient.eventSink[0].Type())
+
+			data := RemediationTriggeredEventData{}
+			require.NoError(t, eventSenderClient.eventSink[0].DataAs(&data))
+			assert.Equal(t, tt.expectedProject, data.Project)
+			assert.Equal(t, tt.expectedStage, data.Stage)
+			assert.Equal(t, tt.expectedService, data.Service)
+			if tt.expectedTags != nil {
+				assert.Equal(t, tt.expectedTags, data.ProblemDetails.EntityTags)
+			}
+		})
+	}
+}
+
+func TestMatchesAnyTag(t *testing.T) {
+	tags := []EntityTag{
+		{Context: "Kubernetes", Key: "app", Value: "carts", StringRepresentation: "[Kubernetes]app:carts"},
+		{Key: "important", StringRepresentation: "important"},
+	}
+
+	assert.True(t, matchesAnyTag("app:carts", tags))
+	assert.True(t, matchesAnyTag("[Kubernetes]app:*", tags))
+	assert.True(t, matchesAnyTag("important", tags))
+	assert.True(t, matchesAnyTag("*", tags))
+	assert.False(t, matchesAnyTag("app", tags))
+	assert.False(t, matchesAnyTag("app:cart", tags))
+	assert.False(t, matchesAnyTag("[Environment]app:carts", tags))
+	assert.False(t, matchesAnyTag("*", nil))
+}
+
+func TestMatchesPattern(t *testing.T) {
+	assert.True(t, matchesPattern("Keptn: sockshop *", "Keptn: sockshop production"))
+	assert.True(t, matchesPattern("*production", "Keptn: sockshop production"))
+	assert.True(t, matchesPattern("Keptn: sockshop production", "Keptn: sockshop production"))
+	assert.True(t, matchesPattern("app:(carts)", "app:(carts)"))
+	assert.False(t, matchesPattern("Keptn: sockshop", "Keptn: sockshop production"))
+	assert.False(t, matchesPattern("app:.*", "app:carts"))
+}
+
+type entitiesClientMock struct {
+	entities []dynatrace.Entity
+	err      error
+}
+
+func (m *entitiesClientMock) GetEntitiesWithTagsByIDs(_ context.Context, _ []string) ([]dynatrace.Entity, error) {
+	return m.entities, m.err
+}
@@ -6,13 +6,25 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/keptn-contrib/dynatrace-service/internal/env"
+	"github.com/keptn-contrib/dynatrace-service/internal/metrics"
+	"github.com/keptn-contrib/dynatrace-service/internal/tracing"
 	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const NoStatus = -1
 
+var apiVersionRegex = regexp.MustCompile(`^v[0-9]+$`)
+
 type ClientInterface interface {
 	// Get performs an HTTP get request.
 	Get(ctx context.Context, apiPath string) ([]byte, int, string, error)
@@ -42,18 +54,30 @@ func (e *ClientError) Error() string {
 	return fmt.Sprintf("HTTP client error: %s [%v]", e.message, e.cause)
 }
 
+// Unwrap returns the cause of the ClientError.
+func (e *ClientError) Unwrap() error {
+	return e.cause
+}
+
 type Client struct {
 	httpClient       *http.Client
 	baseURL          string
 	additionalHeader HTTPHeader
+	retryPolicy      RetryPolicy
 }
 
-// NewClient creates a new Client.
+// NewClient creates a new Client using the default retry policy.
 func NewClient(httpClient *http.Client, baseURL string, additionalHeader HTTPHeader) *Client {
+	return NewClientWithRetryPolicy(httpClient, baseURL, additionalHeader, NewDefaultRetryPolicy())
+}
+
+// NewClientWithRetryPolicy creates a new Client using the specified retry policy.
+func NewClientWithRetryPolicy(httpClient *http.Client, baseURL string, additionalHeader HTTPHeader, retryPolicy RetryPolicy) *Client {
 	return &Client{
 		httpClient:       httpClient,
 		baseURL:          baseURL,
 		additionalHeader: additionalHeader,
+		retryPolicy:      retryPolicy,
 	}
 }
 
@@ -83,15 +107,89 @@ func (c *Client) Delete(ctx context.Context, apiPath string) ([]byte, int, strin
 }
 
 // sendRequest makes an API request and returns the response and the status code or an error.
+// Failed attempts are retried according to the retry policy of the client.
 // The response will not contain any data in case of an error.
 func (c *Client) sendRequest(ctx context.Context, apiPath string, method string, body []byte) ([]byte, int, string, error) {
+	ctx, span := tracing.StartSpan(ctx, "HTTP "+method+" "+getEndpoint(apiPath),
+		semconv.HTTPMethodKey.String(method),
+		semconv.HTTPURLKey.String(c.baseURL+apiPath))
+	defer span.End()
+
+	for attempt := 0; ; attempt++ {
+		// the request has to be recreated for every attempt as its body can only be read once
+		req, err := c.createRequest(ctx, apiPath, method, body)
+		if err != nil {
+			return nil, NoStatus, "", err
+		}
 
-	req, err := c.createRequest(ctx, apiPath, method, body)
-	if err != nil {
-		return nil, NoStatus, "", err
+		start := time.Now()
+		responseBody, status, header, err := c.doRequest(req)
+		recordRequestMetrics(method, apiPath, status, time.Since(start))
+
+		decision := c.retryPolicy.decide(attempt, method, status, header, err)
+		if !decision.retry {
+			span.SetAttributes(attribute.Int("http.attempt_count", attempt+1))
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+				return nil, NoStatus, "", err
+			}
+
+			span.SetAttributes(semconv.HTTPStatusCodeKey.Int(status))
+			span.SetStatus(semconv.SpanStatusFromHTTPStatusCode(status))
+			return responseBody, status, req.URL.String(), nil
+		}
+
+		log.WithError(err).WithFields(log.Fields{
+			"method":  method,
+			"url":     req.URL.String(),
+			"status":  status,
+			"attempt": attempt + 1,
+			"delay":   decision.delay,
+		}).Warn("HTTP request failed, will retry")
+
+		span.AddEvent("retry", trace.WithAttributes(
+			attribute.Int("http.attempt", attempt+1),
+			semconv.HTTPStatusCodeKey.Int(status),
+			attribute.String("http.retry_delay", decision.delay.String())))
+
+		if err := sleepContext(ctx, decision.delay); err != nil {
+			clientErr := &ClientError{
+				message: "cancelled while waiting to retry request",
+				cause:   err,
+			}
+			span.RecordError(clientErr)
+			span.SetStatus(codes.Error, clientErr.Error())
+			return nil, NoStatus, "", clientErr
+		}
+	}
+}
+
+// recordRequestMetrics records the outcome and duration of a single attempt of a request.
+func recordRequestMetrics(method string, apiPath string, status int, duration time.Duration) {
+	endpoint := getEndpoint(apiPath)
+
+	statusLabel := "error"
+	if status != NoStatus {
+		statusLabel = strconv.Itoa(status)
+	}
+
+	metrics.DynatraceAPIRequestsTotal.WithLabelValues(method, endpoint, statusLabel).Inc()
+	metrics.DynatraceAPIRequestDurationSeconds.WithLabelValues(method, endpoint).Observe(duration.Seconds())
+}
+
+// getEndpoint reduces an API path to its endpoint by removing the query and any trailing resource IDs, e.g. "/api/config/v1/dashboards/1234?x=y" becomes "/api/config/v1/dashboards".
+// This keeps the number of distinct endpoints low.
+func getEndpoint(apiPath string) string {
+	path := strings.SplitN(apiPath, "?", 2)[0]
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, segment := range segments {
+		if apiVersionRegex.MatchString(segment) && i+1 < len(segments) {
+			return "/" + strings.Join(segments[:i+2], "/")
+		}
 	}
 
-	return c.doRequest(req)
+	return path
 }
 
 // createRequest creates an HTTP request for an API call with appropriate headers including authorization.
@@ -121,10 +219,10 @@ func (c *Client) createRequest(ctx context.Context, apiPath string, method strin
 }
 
 // doRequest performs the request and reads the response.
-func (c *Client) doRequest(req *http.Request) ([]byte, int, string, error) {
+func (c *Client) doRequest(req *http.Request) ([]byte, int, http.Header, error) {
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, NoStatus, "", &ClientError{
+		return nil, NoStatus, nil, &ClientError{
 			message: "failed to send request",
 			cause:   err,
 		}
@@ -133,11 +231,11 @@ func (c *Client) doRequest(req *http.Request) ([]byte, int, string, error) {
 	defer resp.Body.Close()
 	responseBody, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return nil, NoStatus, "", &ClientError{
+		return nil, NoStatus, nil, &ClientError{
 			message: "failed to read response body",
 			cause:   err,
 		}
 	}
 
-	return responseBody, resp.StatusCode, req.URL.String(), nil
+	return responseBody, resp.StatusCode, resp.Header, nil
 }
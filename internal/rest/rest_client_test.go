@@ -0,0 +1,138 @@
+package rest
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testRetryPolicy = RetryPolicy{
+	MaxRetries:     3,
+	InitialBackoff: time.Millisecond,
+	MaxBackoff:     10 * time.Millisecond,
+}
+
+// TestClient_RetriesUntilSuccess tests that a throttled or unavailable GET request is retried and the successful response is returned.
+func TestClient_RetriesUntilSuccess(t *testing.T) {
+	var requestCount int32
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch atomic.AddInt32(&requestCount, 1) {
+		case 1:
+			w.Header().Set(retryAfterHeader, "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte("response"))
+		}
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client := NewClientWithRetryPolicy(server.Client(), server.URL, HTTPHeader{}, testRetryPolicy)
+	body, status, _, err := client.Get(context.Background(), "/api/v2/metrics")
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "response", string(body))
+	assert.EqualValues(t, 3, atomic.LoadInt32(&requestCount))
+}
+
+// TestClient_ReturnsLastResponseAfterMaxRetries tests that the last response is returned once all retries have been used up.
+func TestClient_ReturnsLastResponseAfterMaxRetries(t *testing.T) {
+	var requestCount int32
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requestCount, 1)
+		w.WriteHeader(http.StatusBadGateway)
+		_, _ = w.Write([]byte("bad gateway"))
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client := NewClientWithRetryPolicy(server.Client(), server.URL, HTTPHeader{}, testRetryPolicy)
+	body, status, _, err := client.Get(context.Background(), "/api/v2/metrics")
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadGateway, status)
+	assert.Equal(t, "bad gateway", string(body))
+	assert.EqualValues(t, 4, atomic.LoadInt32(&requestCount))
+}
+
+// TestClient_ResendsBodyOnRetry tests that the request body is sent again with every attempt.
+func TestClient_ResendsBodyOnRetry(t *testing.T) {
+	var requestCount int32
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, "payload", string(body))
+
+		if atomic.AddInt32(&requestCount, 1) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client := NewClientWithRetryPolicy(server.Client(), server.URL, HTTPHeader{}, testRetryPolicy)
+	_, status, _, err := client.Post(context.Background(), "/api/v2/events/ingest", []byte("payload"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, status)
+	assert.EqualValues(t, 2, atomic.LoadInt32(&requestCount))
+}
+
+// TestClient_StopsRetryingWhenContextIsDone tests that waiting for a retry is aborted once the context is done.
+func TestClient_StopsRetryingWhenContextIsDone(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	policy := RetryPolicy{
+		MaxRetries:     3,
+		InitialBackoff: time.Hour,
+		MaxBackoff:     time.Hour,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	client := NewClientWithRetryPolicy(server.Client(), server.URL, HTTPHeader{}, policy)
+	_, status, _, err := client.Get(ctx, "/api/v2/metrics")
+
+	assert.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, NoStatus, status)
+}
+
+func Test_getEndpoint(t *testing.T) {
+	tests := []struct {
+		apiPath string
+		want    string
+	}{
+		{apiPath: "/api/v2/metrics/query?metricSelector=builtin:service.response.time", want: "/api/v2/metrics"},
+		{apiPath: "/api/v2/metrics/builtin:service.response.time", want: "/api/v2/metrics"},
+		{apiPath: "/api/config/v1/dashboards", want: "/api/config/v1/dashboards"},
+		{apiPath: "/api/config/v1/dashboards/12345678-1111-4444-8888-123456789012", want: "/api/config/v1/dashboards"},
+		{apiPath: "/api/v1/events", want: "/api/v1/events"},
+		{apiPath: "/api/unversioned", want: "/api/unversioned"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.apiPath, func(t *testing.T) {
+			assert.Equal(t, tt.want, getEndpoint(tt.apiPath))
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/env"
+)
+
+const (
+	retryAfterHeader       = "Retry-After"
+	rateLimitResetHeader   = "X-RateLimit-Reset"
+	defaultRetryMultiplier = 2.0
+)
+
+// RetryPolicy defines if and how failed requests should be retried.
+type RetryPolicy struct {
+	// MaxRetries is the maximum number of retries after the initial attempt. Zero disables retrying.
+	MaxRetries int
+
+	// InitialBackoff is the upper bound of the delay before the first retry.
+	InitialBackoff time.Duration
+
+	// MaxBackoff is the maximum delay between two attempts, also when requested by the server.
+	MaxBackoff time.Duration
+
+	// RetryNonIdempotentMethods allows POST and PATCH requests to be retried after transport errors or server errors.
+	RetryNonIdempotentMethods bool
+}
+
+// NewDefaultRetryPolicy creates a RetryPolicy configured using environment variables.
+func NewDefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries:                env.GetAPIMaxRetries(),
+		InitialBackoff:            env.GetAPIRetryInitialBackoff(),
+		MaxBackoff:                env.GetAPIRetryMaxBackoff(),
+		RetryNonIdempotentMethods: env.IsAPIRetryOfNonIdempotentRequestsEnabled(),
+	}
+}
+
+// NewNoRetryPolicy creates a RetryPolicy that never retries.
+func NewNoRetryPolicy() RetryPolicy {
+	return RetryPolicy{}
+}
+
+// retryDecision describes whether and after which delay a request should be retried.
+type retryDecision struct {
+	retry bool
+	delay time.Duration
+}
+
+// decide determines whether the attempt (counting from zero) should be retried based on the outcome of the request.
+func (p RetryPolicy) decide(attempt int, method string, status int, header http.Header, err error) retryDecision {
+	if attempt >= p.MaxRetries {
+		return retryDecision{}
+	}
+
+	if !p.isRetryableOutcome(method, status, err) {
+		return retryDecision{}
+	}
+
+	delay, found := getDelayFromHeader(header, time.Now())
+	if !found {
+		return retryDecision{retry: true, delay: p.getBackoff(attempt)}
+	}
+
+	// don't retry if the server asks us to wait longer than we are willing to
+	if delay > p.MaxBackoff {
+		return retryDecision{}
+	}
+
+	return retryDecision{retry: true, delay: delay}
+}
+
+// isRetryableOutcome returns true if the request failed in a way that is worth retrying.
+// Requests rejected with 429 are never processed by the server, so these may be retried regardless of the method.
+func (p RetryPolicy) isRetryableOutcome(method string, status int, err error) bool {
+	if err != nil {
+		// cancellation or deadline of the caller is final
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return false
+		}
+		return p.isRetryableMethod(method)
+	}
+
+	switch status {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return p.isRetryableMethod(method)
+	default:
+		return false
+	}
+}
+
+func (p RetryPolicy) isRetryableMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return p.RetryNonIdempotentMethods
+	}
+}
+
+// getBackoff returns an exponential backoff with full jitter for the specified attempt.
+func (p RetryPolicy) getBackoff(attempt int) time.Duration {
+	maxDelay := float64(p.InitialBackoff) * math.Pow(defaultRetryMultiplier, float64(attempt))
+	if maxDelay > float64(p.MaxBackoff) {
+		maxDelay = float64(p.MaxBackoff)
+	}
+
+	if maxDelay <= 0 {
+		return 0
+	}
+
+	return time.Duration(rand.Int63n(int64(maxDelay) + 1))
+}
+
+// getDelayFromHeader gets the delay requested by the server via the Retry-After or Dynatrace's X-RateLimit-Reset header.
+// Retry-After may either be a number of seconds or an HTTP date, X-RateLimit-Reset is a Unix timestamp in microseconds.
+func getDelayFromHeader(header http.Header, now time.Time) (time.Duration, bool) {
+	if header == nil {
+		return 0, false
+	}
+
+	if retryAfter := header.Get(retryAfterHeader); retryAfter != "" {
+		if seconds, err := strconv.ParseInt(retryAfter, 10, 64); err == nil {
+			return nonNegativeDuration(time.Duration(seconds) * time.Second), true
+		}
+
+		if date, err := http.ParseTime(retryAfter); err == nil {
+			return nonNegativeDuration(date.Sub(now)), true
+		}
+	}
+
+	if reset := header.Get(rateLimitResetHeader); reset != "" {
+		if timestamp, err := strconv.ParseInt(reset, 10, 64); err == nil {
+			return nonNegativeDuration(time.UnixMicro(timestamp).Sub(now)), true
+		}
+	}
+
+	return 0, false
+}
+
+func nonNegativeDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return 0
+	}
+	return d
+}
+
+// sleepContext waits for the specified duration or returns an error if the context is done first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,233 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryPolicy_decide(t *testing.T) {
+	policy := RetryPolicy{
+		MaxRetries:     2,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     10 * time.Second,
+	}
+
+	tests := []struct {
+		name      string
+		policy    RetryPolicy
+		attempt   int
+		method    string
+		status    int
+		header    http.Header
+		err       error
+		wantRetry bool
+	}{
+		{
+			name:      "GET 200 - no retry",
+			policy:    policy,
+			method:    http.MethodGet,
+			status:    http.StatusOK,
+			wantRetry: false,
+		},
+		{
+			name:      "GET 404 - no retry",
+			policy:    policy,
+			method:    http.MethodGet,
+			status:    http.StatusNotFound,
+			wantRetry: false,
+		},
+		{
+			name:      "GET 503 - retry",
+			policy:    policy,
+			method:    http.MethodGet,
+			status:    http.StatusServiceUnavailable,
+			wantRetry: true,
+		},
+		{
+			name:      "DELETE 502 - retry",
+			policy:    policy,
+			method:    http.MethodDelete,
+			status:    http.StatusBadGateway,
+			wantRetry: true,
+		},
+		{
+			name:      "PUT 504 - retry",
+			policy:    policy,
+			method:    http.MethodPut,
+			status:    http.StatusGatewayTimeout,
+			wantRetry: true,
+		},
+		{
+			name:      "POST 503 - no retry of non-idempotent method",
+			policy:    policy,
+			method:    http.MethodPost,
+			status:    http.StatusServiceUnavailable,
+			wantRetry: false,
+		},
+		{
+			name: "POST 503 - retry if non-idempotent methods are enabled",
+			policy: RetryPolicy{
+				MaxRetries:                2,
+				InitialBackoff:            100 * time.Millisecond,
+				MaxBackoff:                10 * time.Second,
+				RetryNonIdempotentMethods: true,
+			},
+			method:    http.MethodPost,
+			status:    http.StatusServiceUnavailable,
+			wantRetry: true,
+		},
+		{
+			name:      "POST 429 - retry as request was not processed",
+			policy:    policy,
+			method:    http.MethodPost,
+			status:    http.StatusTooManyRequests,
+			wantRetry: true,
+		},
+		{
+			name:      "GET transport error - retry",
+			policy:    policy,
+			method:    http.MethodGet,
+			status:    NoStatus,
+			err:       errors.New("connection reset"),
+			wantRetry: true,
+		},
+		{
+			name:      "POST transport error - no retry",
+			policy:    policy,
+			method:    http.MethodPost,
+			status:    NoStatus,
+			err:       errors.New("connection reset"),
+			wantRetry: false,
+		},
+		{
+			name:      "GET context cancelled - no retry",
+			policy:    policy,
+			method:    http.MethodGet,
+			status:    NoStatus,
+			err:       &ClientError{message: "failed to send request", cause: context.Canceled},
+			wantRetry: false,
+		},
+		{
+			name:      "GET 503 - maximum retries reached",
+			policy:    policy,
+			attempt:   2,
+			method:    http.MethodGet,
+			status:    http.StatusServiceUnavailable,
+			wantRetry: false,
+		},
+		{
+			name:      "GET 503 - no retry policy",
+			policy:    NewNoRetryPolicy(),
+			method:    http.MethodGet,
+			status:    http.StatusServiceUnavailable,
+			wantRetry: false,
+		},
+		{
+			name:      "GET 429 - Retry-After exceeds maximum backoff",
+			policy:    policy,
+			method:    http.MethodGet,
+			status:    http.StatusTooManyRequests,
+			header:    createHeader(retryAfterHeader, "60"),
+			wantRetry: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision := tt.policy.decide(tt.attempt, tt.method, tt.status, tt.header, tt.err)
+			assert.Equal(t, tt.wantRetry, decision.retry)
+		})
+	}
+}
+
+func TestRetryPolicy_getBackoff(t *testing.T) {
+	policy := RetryPolicy{
+		MaxRetries:     10,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     time.Second,
+	}
+
+	for attempt := 0; attempt < 10; attempt++ {
+		t.Run(fmt.Sprintf("attempt %d", attempt), func(t *testing.T) {
+			backoff := policy.getBackoff(attempt)
+			assert.GreaterOrEqual(t, backoff, time.Duration(0))
+			assert.LessOrEqual(t, backoff, policy.MaxBackoff)
+			assert.LessOrEqual(t, backoff, policy.InitialBackoff*time.Duration(1<<attempt))
+		})
+	}
+}
+
+func Test_getDelayFromHeader(t *testing.T) {
+	now := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		header    http.Header
+		wantDelay time.Duration
+		wantFound bool
+	}{
+		{
+			name:      "no header",
+			header:    nil,
+			wantFound: false,
+		},
+		{
+			name:      "Retry-After in seconds",
+			header:    createHeader(retryAfterHeader, "5"),
+			wantDelay: 5 * time.Second,
+			wantFound: true,
+		},
+		{
+			name:      "Retry-After as HTTP date",
+			header:    createHeader(retryAfterHeader, now.Add(7*time.Second).Format(http.TimeFormat)),
+			wantDelay: 7 * time.Second,
+			wantFound: true,
+		},
+		{
+			name:      "Retry-After in the past",
+			header:    createHeader(retryAfterHeader, now.Add(-7*time.Second).Format(http.TimeFormat)),
+			wantDelay: 0,
+			wantFound: true,
+		},
+		{
+			name:      "X-RateLimit-Reset in microseconds",
+			header:    createHeader(rateLimitResetHeader, strconv.FormatInt(now.Add(3*time.Second).UnixMicro(), 10)),
+			wantDelay: 3 * time.Second,
+			wantFound: true,
+		},
+		{
+			name: "Retry-After takes precedence over X-RateLimit-Reset",
+			header: createHeader(
+				retryAfterHeader, "2",
+				rateLimitResetHeader, strconv.FormatInt(now.Add(3*time.Second).UnixMicro(), 10)),
+			wantDelay: 2 * time.Second,
+			wantFound: true,
+		},
+		{
+			name:      "invalid Retry-After",
+			header:    createHeader(retryAfterHeader, "soon"),
+			wantFound: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delay, found := getDelayFromHeader(tt.header, now)
+			assert.Equal(t, tt.wantFound, found)
+			assert.Equal(t, tt.wantDelay, delay)
+		})
+	}
+}
+
+func createHeader(keysAndValues ...string) http.Header {
+	header := http.Header{}
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		header.Set(keysAndValues[i], keysAndValues[i+1])
+	}
+	return header
+}
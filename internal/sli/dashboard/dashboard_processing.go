@@ -7,10 +7,12 @@ import (
 	keptncommon "github.com/keptn/go-utils/pkg/lib"
 	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
 	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/keptn-contrib/dynatrace-service/internal/adapter"
 	"github.com/keptn-contrib/dynatrace-service/internal/common"
 	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+	"github.com/keptn-contrib/dynatrace-service/internal/tracing"
 )
 
 func createDefaultSLOScore() keptncommon.SLOScore {
@@ -86,15 +88,25 @@ func (p *Processing) Process(ctx context.Context, dashboard *dynatrace.Dashboard
 				markdownAlreadyProcessed = true
 			}
 		case dynatrace.SLOTileType:
-			result.addTileResults(NewSLOTileProcessing(p.client, p.timeframe).Process(ctx, &tile))
+			result.addTileResults(processTileWithSpan(ctx, &tile, func(ctx context.Context) []TileResult {
+				return NewSLOTileProcessing(p.client, p.timeframe).Process(ctx, &tile)
+			}))
 		case dynatrace.OpenProblemsTileType:
-			result.addTileResults(NewProblemTileProcessing(p.client, p.timeframe).Process(ctx, &tile, dashboard.GetFilter()))
+			result.addTileResults(processTileWithSpan(ctx, &tile, func(ctx context.Context) []TileResult {
+				return NewProblemTileProcessing(p.client, p.timeframe).Process(ctx, &tile, dashboard.GetFilter())
+			}))
 		case dynatrace.DataExplorerTileType:
-			result.addTileResults(NewDataExplorerTileProcessing(p.client, p.eventData, p.customFilters, p.timeframe).Process(ctx, &tile, dashboard.GetFilter()))
+			result.addTileResults(processTileWithSpan(ctx, &tile, func(ctx context.Context) []TileResult {
+				return NewDataExplorerTileProcessing(p.client, p.eventData, p.customFilters, p.timeframe).Process(ctx, &tile, dashboard.GetFilter())
+			}))
 		case dynatrace.CustomChartingTileType:
-			result.addTileResults(NewCustomChartingTileProcessing(p.client, p.eventData, p.customFilters, p.timeframe).Process(ctx, &tile, dashboard.GetFilter()))
+			result.addTileResults(processTileWithSpan(ctx, &tile, func(ctx context.Context) []TileResult {
+				return NewCustomChartingTileProcessing(p.client, p.eventData, p.customFilters, p.timeframe).Process(ctx, &tile, dashboard.GetFilter())
+			}))
 		case dynatrace.USQLTileType:
-			result.addTileResults(NewUSQLTileProcessing(p.client, p.eventData, p.customFilters, p.timeframe).Process(ctx, &tile))
+			result.addTileResults(processTileWithSpan(ctx, &tile, func(ctx context.Context) []TileResult {
+				return NewUSQLTileProcessing(p.client, p.eventData, p.customFilters, p.timeframe).Process(ctx, &tile)
+			}))
 		default:
 			// we do not do markdowns (HEADER) or synthetic tests (SYNTHETIC_TESTS)
 			continue
@@ -103,3 +115,15 @@ func (p *Processing) Process(ctx context.Context, dashboard *dynatrace.Dashboard
 
 	return result, nil
 }
+
+// processTileWithSpan processes a tile as part of a span, so that the time taken by each tile can be traced.
+func processTileWithSpan(ctx context.Context, tile *dynatrace.Tile, process func(ctx context.Context) []TileResult) []TileResult {
+	ctx, span := tracing.StartSpan(ctx, "process "+tile.TileType+" tile",
+		attribute.String("dynatrace.tile.type", tile.TileType),
+		attribute.String("dynatrace.tile.name", tile.Name))
+	defer span.End()
+
+	tileResults := process(ctx)
+	span.SetAttributes(attribute.Int("dynatrace.tile.result_count", len(tileResults)))
+	return tileResults
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/keptn-contrib/dynatrace-service/internal/adapter"
 	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
 	"github.com/keptn-contrib/dynatrace-service/internal/keptn"
+	"github.com/keptn-contrib/dynatrace-service/internal/metrics"
 	"github.com/keptn-contrib/dynatrace-service/internal/sli/dashboard"
 	"github.com/keptn-contrib/dynatrace-service/internal/sli/query"
 	"github.com/keptn-contrib/dynatrace-service/internal/sli/result"
@@ -275,6 +276,10 @@ func (eh *GetSLIEventHandler) sendGetSLIFinishedEvent(sliResults []result.SLIRes
 	// if an error was set - the SLI results will be set to failed and an error message is set to each
 	sliResults = resetSLIResultsInCaseOfError(err, eh.event, sliResults)
 
+	for _, sliResult := range sliResults {
+		metrics.SLIResultsTotal.WithLabelValues(string(sliResult.IndicatorResult)).Inc()
+	}
+
 	log.Info("Finished retrieving SLI results, sending sh.keptn.event.get-sli.finished event now...")
 	return eh.sendEvent(NewSucceededGetSLIFinishedEventFactory(eh.event, sliResults, err))
 }
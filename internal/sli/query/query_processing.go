@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/keptn-contrib/dynatrace-service/internal/adapter"
 	"github.com/keptn-contrib/dynatrace-service/internal/common"
@@ -20,6 +21,7 @@ import (
 	v1secpv2 "github.com/keptn-contrib/dynatrace-service/internal/sli/v1/secpv2"
 	v1slo "github.com/keptn-contrib/dynatrace-service/internal/sli/v1/slo"
 	v1usql "github.com/keptn-contrib/dynatrace-service/internal/sli/v1/usql"
+	"github.com/keptn-contrib/dynatrace-service/internal/tracing"
 )
 
 // Processing representing the processing of custom SLI queries.
@@ -45,6 +47,15 @@ func NewProcessing(client dynatrace.ClientInterface, eventData adapter.EventCont
 // GetSLIResultFromIndicator queries a single SLI value ultimately from the Dynatrace API and returns an SLIResult.
 // TODO: 2022-01-28: Refactoring needed: this is currently SLI v1 format processing, it should moved to the v1 package, separating it from the general logic.
 func (p *Processing) GetSLIResultFromIndicator(ctx context.Context, name string) result.SLIResult {
+	ctx, span := tracing.StartSpan(ctx, "get SLI result", attribute.String("keptn.sli.name", name))
+	defer span.End()
+
+	sliResult := p.getSLIResultFromIndicator(ctx, name)
+	span.SetAttributes(attribute.String("keptn.sli.result", string(sliResult.IndicatorResult)))
+	return sliResult
+}
+
+func (p *Processing) getSLIResultFromIndicator(ctx context.Context, name string) result.SLIResult {
 
 	// first we get the query from the SLI configuration based on its logical name
 	// no default values here anymore if indicator could not be matched (e.g. due to a misspelling) and custom SLIs were defined
@@ -0,0 +1,75 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/env"
+)
+
+const instrumentationName = "github.com/keptn-contrib/dynatrace-service"
+
+const serviceName = "dynatrace-service"
+
+// KeptnContextAttributeKey is the key of the span attribute holding the Keptn context (shkeptncontext) of the processed event.
+const KeptnContextAttributeKey = attribute.Key("shkeptncontext")
+
+// Init sets up the global tracer provider to export spans via OTLP, if tracing is enabled.
+// The exporter is configured using the standard OTEL_EXPORTER_OTLP_* environment variables.
+// The returned function flushes and stops the exporter and should be called before exiting.
+func Init(ctx context.Context) (func(ctx context.Context) error, error) {
+	if !env.IsTracingEnabled() {
+		return func(ctx context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not create OTLP trace exporter: %w", err)
+	}
+
+	res, err := resource.Merge(
+		resource.Default(),
+		resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceNameKey.String(serviceName),
+			semconv.ServiceVersionKey.String(env.GetVersion())))
+	if err != nil {
+		return nil, fmt.Errorf("could not create trace resource: %w", err)
+	}
+
+	tracerProvider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res))
+
+	otel.SetTracerProvider(tracerProvider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	log.Info("Tracing enabled, exporting spans via OTLP")
+	return tracerProvider.Shutdown, nil
+}
+
+// StartSpan starts a new span with the specified name as a child of any span contained in the context.
+// If tracing is disabled, a no-op span is returned.
+func StartSpan(ctx context.Context, spanName string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, spanName, trace.WithAttributes(attributes...))
+}
+
+// EndSpan records the error, if any, and ends the span.
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,198 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/metrics"
+)
+
+// Priority is the priority of a task. Tasks with a higher priority are started before queued tasks with a lower priority.
+type Priority int
+
+const (
+	// PriorityLow is the priority of tasks that are purely informational, e.g. forwarding events to Dynatrace.
+	PriorityLow Priority = iota
+
+	// PriorityHigh is the priority of tasks that Keptn is waiting for, e.g. retrieving SLIs or configuring monitoring.
+	PriorityHigh
+)
+
+// ErrPoolClosed is returned when submitting a task to a pool that has been closed.
+var ErrPoolClosed = errors.New("worker pool is closed")
+
+// Task is a unit of work processed by a Pool.
+type Task struct {
+	// Priority is the priority of the task.
+	Priority Priority
+
+	// Project is the Keptn project of the task, used to limit the number of concurrently running tasks per project. May be empty.
+	Project string
+
+	// Run performs the work.
+	Run func()
+}
+
+// Pool runs submitted tasks using a fixed number of workers.
+// Queued tasks are started in order of priority and then submission, skipping tasks whose project has already reached the maximum number of running tasks.
+type Pool struct {
+	maxTasksPerProject int
+
+	// slots limits the number of queued tasks
+	slots chan struct{}
+
+	mutex        sync.Mutex
+	cond         *sync.Cond
+	queues       map[Priority][]Task
+	runningTasks map[string]int
+	closed       bool
+
+	waitGroup sync.WaitGroup
+}
+
+// NewPool creates and starts a new Pool with the specified number of workers and queue size.
+// If maxTasksPerProject is greater than 0, at most this many tasks of a single project run concurrently.
+func NewPool(workers int, queueSize int, maxTasksPerProject int) *Pool {
+	if workers < 1 {
+		workers = 1
+	}
+	if queueSize < 1 {
+		queueSize = 1
+	}
+
+	p := &Pool{
+		maxTasksPerProject: maxTasksPerProject,
+		slots:              make(chan struct{}, queueSize),
+		queues:             make(map[Priority][]Task),
+		runningTasks:       make(map[string]int),
+	}
+	p.cond = sync.NewCond(&p.mutex)
+
+	p.waitGroup.Add(workers)
+	for i := 0; i < workers; i++ {
+		go p.work()
+	}
+	return p
+}
+
+// Submit queues the task, blocking while the queue is full. It returns an error if the context is done before the task could be queued or if the pool has been closed.
+func (p *Pool) Submit(ctx context.Context, task Task) error {
+	select {
+	case p.slots <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.closed {
+		<-p.slots
+		return ErrPoolClosed
+	}
+
+	p.queues[task.Priority] = append(p.queues[task.Priority], task)
+	metrics.QueuedEvents.Inc()
+	p.cond.Broadcast()
+	return nil
+}
+
+// Close stops accepting new tasks. Tasks that have already been queued are still run.
+func (p *Pool) Close() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.closed = true
+	p.cond.Broadcast()
+}
+
+// Wait blocks until the pool has been closed and all queued tasks have finished.
+func (p *Pool) Wait() {
+	p.waitGroup.Wait()
+}
+
+func (p *Pool) work() {
+	defer p.waitGroup.Done()
+
+	for {
+		task, ok := p.next()
+		if !ok {
+			return
+		}
+
+		p.run(task)
+	}
+}
+
+// next blocks until a queued task can be started and returns it. It returns false if the pool has been closed and no tasks remain.
+func (p *Pool) next() (Task, bool) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for {
+		if task, ok := p.dequeue(); ok {
+			if task.Project != "" {
+				p.runningTasks[task.Project]++
+			}
+			return task, true
+		}
+
+		if p.closed && p.isEmpty() {
+			return Task{}, false
+		}
+
+		p.cond.Wait()
+	}
+}
+
+// dequeue removes and returns the first task of the highest priority whose project is below the limit of running tasks. The caller must hold the mutex.
+func (p *Pool) dequeue() (Task, bool) {
+	for _, priority := range []Priority{PriorityHigh, PriorityLow} {
+		queue := p.queues[priority]
+		for i, task := range queue {
+			if !p.canStart(task) {
+				continue
+			}
+
+			p.queues[priority] = append(queue[:i:i], queue[i+1:]...)
+			<-p.slots
+			metrics.QueuedEvents.Dec()
+			return task, true
+		}
+	}
+	return Task{}, false
+}
+
+func (p *Pool) canStart(task Task) bool {
+	return p.maxTasksPerProject <= 0 || task.Project == "" || p.runningTasks[task.Project] < p.maxTasksPerProject
+}
+
+func (p *Pool) isEmpty() bool {
+	for _, queue := range p.queues {
+		if len(queue) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *Pool) run(task Task) {
+	metrics.InFlightWorkers.Inc()
+	defer func() {
+		metrics.InFlightWorkers.Dec()
+
+		p.mutex.Lock()
+		defer p.mutex.Unlock()
+
+		if task.Project != "" {
+			p.runningTasks[task.Project]--
+			if p.runningTasks[task.Project] == 0 {
+				delete(p.runningTasks, task.Project)
+			}
+		}
+		p.cond.Broadcast()
+	}()
+
+	task.Run()
+}
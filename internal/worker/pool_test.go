@@ -0,0 +1,119 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPool_Priorities tests that queued tasks with a high priority are started before queued tasks with a low priority.
+func TestPool_Priorities(t *testing.T) {
+	pool := NewPool(1, 10, 0)
+
+	// block the only worker until all tasks have been queued
+	release := make(chan struct{})
+	assert.NoError(t, pool.Submit(context.Background(), Task{Priority: PriorityLow, Run: func() { <-release }}))
+	waitForQueuedTasks(t, pool, 0)
+
+	var mutex sync.Mutex
+	var order []string
+	record := func(name string) func() {
+		return func() {
+			mutex.Lock()
+			defer mutex.Unlock()
+			order = append(order, name)
+		}
+	}
+
+	assert.NoError(t, pool.Submit(context.Background(), Task{Priority: PriorityLow, Run: record("low-1")}))
+	assert.NoError(t, pool.Submit(context.Background(), Task{Priority: PriorityHigh, Run: record("high-1")}))
+	assert.NoError(t, pool.Submit(context.Background(), Task{Priority: PriorityLow, Run: record("low-2")}))
+	assert.NoError(t, pool.Submit(context.Background(), Task{Priority: PriorityHigh, Run: record("high-2")}))
+
+	close(release)
+	pool.Close()
+	pool.Wait()
+
+	assert.Equal(t, []string{"high-1", "high-2", "low-1", "low-2"}, order)
+}
+
+// TestPool_MaxTasksPerProject tests that no more than the maximum number of tasks of a project run concurrently, while tasks of other projects are not held up.
+func TestPool_MaxTasksPerProject(t *testing.T) {
+	pool := NewPool(4, 10, 2)
+
+	var mutex sync.Mutex
+	running := map[string]int{}
+	maxRunning := map[string]int{}
+	release := make(chan struct{})
+	otherProjectDone := make(chan struct{})
+
+	task := func(project string) Task {
+		return Task{
+			Priority: PriorityHigh,
+			Project:  project,
+			Run: func() {
+				mutex.Lock()
+				running[project]++
+				if running[project] > maxRunning[project] {
+					maxRunning[project] = running[project]
+				}
+				mutex.Unlock()
+
+				if project == "other" {
+					close(otherProjectDone)
+				} else {
+					<-release
+				}
+
+				mutex.Lock()
+				running[project]--
+				mutex.Unlock()
+			},
+		}
+	}
+
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, pool.Submit(context.Background(), task("busy")))
+	}
+	assert.NoError(t, pool.Submit(context.Background(), task("other")))
+
+	select {
+	case <-otherProjectDone:
+	case <-time.After(5 * time.Second):
+		t.Fatal("task of other project was not started")
+	}
+
+	close(release)
+	pool.Close()
+	pool.Wait()
+
+	assert.Equal(t, 2, maxRunning["busy"])
+}
+
+// TestPool_Submit tests that submitting blocks while the queue is full and fails once the pool is closed.
+func TestPool_Submit(t *testing.T) {
+	pool := NewPool(1, 1, 0)
+
+	release := make(chan struct{})
+	assert.NoError(t, pool.Submit(context.Background(), Task{Run: func() { <-release }}))
+	waitForQueuedTasks(t, pool, 0)
+	assert.NoError(t, pool.Submit(context.Background(), Task{Run: func() {}}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, pool.Submit(ctx, Task{Run: func() {}}), context.DeadlineExceeded)
+
+	close(release)
+	pool.Close()
+	assert.ErrorIs(t, pool.Submit(context.Background(), Task{Run: func() {}}), ErrPoolClosed)
+	pool.Wait()
+}
+
+func waitForQueuedTasks(t *testing.T, pool *Pool, count int) {
+	assert.Eventually(t, func() bool {
+		return len(pool.slots) == count
+	}, 5*time.Second, time.Millisecond)
+}